@@ -2,24 +2,140 @@ package datadog
 
 import (
 	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 )
 
+// syntheticsPrivateLocationsWorkersQuery is the number of running workers reported by each private location
+const syntheticsPrivateLocationsWorkersQuery = "sum:synthetics.pl.worker.running{*} by {location}"
+
+// syntheticsLocationGeographies maps the region prefixes of cloud providers to geographical areas
+var syntheticsLocationGeographies = []struct {
+	geography string
+	prefixes  []string
+}{
+	{"americas", []string{"us", "ca", "sa", "mx", "northamerica", "southamerica", "brazil", "canada", "mexico", "chile"}},
+	{"europe", []string{"eu", "europe", "uk", "france", "germany", "norway", "switzerland", "sweden", "poland", "italy", "spain"}},
+	{"asia_pacific", []string{"ap", "asia", "australia", "japan", "korea", "india"}},
+	{"middle_east", []string{"me", "il", "uae", "qatar", "israel"}},
+	{"africa", []string{"af", "africa", "southafrica"}},
+}
+
 func dataSourceDatadogSyntheticsLocations() *schema.Resource {
 	return &schema.Resource{
-		Description: "Use this data source to retrieve Datadog's Synthetics Locations (to be used in Synthetics tests).",
+		Description: "Use this data source to retrieve Datadog's Synthetics Locations (to be used in Synthetics tests), optionally filtered by provider, region, type and availability.",
 		ReadContext: dataSourceDatadogSyntheticsLocationsRead,
 
 		SchemaFunc: func() map[string]*schema.Schema {
 			// Locations are a map of IDs to names
 			return map[string]*schema.Schema{
+				// Filters
+				"provider_filter": {
+					Description: "Only keep the managed locations hosted by one of these cloud providers, for example `aws`, `azure` or `gcp`. Private locations are excluded when set.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"region_filter": {
+					Description: "Only keep the managed locations in one of these regions, either a region of the provider such as `eu-central-1`, or a geographical area among `americas`, `europe`, `asia_pacific`, `middle_east` and `africa`. Private locations are excluded when set.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"type_filter": {
+					Description:  "Only keep the `managed` or the `private` locations.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{"managed", "private"}, false),
+				},
+				"private_location_tags": {
+					Description: "Only keep the private locations having all these tags. Managed locations are kept.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"private_location_details": {
+					Description: "Whether to retrieve the description and tags of the private locations, which requires a request per private location. Always retrieved when `private_location_tags` is set.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"available_only": {
+					Description: "Only keep the private locations with at least one worker running during the last 15 minutes. Managed locations are kept.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				// Computed values
 				"locations": {
 					Description: "A map of available Synthetics location IDs to names for Synthetics tests.",
 					Type:        schema.TypeMap,
 					Computed:    true,
 				},
+				"location_ids": {
+					Description: "The sorted IDs of the locations, to be used as `locations` of Synthetics tests.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"location": {
+					Description: "Details of the locations.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Description: "ID of the location.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"name": {
+								Description: "Name of the location.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"is_private": {
+								Description: "Whether the location is a private location.",
+								Type:        schema.TypeBool,
+								Computed:    true,
+							},
+							"provider": {
+								Description: "Cloud provider hosting the managed location.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"region": {
+								Description: "Region of the cloud provider hosting the managed location.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"geography": {
+								Description: "Geographical area of the managed location.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"description": {
+								Description: "Description of the private location. Only set when `private_location_details` or `private_location_tags` is set.",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"tags": {
+								Description: "Tags of the private location. Only set when `private_location_details` or `private_location_tags` is set.",
+								Type:        schema.TypeList,
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
 			}
 		},
 	}
@@ -36,15 +152,176 @@ func dataSourceDatadogSyntheticsLocationsRead(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
+	providers := d.Get("provider_filter").(*schema.Set)
+	regions := d.Get("region_filter").(*schema.Set)
+	locationType := d.Get("type_filter").(string)
+
+	privateLocationTags := d.Get("private_location_tags").([]interface{})
+	privateLocationDetails := d.Get("private_location_details").(bool) || len(privateLocationTags) > 0
+
+	var runningPrivateLocations map[string]bool
+	if d.Get("available_only").(bool) {
+		now := providerConf.Now()
+		metrics, httpresp, err := apiInstances.GetMetricsApiV1().QueryMetrics(auth, now.Add(-15*time.Minute).Unix(), now.Unix(), syntheticsPrivateLocationsWorkersQuery)
+		if err != nil {
+			return utils.TranslateClientErrorDiag(err, httpresp, "error querying synthetics private location workers")
+		}
+		runningPrivateLocations = make(map[string]bool)
+		for _, series := range metrics.GetSeries() {
+			if syntheticsLatestPointValue(series.GetPointlist()) > 0 {
+				for _, tag := range series.GetTagSet() {
+					if location, ok := strings.CutPrefix(tag, "location:"); ok {
+						runningPrivateLocations[location] = true
+					}
+				}
+			}
+		}
+	}
+
 	locationsMap := make(map[string]string)
+	locationIds := make([]string, 0)
+	localLocations := make([]map[string]interface{}, 0)
 	for _, location := range syntheticsLocations.GetLocations() {
-		locationsMap[location.GetId()] = location.GetName()
-	}
+		id := location.GetId()
+		localLocation := map[string]interface{}{
+			"id":   id,
+			"name": location.GetName(),
+		}
 
-	if len(locationsMap) > 0 {
-		d.SetId("datadog-synthetics-location")
+		if strings.HasPrefix(id, "pl:") {
+			if locationType == "managed" || providers.Len() > 0 || regions.Len() > 0 {
+				continue
+			}
+			if runningPrivateLocations != nil && !runningPrivateLocations[id] {
+				continue
+			}
+			localLocation["is_private"] = true
+			if privateLocationDetails {
+				privateLocation, httpresp, err := apiInstances.GetSyntheticsApiV1().GetPrivateLocation(auth, id)
+				if err != nil {
+					if httpresp != nil && httpresp.StatusCode == 404 {
+						// The private location was deleted since the locations were listed
+						continue
+					}
+					return utils.TranslateClientErrorDiag(err, httpresp, "error getting synthetics private location")
+				}
+				if !hasAllTags(privateLocation.GetTags(), privateLocationTags) {
+					continue
+				}
+				localLocation["description"] = privateLocation.GetDescription()
+				localLocation["tags"] = privateLocation.GetTags()
+			}
+		} else {
+			if locationType == "private" {
+				continue
+			}
+			provider, region, _ := strings.Cut(id, ":")
+			geography := syntheticsLocationGeography(region)
+			if providers.Len() > 0 && !providers.Contains(provider) {
+				continue
+			}
+			if regions.Len() > 0 && !regions.Contains(region) && !regions.Contains(geography) {
+				continue
+			}
+			localLocation["is_private"] = false
+			localLocation["provider"] = provider
+			localLocation["region"] = region
+			localLocation["geography"] = geography
+		}
+
+		locationsMap[id] = location.GetName()
+		locationIds = append(locationIds, id)
+		localLocations = append(localLocations, localLocation)
 	}
+
+	sort.Strings(locationIds)
+	sort.SliceStable(localLocations, func(i, j int) bool {
+		return localLocations[i]["id"].(string) < localLocations[j]["id"].(string)
+	})
+
+	d.SetId(computeSyntheticsLocationsDatasourceID(d))
 	d.Set("locations", locationsMap)
+	if err := d.Set("location_ids", locationIds); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("location", localLocations); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
+
+func computeSyntheticsLocationsDatasourceID(d *schema.ResourceData) string {
+	var dsID strings.Builder
+	dsID.WriteString("datadog-synthetics-location")
+	for _, filter := range []string{"provider_filter", "region_filter"} {
+		dsID.WriteRune('|')
+		values := expandStringList(d.Get(filter).(*schema.Set).List())
+		sort.Strings(values)
+		dsID.WriteString(strings.Join(values, ","))
+	}
+	dsID.WriteRune('|')
+	dsID.WriteString(d.Get("type_filter").(string))
+	dsID.WriteRune('|')
+	dsID.WriteString(strconv.FormatBool(d.Get("available_only").(bool)))
+	dsID.WriteRune('|')
+	dsID.WriteString(strings.Join(expandStringList(d.Get("private_location_tags").([]interface{})), ","))
+	return dsID.String()
+}
+
+// syntheticsLocationGeography returns the geographical area of a region of a cloud provider, such as
+// `eu-central-1` for AWS, `europe-west3` for GCP or `westeurope` for Azure.
+func syntheticsLocationGeography(region string) string {
+	if !strings.Contains(region, "-") {
+		// Azure regions are not split, e.g. `southafricanorth`, so the most specific names are checked first
+		for _, name := range []string{"southafrica", "uae", "qatar", "israel", "australia", "japan", "korea", "india", "asia", "europe", "uk", "france", "germany", "norway", "switzerland", "sweden", "poland", "italy", "spain", "brazil", "canada", "mexico", "chile", "us"} {
+			if strings.Contains(region, name) {
+				return syntheticsLocationGeographyFromPrefix(name)
+			}
+		}
+	}
+	prefix, _, _ := strings.Cut(region, "-")
+	return syntheticsLocationGeographyFromPrefix(prefix)
+}
+
+func syntheticsLocationGeographyFromPrefix(prefix string) string {
+	for _, g := range syntheticsLocationGeographies {
+		for _, p := range g.prefixes {
+			if p == prefix {
+				return g.geography
+			}
+		}
+	}
+	return ""
+}
+
+// syntheticsLatestPointValue returns the value of the latest point of a series
+func syntheticsLatestPointValue(points [][]*float64) float64 {
+	var latest, value float64
+	for _, point := range points {
+		if len(point) < 2 || point[0] == nil || point[1] == nil {
+			continue
+		}
+		if *point[0] >= latest {
+			latest, value = *point[0], *point[1]
+		}
+	}
+	return value
+}
+
+// hasAllTags returns whether all the expected tags are in the list of tags
+func hasAllTags(tags []string, expectedTags []interface{}) bool {
+	for _, expected := range expectedTags {
+		found := false
+		for _, tag := range tags {
+			if tag == expected.(string) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
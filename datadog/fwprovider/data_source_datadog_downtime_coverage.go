@@ -0,0 +1,233 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
+)
+
+// maxDowntimeCoverageWindows bounds the number of windows listed per downtime
+const maxDowntimeCoverageWindows = 100
+
+var (
+	_ datasource.DataSource = &datadogDowntimeCoverageDataSource{}
+)
+
+type datadogDowntimeCoverageDataSourceModel struct {
+	// Query Parameters
+	MonitorID   types.Int64  `tfsdk:"monitor_id"`
+	MonitorTags types.Set    `tfsdk:"monitor_tags"`
+	From        types.String `tfsdk:"from"`
+	To          types.String `tfsdk:"to"`
+	// Results
+	ID        types.String             `tfsdk:"id"`
+	Downtimes []*downtimeCoverageModel `tfsdk:"downtimes"`
+}
+
+type downtimeCoverageModel struct {
+	ID              types.String `tfsdk:"id"`
+	Scope           types.String `tfsdk:"scope"`
+	Message         types.String `tfsdk:"message"`
+	DisplayTimezone types.String `tfsdk:"display_timezone"`
+	Status          types.String `tfsdk:"status"`
+	Windows         types.List   `tfsdk:"windows"`
+}
+
+func NewDatadogDowntimeCoverageDataSource() datasource.DataSource {
+	return &datadogDowntimeCoverageDataSource{}
+}
+
+type datadogDowntimeCoverageDataSource struct {
+	Api         *datadogV2.DowntimesApi
+	MonitorsApi *datadogV1.MonitorsApi
+	Auth        context.Context
+	Now         func() time.Time
+}
+
+func (d *datadogDowntimeCoverageDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetDowntimesApiV2()
+	d.MonitorsApi = providerData.DatadogApiInstances.GetMonitorsApiV1()
+	d.Auth = providerData.Auth
+	d.Now = providerData.Now
+}
+
+func (d *datadogDowntimeCoverageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "downtime_coverage"
+}
+
+func (d *datadogDowntimeCoverageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list the downtimes muting a monitor during a time range, along with the windows during which they are active.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"monitor_id": schema.Int64Attribute{
+				Description: "ID of the monitor to check. Downtimes targeting this monitor, or tags applied to it, are returned.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.ExactlyOneOf(path.MatchRoot("monitor_tags"))},
+			},
+			"monitor_tags": schema.SetAttribute{
+				Description: "Monitor tags to check. Downtimes whose `monitor_tags` are all part of this list are returned.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"from": schema.StringAttribute{
+				Description: "RFC 3339 timestamp of the beginning of the time range. Defaults to now.",
+				Optional:    true,
+				Validators:  []validator.String{validators.TimeFormatValidator(time.RFC3339)},
+			},
+			"to": schema.StringAttribute{
+				Description: "RFC 3339 timestamp of the end of the time range.",
+				Required:    true,
+				Validators:  []validator.String{validators.TimeFormatValidator(time.RFC3339)},
+			},
+			// Computed values
+			"downtimes": schema.ListAttribute{
+				Computed:    true,
+				Description: "List of downtimes active during the time range. `windows` lists the active windows overlapping the time range, resolved in the downtime's `display_timezone`.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":               types.StringType,
+						"scope":            types.StringType,
+						"message":          types.StringType,
+						"display_timezone": types.StringType,
+						"status":           types.StringType,
+						"windows":          types.ListType{ElemType: downtimeActiveWindowType},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *datadogDowntimeCoverageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogDowntimeCoverageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	from := d.Now()
+	if !state.From.IsNull() {
+		from, _ = time.Parse(time.RFC3339, state.From.ValueString())
+	}
+	to, _ := time.Parse(time.RFC3339, state.To.ValueString())
+	if !to.After(from) {
+		resp.Diagnostics.AddAttributeError(path.Root("to"), "invalid time range", "`to` must be after `from`")
+		return
+	}
+
+	var monitorTags []string
+	if !state.MonitorID.IsNull() {
+		monitor, _, err := d.MonitorsApi.GetMonitor(d.Auth, state.MonitorID.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error getting monitor"))
+			return
+		}
+		monitorTags = monitor.GetTags()
+	} else {
+		resp.Diagnostics.Append(state.MonitorTags.ElementsAs(ctx, &monitorTags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	downtimes := make([]*downtimeCoverageModel, 0)
+	response, _ := d.Api.ListDowntimesWithPagination(d.Auth)
+	for paginationResult := range response {
+		if paginationResult.Error != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(paginationResult.Error, "error listing downtimes"))
+			return
+		}
+
+		item := paginationResult.Item
+		attributes := item.GetAttributes()
+		if status := attributes.GetStatus(); status == "canceled" || status == "ended" {
+			continue
+		}
+
+		var downtime DowntimeScheduleModel
+		(&DowntimeScheduleResource{}).updateStateFromData(ctx, &downtime, item)
+		if !downtimeAppliesToMonitor(ctx, downtime.MonitorIdentifier, state.MonitorID, monitorTags) {
+			continue
+		}
+
+		windows, _, err := buildDowntimeActiveWindows(downtime.DowntimeScheduleRecurrenceSchedule, downtime.DowntimeScheduleOneTimeSchedule, from, to, maxDowntimeCoverageWindows)
+		if err != nil {
+			resp.Diagnostics.AddWarning(fmt.Sprintf("unable to compute the active windows of downtime %s", item.GetId()), err.Error())
+			continue
+		}
+		if len(windows) == 0 {
+			continue
+		}
+
+		windowsValue, diags := flattenDowntimeActiveWindows(ctx, windows, downtime.DisplayTimezone)
+		resp.Diagnostics.Append(diags...)
+		downtimes = append(downtimes, &downtimeCoverageModel{
+			ID:              types.StringValue(item.GetId()),
+			Scope:           downtime.Scope,
+			Message:         types.StringValue(attributes.GetMessage()),
+			DisplayTimezone: types.StringValue(downtime.DisplayTimezone.ValueString()),
+			Status:          types.StringValue(string(attributes.GetStatus())),
+			Windows:         windowsValue,
+		})
+	}
+
+	identifier := state.MonitorID.String()
+	if state.MonitorID.IsNull() {
+		identifier = strings.Join(monitorTags, ",")
+	}
+	state.ID = types.StringValue(utils.ConvertToSha256(fmt.Sprintf("%s:%s:%s", identifier, from.Format(time.RFC3339), to.Format(time.RFC3339))))
+	state.Downtimes = downtimes
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// downtimeAppliesToMonitor reports whether a downtime mutes a monitor with the given ID and tags. Downtimes defined
+// with monitor tags apply to monitors which have all of them, `*` matching every monitor.
+func downtimeAppliesToMonitor(ctx context.Context, identifier *MonitorIdentifierModel, monitorID types.Int64, monitorTags []string) bool {
+	if identifier == nil {
+		return false
+	}
+	if !identifier.DowntimeMonitorIdentifierId.IsNull() {
+		return !monitorID.IsNull() && identifier.DowntimeMonitorIdentifierId.ValueInt64() == monitorID.ValueInt64()
+	}
+
+	var downtimeTags []string
+	identifier.DowntimeMonitorIdentifierTags.ElementsAs(ctx, &downtimeTags, false)
+	if len(downtimeTags) == 0 {
+		return false
+	}
+	for _, tag := range downtimeTags {
+		if tag == "*" {
+			continue
+		}
+		found := false
+		for _, monitorTag := range monitorTags {
+			if monitorTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/logsquery"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogLogsCustomDestinationsDataSource{}
+)
+
+var logsCustomDestinationStatusType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":       types.StringType,
+		"name":     types.StringType,
+		"type":     types.StringType,
+		"endpoint": types.StringType,
+		"enabled":  types.BoolType,
+		"status":   types.StringType,
+		"errors":   types.ListType{ElemType: types.StringType},
+	},
+}
+
+type datadogLogsCustomDestinationsDataSourceModel struct {
+	// Query Parameters
+	IDs types.List `tfsdk:"ids"`
+	// Results
+	ID           types.String `tfsdk:"id"`
+	Destinations types.List   `tfsdk:"destinations"`
+}
+
+func NewDatadogLogsCustomDestinationsDataSource() datasource.DataSource {
+	return &datadogLogsCustomDestinationsDataSource{}
+}
+
+type datadogLogsCustomDestinationsDataSource struct {
+	Api  *datadogV2.LogsCustomDestinationsApi
+	Auth context.Context
+}
+
+func (d *datadogLogsCustomDestinationsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetLogsCustomDestinationsApiV2()
+	d.Auth = providerData.Auth
+}
+
+func (d *datadogLogsCustomDestinationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "logs_custom_destinations"
+}
+
+func (d *datadogLogsCustomDestinationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to retrieve the status of logs custom destinations and the configuration errors detected by the provider. The API does not report delivery failures, so a destination rejecting the logs is not detected.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"ids": schema.ListAttribute{
+				Description: "IDs of the custom destinations to report. Defaults to all the custom destinations.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			// Computed values
+			"destinations": schema.ListAttribute{
+				Computed:    true,
+				Description: "Status of the custom destinations, sorted by name. `type` is one of `http`, `splunk_hec` and `elasticsearch`. `status` is `disabled` for disabled destinations, `misconfigured` when `errors` lists configuration errors, such as an invalid query or an endpoint not using HTTPS, and `enabled` otherwise.",
+				ElementType: logsCustomDestinationStatusType,
+			},
+		},
+	}
+}
+
+func (d *datadogLogsCustomDestinationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogLogsCustomDestinationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.IDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destinationsResp, httpResp, err := d.Api.ListLogsCustomDestinations(d.Auth)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error listing custom destinations"))
+		return
+	}
+	var definitions []datadogV2.CustomDestinationResponseDefinition
+	for _, definition := range destinationsResp.GetData() {
+		if len(ids) == 0 || slices.Contains(ids, definition.GetId()) {
+			definitions = append(definitions, definition)
+		}
+	}
+	slices.SortFunc(definitions, func(a, b datadogV2.CustomDestinationResponseDefinition) int {
+		attributesA, attributesB := a.GetAttributes(), b.GetAttributes()
+		return strings.Compare(attributesA.GetName(), attributesB.GetName())
+	})
+
+	destinations := make([]attr.Value, 0, len(definitions))
+	for _, definition := range definitions {
+		attributes := definition.GetAttributes()
+		destinationType, endpoint, errors := checkLogsCustomDestination(attributes)
+
+		status := "enabled"
+		switch {
+		case !attributes.GetEnabled():
+			status = "disabled"
+		case len(errors) > 0:
+			status = "misconfigured"
+		}
+
+		tfErrors, diags := types.ListValueFrom(ctx, types.StringType, errors)
+		resp.Diagnostics.Append(diags...)
+		destination, diags := types.ObjectValue(logsCustomDestinationStatusType.AttrTypes, map[string]attr.Value{
+			"id":       types.StringValue(definition.GetId()),
+			"name":     types.StringValue(attributes.GetName()),
+			"type":     types.StringValue(destinationType),
+			"endpoint": types.StringValue(endpoint),
+			"enabled":  types.BoolValue(attributes.GetEnabled()),
+			"status":   types.StringValue(status),
+			"errors":   tfErrors,
+		})
+		resp.Diagnostics.Append(diags...)
+		destinations = append(destinations, destination)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(utils.ConvertToSha256(strings.Join(ids, ",")))
+	state.Destinations, _ = types.ListValue(logsCustomDestinationStatusType, destinations)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// checkLogsCustomDestination returns the type and endpoint of a custom destination, and the configuration errors
+// preventing it from receiving logs.
+func checkLogsCustomDestination(attributes datadogV2.CustomDestinationResponseAttributes) (string, string, []string) {
+	errors := make([]string, 0)
+	if _, err := logsquery.Parse(attributes.GetQuery()); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid query: %s", err))
+	}
+
+	var destinationType, endpoint string
+	forwarderDestination := attributes.GetForwarderDestination()
+	switch {
+	case forwarderDestination.CustomDestinationResponseForwardDestinationHttp != nil:
+		destinationType = string(forwarderDestination.CustomDestinationResponseForwardDestinationHttp.GetType())
+		endpoint = forwarderDestination.CustomDestinationResponseForwardDestinationHttp.GetEndpoint()
+	case forwarderDestination.CustomDestinationResponseForwardDestinationSplunk != nil:
+		destinationType = string(forwarderDestination.CustomDestinationResponseForwardDestinationSplunk.GetType())
+		endpoint = forwarderDestination.CustomDestinationResponseForwardDestinationSplunk.GetEndpoint()
+	case forwarderDestination.CustomDestinationResponseForwardDestinationElasticsearch != nil:
+		elasticsearch := forwarderDestination.CustomDestinationResponseForwardDestinationElasticsearch
+		destinationType = string(elasticsearch.GetType())
+		endpoint = elasticsearch.GetEndpoint()
+		if elasticsearch.GetIndexName() == "" {
+			errors = append(errors, "missing Elasticsearch index name")
+		}
+	default:
+		return "unknown", "", append(errors, "destination type not supported by the provider")
+	}
+
+	if u, err := url.Parse(endpoint); err != nil || u.Host == "" {
+		errors = append(errors, fmt.Sprintf("invalid endpoint %q", endpoint))
+	} else if u.Scheme != "https" {
+		errors = append(errors, fmt.Sprintf("endpoint %q does not use HTTPS", endpoint))
+	}
+	return destinationType, endpoint, errors
+}
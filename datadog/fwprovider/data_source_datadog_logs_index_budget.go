@@ -0,0 +1,197 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogLogsIndexBudgetDataSource{}
+)
+
+var logsIndexBudgetType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":        types.StringType,
+		"daily_limit": types.Int64Type,
+		"daily_limit_warning_threshold_percentage": types.Float64Type,
+		"retention_days":                   types.Int64Type,
+		"flex_retention_days":              types.Int64Type,
+		"indexed_events":                   types.Int64Type,
+		"average_daily_events":             types.Float64Type,
+		"peak_daily_events":                types.Int64Type,
+		"peak_daily_limit_percentage":      types.Float64Type,
+		"projected_monthly_events":         types.Float64Type,
+		"projected_monthly_retention_cost": types.Float64Type,
+	},
+}
+
+type datadogLogsIndexBudgetDataSourceModel struct {
+	// Query Parameters
+	IndexNames            types.List  `tfsdk:"index_names"`
+	Days                  types.Int64 `tfsdk:"days"`
+	PricePerMillionEvents types.Map   `tfsdk:"price_per_million_events"`
+	// Results
+	ID      types.String `tfsdk:"id"`
+	Indexes types.List   `tfsdk:"indexes"`
+}
+
+func NewDatadogLogsIndexBudgetDataSource() datasource.DataSource {
+	return &datadogLogsIndexBudgetDataSource{}
+}
+
+type datadogLogsIndexBudgetDataSource struct {
+	Api      *datadogV1.LogsIndexesApi
+	UsageApi *datadogV1.UsageMeteringApi
+	Auth     context.Context
+	Now      func() time.Time
+}
+
+func (d *datadogLogsIndexBudgetDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetLogsIndexesApiV1()
+	d.UsageApi = providerData.DatadogApiInstances.GetUsageMeteringApiV1()
+	d.Auth = providerData.Auth
+	d.Now = providerData.Now
+}
+
+func (d *datadogLogsIndexBudgetDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "logs_index_budget"
+}
+
+func (d *datadogLogsIndexBudgetDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to compare the volume recently indexed by logs indexes with their daily limit, and project their monthly retention cost.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"index_names": schema.ListAttribute{
+				Description: "Names of the indexes to report. Defaults to all the indexes.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"days": schema.Int64Attribute{
+				Description: "Number of complete days of usage to report, up to yesterday. Defaults to `7`.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 31)},
+			},
+			"price_per_million_events": schema.MapAttribute{
+				Description: "Price of a million indexed events, keyed by number of retention days, for example `{ \"15\" = 1.70, \"30\" = 2.50 }`. Required to project the monthly retention cost.",
+				Optional:    true,
+				ElementType: types.Float64Type,
+			},
+			// Computed values
+			"indexes": schema.ListAttribute{
+				Computed:    true,
+				Description: "Budget of the indexes, sorted by name. `peak_daily_limit_percentage` and `daily_limit` are not set for indexes without daily limit, `projected_monthly_retention_cost` is not set without a price for the retention of the index. The monthly volume is projected from the average daily volume, capped by the daily limit.",
+				ElementType: logsIndexBudgetType,
+			},
+		},
+	}
+}
+
+func (d *datadogLogsIndexBudgetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogLogsIndexBudgetDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var indexNames []string
+	prices := make(map[string]float64)
+	resp.Diagnostics.Append(state.IndexNames.ElementsAs(ctx, &indexNames, false)...)
+	resp.Diagnostics.Append(state.PricePerMillionEvents.ElementsAs(ctx, &prices, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	days := 7
+	if !state.Days.IsNull() {
+		days = int(state.Days.ValueInt64())
+	}
+
+	indexesResp, httpResp, err := d.Api.ListLogIndexes(d.Auth)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error listing logs indexes"))
+		return
+	}
+	var indexes []datadogV1.LogsIndex
+	for _, index := range indexesResp.GetIndexes() {
+		if len(indexNames) == 0 || slices.Contains(indexNames, index.GetName()) {
+			indexes = append(indexes, index)
+		}
+	}
+	slices.SortFunc(indexes, func(a, b datadogV1.LogsIndex) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	usages, httpResp, err := utils.GetLogsIndexUsage(d.Auth, d.UsageApi, indexNames, d.Now(), days)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error getting logs index usage"))
+		return
+	}
+
+	budgets := make([]attr.Value, 0, len(indexes))
+	for _, index := range indexes {
+		usage, ok := usages[index.GetName()]
+		if !ok {
+			usage = &utils.LogsIndexUsage{Days: days}
+		}
+
+		dailyLimit := types.Int64Null()
+		peakDailyLimitPercentage := types.Float64Null()
+		var limit *int64
+		if index.HasDailyLimit() && index.GetDailyLimit() > 0 {
+			limit = index.DailyLimit
+			dailyLimit = types.Int64Value(*limit)
+			peakDailyLimitPercentage = types.Float64Value(float64(usage.PeakDailyEvents) * 100 / float64(*limit))
+		}
+
+		monthlyEvents := utils.ProjectLogsIndexMonthlyEvents(usage.AverageDailyEvents(), limit)
+		monthlyCost := types.Float64Null()
+		if len(prices) > 0 {
+			cost, err := utils.LogsRetentionMonthlyCost(monthlyEvents, index.GetNumRetentionDays(), prices)
+			if err != nil {
+				resp.Diagnostics.AddWarning(fmt.Sprintf("can't project the retention cost of logs index %s", index.GetName()), err.Error())
+			} else {
+				monthlyCost = types.Float64Value(cost)
+			}
+		}
+
+		budget, diags := types.ObjectValue(logsIndexBudgetType.AttrTypes, map[string]attr.Value{
+			"name":        types.StringValue(index.GetName()),
+			"daily_limit": dailyLimit,
+			"daily_limit_warning_threshold_percentage": types.Float64Value(index.GetDailyLimitWarningThresholdPercentage()),
+			"retention_days":                   types.Int64Value(index.GetNumRetentionDays()),
+			"flex_retention_days":              types.Int64Value(index.GetNumFlexLogsRetentionDays()),
+			"indexed_events":                   types.Int64Value(usage.Events),
+			"average_daily_events":             types.Float64Value(usage.AverageDailyEvents()),
+			"peak_daily_events":                types.Int64Value(usage.PeakDailyEvents),
+			"peak_daily_limit_percentage":      peakDailyLimitPercentage,
+			"projected_monthly_events":         types.Float64Value(monthlyEvents),
+			"projected_monthly_retention_cost": monthlyCost,
+		})
+		resp.Diagnostics.Append(diags...)
+		budgets = append(budgets, budget)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(utils.ConvertToSha256(fmt.Sprintf("%s|%d|%s", strings.Join(indexNames, ","), days, state.PricePerMillionEvents.String())))
+	state.Indexes, _ = types.ListValue(logsIndexBudgetType, budgets)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,206 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/logsquery"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogLogsIndexRoutingDataSource{}
+)
+
+type datadogLogsIndexRoutingDataSourceModel struct {
+	// Query Parameters
+	Log        types.String `tfsdk:"log"`
+	Facets     types.Map    `tfsdk:"facets"`
+	IndexNames types.List   `tfsdk:"index_names"`
+	// Results
+	ID                  types.String  `tfsdk:"id"`
+	Index               types.String  `tfsdk:"index"`
+	ExclusionFilter     types.String  `tfsdk:"exclusion_filter"`
+	ExclusionSampleRate types.Float64 `tfsdk:"exclusion_sample_rate"`
+	MatchingIndexes     types.List    `tfsdk:"matching_indexes"`
+}
+
+func NewDatadogLogsIndexRoutingDataSource() datasource.DataSource {
+	return &datadogLogsIndexRoutingDataSource{}
+}
+
+type datadogLogsIndexRoutingDataSource struct {
+	Api  *datadogV1.LogsIndexesApi
+	Auth context.Context
+}
+
+func (d *datadogLogsIndexRoutingDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetLogsIndexesApiV1()
+	d.Auth = providerData.Auth
+}
+
+func (d *datadogLogsIndexRoutingDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "logs_index_routing"
+}
+
+func (d *datadogLogsIndexRoutingDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to find the logs index capturing a sample log, following the index order, and the exclusion filter applied to it. The filter queries are evaluated by the provider.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"log": schema.StringAttribute{
+				Description: "The sample log, as a JSON object with the attributes of the log, such as `message`, `ddsource`, `service` and `ddtags`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("facets")),
+				},
+			},
+			"facets": schema.MapAttribute{
+				Description: "The facets of the sample log, keyed the way they are searched: `@` followed by the path of an attribute, a reserved attribute such as `source`, `service`, `host` or `status`, `message`, or the key of a tag.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"index_names": schema.ListAttribute{
+				Description: "Names of the indexes, in order, for example the `indexes` of a `datadog_logs_index_order` resource. Defaults to the current index order.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			// Computed values
+			"index": schema.StringAttribute{
+				Description: "Name of the first index whose filter matches the log, which captures it. Not set when no index matches.",
+				Computed:    true,
+			},
+			"exclusion_filter": schema.StringAttribute{
+				Description: "Name of the first enabled exclusion filter of the index matching the log. Not set when no exclusion filter matches.",
+				Computed:    true,
+			},
+			"exclusion_sample_rate": schema.Float64Attribute{
+				Description: "Fraction of the logs excluded by the exclusion filter. Not set when no exclusion filter matches.",
+				Computed:    true,
+			},
+			"matching_indexes": schema.ListAttribute{
+				Description: "Names of all the indexes whose filter matches the log, in order. The indexes after the first one overlap with it and never receive the log.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *datadogLogsIndexRoutingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogLogsIndexRoutingDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var log map[string]interface{}
+	if !state.Log.IsNull() {
+		if err := json.Unmarshal([]byte(state.Log.ValueString()), &log); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("log"), "invalid log", fmt.Sprintf("log must be a JSON object: %s", err))
+			return
+		}
+	} else {
+		var facets map[string]string
+		resp.Diagnostics.Append(state.Facets.ElementsAs(ctx, &facets, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		log = logsquery.LogFromFacets(facets)
+	}
+
+	var indexNames []string
+	if !state.IndexNames.IsNull() {
+		resp.Diagnostics.Append(state.IndexNames.ElementsAs(ctx, &indexNames, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		indexOrder, httpResp, err := d.Api.GetLogsIndexOrder(d.Auth)
+		if err != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error getting logs index order"))
+			return
+		}
+		indexNames = indexOrder.GetIndexNames()
+	}
+
+	indexesResp, httpResp, err := d.Api.ListLogIndexes(d.Auth)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error listing logs indexes"))
+		return
+	}
+	indexes := make(map[string]datadogV1.LogsIndex)
+	for _, index := range indexesResp.GetIndexes() {
+		indexes[index.GetName()] = index
+	}
+
+	state.Index = types.StringNull()
+	state.ExclusionFilter = types.StringNull()
+	state.ExclusionSampleRate = types.Float64Null()
+	matchingIndexes := make([]string, 0)
+	for _, name := range indexNames {
+		index, ok := indexes[name]
+		if !ok {
+			resp.Diagnostics.AddError("unknown logs index", fmt.Sprintf("logs index %s does not exist", name))
+			return
+		}
+		filter := index.GetFilter()
+		matches, err := matchLogsQuery(filter.GetQuery(), log)
+		if err != nil {
+			resp.Diagnostics.AddError("invalid logs index filter", fmt.Sprintf("filter of logs index %s: %s", name, err))
+			return
+		}
+		if !matches {
+			continue
+		}
+		matchingIndexes = append(matchingIndexes, name)
+		if !state.Index.IsNull() {
+			continue
+		}
+
+		state.Index = types.StringValue(name)
+		for _, exclusion := range index.GetExclusionFilters() {
+			if !exclusion.GetIsEnabled() {
+				continue
+			}
+			exclusionFilter := exclusion.GetFilter()
+			matches, err := matchLogsQuery(exclusionFilter.GetQuery(), log)
+			if err != nil {
+				resp.Diagnostics.AddError("invalid logs exclusion filter", fmt.Sprintf("exclusion filter %s of logs index %s: %s", exclusion.GetName(), name, err))
+				return
+			}
+			if matches {
+				state.ExclusionFilter = types.StringValue(exclusion.GetName())
+				state.ExclusionSampleRate = types.Float64Value(exclusionFilter.GetSampleRate())
+				break
+			}
+		}
+	}
+
+	logJSON, _ := json.Marshal(log)
+	state.ID = types.StringValue(utils.ConvertToSha256(string(logJSON) + "|" + strings.Join(indexNames, ",")))
+	state.MatchingIndexes, _ = types.ListValueFrom(ctx, types.StringType, matchingIndexes)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func matchLogsQuery(query string, log map[string]interface{}) (bool, error) {
+	node, err := logsquery.Parse(query)
+	if err != nil {
+		return false, err
+	}
+	return node.Match(log), nil
+}
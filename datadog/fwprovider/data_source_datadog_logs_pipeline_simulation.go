@@ -0,0 +1,204 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/logspipeline"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogLogsPipelineSimulationDataSource{}
+)
+
+type datadogLogsPipelineSimulationDataSourceModel struct {
+	// Query Parameters
+	Log         types.String `tfsdk:"log"`
+	PipelineIDs types.List   `tfsdk:"pipeline_ids"`
+	Pipelines   types.List   `tfsdk:"pipelines"`
+	// Results
+	ID                types.String `tfsdk:"id"`
+	Result            types.String `tfsdk:"result"`
+	MatchedPipelines  types.List   `tfsdk:"matched_pipelines"`
+	SkippedProcessors types.List   `tfsdk:"skipped_processors"`
+}
+
+func NewDatadogLogsPipelineSimulationDataSource() datasource.DataSource {
+	return &datadogLogsPipelineSimulationDataSource{}
+}
+
+type datadogLogsPipelineSimulationDataSource struct {
+	Api  *datadogV1.LogsPipelinesApi
+	Auth context.Context
+}
+
+func (d *datadogLogsPipelineSimulationDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetLogsPipelinesApiV1()
+	d.Auth = providerData.Auth
+}
+
+func (d *datadogLogsPipelineSimulationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "logs_pipeline_simulation"
+}
+
+func (d *datadogLogsPipelineSimulationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to run a sample log through logs pipelines locally, and check the processed log. Filters and processors are evaluated by the provider: the GeoIP parser and the reference table lookup processor, which depend on data only available in Datadog, are skipped, and the user agent parser only recognizes the most common user agents.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"log": schema.StringAttribute{
+				Description: "The sample log, as a JSON object with the attributes of the log, such as `message`, `ddsource`, `service` and `ddtags`.",
+				Required:    true,
+			},
+			"pipeline_ids": schema.ListAttribute{
+				Description: "IDs of existing pipelines to run the log through, in order, for example the `pipelines` of a `datadog_logs_pipeline_order` resource.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.AtLeastOneOf(path.MatchRoot("pipelines")),
+				},
+			},
+			"pipelines": schema.ListAttribute{
+				Description: "Definitions of pipelines to run the log through, as JSON objects in the shape of the `datadog_logs_custom_pipeline` resource, for example `jsonencode(datadog_logs_custom_pipeline.foo)`. A definition with the `id` of one of the `pipeline_ids` replaces the existing pipeline, other definitions are run after the `pipeline_ids`, in order.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			// Computed values
+			"result": schema.StringAttribute{
+				Description: "The processed log, as a JSON object.",
+				Computed:    true,
+			},
+			"matched_pipelines": schema.ListAttribute{
+				Description: "Names of the pipelines whose filter matched the log, in order. Nested pipelines are prefixed with the name of their parent, such as `parent > nested`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"skipped_processors": schema.ListAttribute{
+				Description: "Names of the processors of the matched pipelines which could not be evaluated locally, prefixed with the name of their pipeline.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *datadogLogsPipelineSimulationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogLogsPipelineSimulationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var log map[string]interface{}
+	if err := json.Unmarshal([]byte(state.Log.ValueString()), &log); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("log"), "invalid log", fmt.Sprintf("log must be a JSON object: %s", err))
+		return
+	}
+
+	var ids, definitionsJSON []string
+	resp.Diagnostics.Append(state.PipelineIDs.ElementsAs(ctx, &ids, false)...)
+	resp.Diagnostics.Append(state.Pipelines.ElementsAs(ctx, &definitionsJSON, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definitions := make(map[string]map[string]interface{})
+	var additionalPipelines []map[string]interface{}
+	for i, p := range definitionsJSON {
+		var pipeline map[string]interface{}
+		if err := json.Unmarshal([]byte(p), &pipeline); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pipelines").AtListIndex(i), "invalid pipeline", fmt.Sprintf("pipeline %d must be a JSON object: %s", i, err))
+			return
+		}
+		if id, ok := pipeline["id"].(string); ok && id != "" {
+			definitions[id] = pipeline
+		}
+		additionalPipelines = append(additionalPipelines, pipeline)
+	}
+
+	var pipelines []map[string]interface{}
+	pipelineIDs := make(map[string]bool)
+	for _, id := range ids {
+		pipelineIDs[id] = true
+		if pipeline, ok := definitions[id]; ok {
+			pipelines = append(pipelines, pipeline)
+			continue
+		}
+
+		ddPipeline, httpResp, err := d.Api.GetLogsPipeline(d.Auth, id)
+		if err != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error getting logs pipeline"))
+			return
+		}
+		tfProcessors, err := logspipeline.BuildTerraformProcessors(ddPipeline.GetProcessors())
+		if err != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error converting logs pipeline"))
+			return
+		}
+		pipeline, err := toLogsPipelineSimulationJSON(map[string]interface{}{
+			"name":       ddPipeline.GetName(),
+			"is_enabled": ddPipeline.GetIsEnabled(),
+			"filter":     logspipeline.BuildTerraformFilter(ddPipeline.Filter),
+			"processor":  tfProcessors,
+		})
+		if err != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error converting logs pipeline"))
+			return
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+	for _, pipeline := range additionalPipelines {
+		if id, ok := pipeline["id"].(string); !ok || !pipelineIDs[id] {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+
+	result, err := logspipeline.Simulate(log, pipelines)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error simulating logs pipelines"))
+		return
+	}
+	resultJSON, err := json.Marshal(result.Log)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error encoding processed log"))
+		return
+	}
+
+	state.Result = types.StringValue(string(resultJSON))
+	// nil slices would be converted to null lists
+	state.MatchedPipelines, _ = types.ListValueFrom(ctx, types.StringType, append([]string{}, result.MatchedPipelines...))
+	state.SkippedProcessors, _ = types.ListValueFrom(ctx, types.StringType, append([]string{}, result.SkippedProcessors...))
+
+	inputs := append([]string{state.Log.ValueString()}, ids...)
+	inputs = append(inputs, definitionsJSON...)
+	state.ID = types.StringValue(utils.ConvertToSha256(strings.Join(inputs, "|")))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// toLogsPipelineSimulationJSON converts a pipeline built for the state to the JSON shape read by the simulation
+func toLogsPipelineSimulationJSON(tfPipeline map[string]interface{}) (map[string]interface{}, error) {
+	var pipeline map[string]interface{}
+	pipelineJSON, err := json.Marshal(tfPipeline)
+	if err == nil {
+		err = json.Unmarshal(pipelineJSON, &pipeline)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error converting logs pipeline: %s", err)
+	}
+	return pipeline, nil
+}
@@ -0,0 +1,205 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogMetricTagUsageDataSource{}
+)
+
+var metricTagKeyUsageType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"key":                      types.StringType,
+		"queried":                  types.BoolType,
+		"estimated_series_with":    types.Int64Type,
+		"estimated_series_without": types.Int64Type,
+	},
+}
+
+type datadogMetricTagUsageDataSourceModel struct {
+	// Query Parameters
+	MetricName      types.String `tfsdk:"metric_name"`
+	WindowSeconds   types.Int64  `tfsdk:"window_seconds"`
+	EstimateTagKeys types.Bool   `tfsdk:"estimate_tag_keys"`
+	// Results
+	ID                         types.String `tfsdk:"id"`
+	IngestedTagKeys            types.List   `tfsdk:"ingested_tag_keys"`
+	QueriedTagKeys             types.List   `tfsdk:"queried_tag_keys"`
+	EstimatedSeries            types.Int64  `tfsdk:"estimated_series"`
+	QueriedTagsEstimatedSeries types.Int64  `tfsdk:"queried_tags_estimated_series"`
+	TagKeys                    types.List   `tfsdk:"tag_keys"`
+}
+
+func NewDatadogMetricTagUsageDataSource() datasource.DataSource {
+	return &datadogMetricTagUsageDataSource{}
+}
+
+type datadogMetricTagUsageDataSource struct {
+	Api  *datadogV2.MetricsApi
+	Auth context.Context
+}
+
+func (d *datadogMetricTagUsageDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetMetricsApiV2()
+	d.Auth = providerData.Auth
+}
+
+func (d *datadogMetricTagUsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "metric_tag_usage"
+}
+
+func (d *datadogMetricTagUsageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to retrieve the tag keys ingested and queried for a metric, and the number of series estimated by Datadog when keeping some of them, for example to configure a `datadog_metric_tag_configuration` resource keeping only the queried tags.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"metric_name": schema.StringAttribute{
+				Description: "The name of the metric.",
+				Required:    true,
+			},
+			"window_seconds": schema.Int64Attribute{
+				Description: "Number of seconds in the past during which queries of the metric are searched. Defaults to the window of the API, 604800 seconds (7 days).",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"estimate_tag_keys": schema.BoolAttribute{
+				Description: "Whether to estimate the number of series with and without each ingested tag key in `tag_keys`. This makes two estimate requests per tag key. Defaults to `false`.",
+				Optional:    true,
+			},
+			// Computed values
+			"ingested_tag_keys": schema.ListAttribute{
+				Description: "Keys of the tags ingested for the metric, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"queried_tag_keys": schema.ListAttribute{
+				Description: "Keys of the tags used by queries of the metric, such as dashboards and monitors, during the window, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"estimated_series": schema.Int64Attribute{
+				Description: "Estimated number of series when keeping all the ingested tag keys. Not set when the metric has no tag.",
+				Computed:    true,
+			},
+			"queried_tags_estimated_series": schema.Int64Attribute{
+				Description: "Estimated number of series when keeping only the queried tag keys. Not set when no tag is queried.",
+				Computed:    true,
+			},
+			"tag_keys": schema.ListAttribute{
+				Description: "Usage of each ingested tag key, sorted by key. `estimated_series_with` is the estimated number of series when keeping only the key, `estimated_series_without` when keeping all the other ingested keys, and is not set when the key is the only one. Both are only set when `estimate_tag_keys` is `true`.",
+				Computed:    true,
+				ElementType: metricTagKeyUsageType,
+			},
+		},
+	}
+}
+
+func (d *datadogMetricTagUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogMetricTagUsageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	metricName := state.MetricName.ValueString()
+
+	tagsResp, httpResp, err := d.Api.ListTagsByMetricName(d.Auth, metricName)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error listing metric tags"))
+		return
+	}
+	tagsData := tagsResp.GetData()
+	tagsAttributes := tagsData.GetAttributes()
+	ingestedKeys := utils.MetricTagKeys(tagsAttributes.GetTags())
+
+	activeParams := datadogV2.NewListActiveMetricConfigurationsOptionalParameters()
+	if !state.WindowSeconds.IsNull() {
+		activeParams = activeParams.WithWindowSeconds(state.WindowSeconds.ValueInt64())
+	}
+	activeResp, httpResp, err := d.Api.ListActiveMetricConfigurations(d.Auth, metricName, *activeParams)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error listing active metric configurations"))
+		return
+	}
+	activeData := activeResp.GetData()
+	activeAttributes := activeData.GetAttributes()
+	queriedKeys := utils.MetricTagKeys(activeAttributes.GetActiveTags())
+
+	estimate := func(keys []string) (types.Int64, bool) {
+		if len(keys) == 0 {
+			return types.Int64Null(), true
+		}
+		series, httpResp, err := d.estimateSeries(metricName, keys)
+		if err != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), fmt.Sprintf("error estimating the series of metric %s with tags %s", metricName, strings.Join(keys, ","))))
+			return types.Int64Null(), false
+		}
+		return types.Int64Value(series), true
+	}
+
+	var ok bool
+	if state.EstimatedSeries, ok = estimate(ingestedKeys); !ok {
+		return
+	}
+	if state.QueriedTagsEstimatedSeries, ok = estimate(queriedKeys); !ok {
+		return
+	}
+	tagKeys := make([]attr.Value, 0, len(ingestedKeys))
+	for _, key := range ingestedKeys {
+		seriesWith, seriesWithout := types.Int64Null(), types.Int64Null()
+		if state.EstimateTagKeys.ValueBool() {
+			if seriesWith, ok = estimate([]string{key}); !ok {
+				return
+			}
+			if seriesWithout, ok = estimate(utils.WithoutMetricTagKey(ingestedKeys, key)); !ok {
+				return
+			}
+		}
+		tagKey, diags := types.ObjectValue(metricTagKeyUsageType.AttrTypes, map[string]attr.Value{
+			"key":                      types.StringValue(key),
+			"queried":                  types.BoolValue(slices.Contains(queriedKeys, key)),
+			"estimated_series_with":    seriesWith,
+			"estimated_series_without": seriesWithout,
+		})
+		resp.Diagnostics.Append(diags...)
+		tagKeys = append(tagKeys, tagKey)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(utils.ConvertToSha256(fmt.Sprintf("%s|%s|%t", metricName, state.WindowSeconds.String(), state.EstimateTagKeys.ValueBool())))
+	state.IngestedTagKeys, _ = types.ListValueFrom(ctx, types.StringType, ingestedKeys)
+	state.QueriedTagKeys, _ = types.ListValueFrom(ctx, types.StringType, queriedKeys)
+	state.TagKeys, _ = types.ListValue(metricTagKeyUsageType, tagKeys)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *datadogMetricTagUsageDataSource) estimateSeries(metricName string, keys []string) (int64, *http.Response, error) {
+	params := datadogV2.NewEstimateMetricsOutputSeriesOptionalParameters().WithFilterGroups(strings.Join(keys, ","))
+	estimateResp, httpResp, err := d.Api.EstimateMetricsOutputSeries(d.Auth, metricName, *params)
+	if err != nil {
+		return 0, httpResp, err
+	}
+	data := estimateResp.GetData()
+	attributes := data.GetAttributes()
+	return attributes.GetEstimatedOutputSeries(), httpResp, nil
+}
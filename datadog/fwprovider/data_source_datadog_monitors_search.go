@@ -0,0 +1,184 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogMonitorsSearchDataSource{}
+)
+
+type monitorSearchModel struct {
+	ID              types.Int64  `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	Query           types.String `tfsdk:"query"`
+	Tags            types.List   `tfsdk:"tags"`
+	Priority        types.Int64  `tfsdk:"priority"`
+	OverallState    types.String `tfsdk:"overall_state"`
+	RestrictedRoles types.Set    `tfsdk:"restricted_roles"`
+}
+
+type datadogMonitorsSearchDataSourceModel struct {
+	// Query Parameters
+	Query    types.String `tfsdk:"query"`
+	Sort     types.String `tfsdk:"sort"`
+	PageSize types.Int64  `tfsdk:"page_size"`
+
+	// Results
+	ID       types.String          `tfsdk:"id"`
+	Monitors []*monitorSearchModel `tfsdk:"monitors"`
+}
+
+type datadogMonitorsSearchDataSource struct {
+	Api  *datadogV1.MonitorsApi
+	Auth context.Context
+}
+
+func NewDatadogMonitorsSearchDataSource() datasource.DataSource {
+	return &datadogMonitorsSearchDataSource{}
+}
+
+func (d *datadogMonitorsSearchDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetMonitorsApiV1()
+	d.Auth = providerData.Auth
+}
+
+func (d *datadogMonitorsSearchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "monitors_search"
+}
+
+func (d *datadogMonitorsSearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to search monitors using the [monitor search syntax](https://docs.datadoghq.com/monitors/manage/search/). All pages of results are fetched.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"query": schema.StringAttribute{
+				Optional:    true,
+				Description: "Monitor search query, for example `status:alert muted:false priority:1 team:sre`. When omitted, all monitors are returned.",
+			},
+			"sort": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comma-separated list of `field,direction` pairs to sort results by, for example `name,asc`.",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of monitors fetched per search request. Defaults to `100`.",
+				Validators:  []validator.Int64{int64validator.Between(1, 1000)},
+			},
+
+			// computed values
+			"monitors": schema.ListAttribute{
+				Computed:    true,
+				Description: "List of monitors matching the search query.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":               types.Int64Type,
+						"name":             types.StringType,
+						"type":             types.StringType,
+						"query":            types.StringType,
+						"tags":             types.ListType{ElemType: types.StringType},
+						"priority":         types.Int64Type,
+						"overall_state":    types.StringType,
+						"restricted_roles": types.SetType{ElemType: types.StringType},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *datadogMonitorsSearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogMonitorsSearchDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pageSize := int64(100)
+	if !state.PageSize.IsNull() {
+		pageSize = state.PageSize.ValueInt64()
+	}
+
+	optionalParams := datadogV1.NewSearchMonitorsOptionalParameters().WithPerPage(pageSize)
+	if !state.Query.IsNull() {
+		optionalParams.WithQuery(state.Query.ValueString())
+	}
+	if !state.Sort.IsNull() {
+		optionalParams.WithSort(state.Sort.ValueString())
+	}
+
+	var results []datadogV1.MonitorSearchResult
+	for page := int64(0); ; page++ {
+		optionalParams.WithPage(page)
+		ddResp, httpResp, err := d.Api.SearchMonitors(d.Auth, *optionalParams)
+		if err != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error searching monitors"))
+			return
+		}
+		results = append(results, ddResp.GetMonitors()...)
+
+		metadata := ddResp.GetMetadata()
+		if len(ddResp.GetMonitors()) == 0 || page+1 >= metadata.GetPageCount() {
+			break
+		}
+	}
+
+	// Search results don't include the priority nor the restricted roles of monitors, get them for each matched monitor
+	monitors := make(map[int64]datadogV1.Monitor, len(results))
+	for _, result := range results {
+		monitor, httpResp, err := d.Api.GetMonitor(d.Auth, result.GetId())
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == 404 {
+				// The monitor was deleted since the search
+				continue
+			}
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), fmt.Sprintf("error getting monitor %d", result.GetId())))
+			return
+		}
+		monitors[result.GetId()] = monitor
+	}
+
+	state.Monitors = make([]*monitorSearchModel, 0, len(results))
+	for _, result := range results {
+		m := &monitorSearchModel{
+			ID:           types.Int64Value(result.GetId()),
+			Name:         types.StringValue(result.GetName()),
+			Type:         types.StringValue(string(result.GetType())),
+			Query:        types.StringValue(result.GetQuery()),
+			Priority:     types.Int64Null(),
+			OverallState: types.StringValue(string(result.GetStatus())),
+		}
+		m.Tags, _ = types.ListValueFrom(ctx, types.StringType, result.GetTags())
+
+		restrictedRoles := []string{}
+		if monitor, ok := monitors[result.GetId()]; ok {
+			if priority, ok := monitor.GetPriorityOk(); ok && priority != nil {
+				m.Priority = types.Int64Value(*priority)
+			}
+			restrictedRoles = monitor.GetRestrictedRoles()
+		} else {
+			resp.Diagnostics.AddWarning(fmt.Sprintf("incomplete details for monitor %d", result.GetId()), "the monitor was not found after the search, its priority and restricted roles are unknown")
+		}
+		m.RestrictedRoles, _ = types.SetValueFrom(ctx, types.StringType, restrictedRoles)
+
+		state.Monitors = append(state.Monitors, m)
+	}
+
+	hashingData := fmt.Sprintf("%s:%s", state.Query.ValueString(), state.Sort.ValueString())
+	state.ID = types.StringValue(utils.ConvertToSha256(hashingData))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,300 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/syntheticsbrowser"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogSyntheticsBrowserTestHCLDataSource{}
+)
+
+type datadogSyntheticsBrowserTestHCLDataSourceModel struct {
+	// Query Parameters
+	PublicID types.String `tfsdk:"public_id"`
+	JSON     types.String `tfsdk:"json"`
+	// Results
+	ID                 types.String `tfsdk:"id"`
+	BrowserStepHCL     types.String `tfsdk:"browser_step_hcl"`
+	BrowserVariableHCL types.String `tfsdk:"browser_variable_hcl"`
+}
+
+func NewDatadogSyntheticsBrowserTestHCLDataSource() datasource.DataSource {
+	return &datadogSyntheticsBrowserTestHCLDataSource{}
+}
+
+type datadogSyntheticsBrowserTestHCLDataSource struct {
+	Api  *datadogV1.SyntheticsApi
+	Auth context.Context
+}
+
+func (d *datadogSyntheticsBrowserTestHCLDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetSyntheticsApiV1()
+	d.Auth = providerData.Auth
+}
+
+func (d *datadogSyntheticsBrowserTestHCLDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "synthetics_browser_test_hcl"
+}
+
+func (d *datadogSyntheticsBrowserTestHCLDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to convert a recorded Synthetic browser test into `browser_step` and `browser_variable` blocks for the `datadog_synthetics_test` resource.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"public_id": schema.StringAttribute{
+				Description: "The public ID of the browser test to convert.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("json")),
+				},
+			},
+			"json": schema.StringAttribute{
+				Description: "The JSON export of the browser test to convert.",
+				Optional:    true,
+			},
+			// Computed values
+			"browser_step_hcl": schema.StringAttribute{
+				Description: "The `browser_step` blocks of the test.",
+				Computed:    true,
+			},
+			"browser_variable_hcl": schema.StringAttribute{
+				Description: "The `browser_variable` blocks of the test.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *datadogSyntheticsBrowserTestHCLDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogSyntheticsBrowserTestHCLDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var syntheticsTest datadogV1.SyntheticsBrowserTest
+	if !state.PublicID.IsNull() {
+		test, httpResp, err := d.Api.GetBrowserTest(d.Auth, state.PublicID.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error getting synthetics browser test"))
+			return
+		}
+		syntheticsTest = test
+	} else if err := json.Unmarshal([]byte(state.JSON.ValueString()), &syntheticsTest); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("json"), "invalid synthetics browser test JSON export", err.Error())
+		return
+	}
+
+	if syntheticsTest.GetType() != datadogV1.SYNTHETICSBROWSERTESTTYPE_BROWSER {
+		resp.Diagnostics.AddError("invalid synthetics test", fmt.Sprintf("synthetics test %s is not a browser test", syntheticsTest.GetPublicId()))
+		return
+	}
+
+	stepsFile := hclwrite.NewEmptyFile()
+	for i, step := range syntheticsTest.GetSteps() {
+		if paramsMap, ok := step.GetParams().(map[string]interface{}); ok {
+			if element, ok := paramsMap["element"].(string); ok {
+				decodedElement, err := syntheticsbrowser.DecodeStepElement(element)
+				if err != nil {
+					resp.Diagnostics.AddError("invalid browser step", fmt.Sprintf("error decoding element of step %d: %s", i, err))
+					return
+				}
+				paramsMap["element"] = decodedElement
+			}
+		}
+
+		localStep := syntheticsbrowser.BuildTerraformStep(step)
+
+		// Only keep the parameters sent by the resource for this step type
+		localParams := localStep["params"].([]interface{})[0].(map[string]interface{})
+		keptParams := make(map[string]interface{})
+		for _, key := range append(syntheticsbrowser.ParamsKeysForStepType(step.GetType()), "element_user_locator") {
+			if value := hclValue(localParams[key]); !isEmptyHCLValue(value) {
+				keptParams[key] = value
+			}
+		}
+
+		if i > 0 {
+			stepsFile.Body().AppendNewline()
+		}
+		stepBody := stepsFile.Body().AppendNewBlock("browser_step", nil).Body()
+		setHCLAttribute(stepBody, "name", localStep["name"])
+		setHCLAttribute(stepBody, "type", localStep["type"])
+		for _, key := range []string{"allow_failure", "is_critical", "timeout", "no_screenshot"} {
+			if value := hclValue(localStep[key]); !isEmptyHCLValue(value) {
+				setHCLAttribute(stepBody, key, value)
+			}
+		}
+		writeHCLBlock(stepBody, "params", keptParams)
+	}
+
+	config := syntheticsTest.GetConfig()
+	variablesFile := hclwrite.NewEmptyFile()
+	for i, variable := range config.GetVariables() {
+		if i > 0 {
+			variablesFile.Body().AppendNewline()
+		}
+		variableBody := variablesFile.Body().AppendNewBlock("browser_variable", nil).Body()
+		variableBody.SetAttributeValue("type", cty.StringVal(string(variable.GetType())))
+		variableBody.SetAttributeValue("name", cty.StringVal(variable.GetName()))
+		if v, ok := variable.GetIdOk(); ok {
+			variableBody.SetAttributeValue("id", cty.StringVal(*v))
+		}
+		if v, ok := variable.GetExampleOk(); ok {
+			variableBody.SetAttributeValue("example", cty.StringVal(*v))
+		}
+		if v, ok := variable.GetPatternOk(); ok {
+			variableBody.SetAttributeValue("pattern", cty.StringVal(*v))
+		}
+		if v, ok := variable.GetSecureOk(); ok {
+			variableBody.SetAttributeValue("secure", cty.BoolVal(*v))
+		}
+	}
+
+	if publicID := syntheticsTest.GetPublicId(); publicID != "" {
+		state.ID = types.StringValue(publicID)
+	} else {
+		state.ID = types.StringValue(utils.ConvertToSha256(state.JSON.ValueString()))
+	}
+	state.BrowserStepHCL = types.StringValue(string(hclwrite.Format(stepsFile.Bytes())))
+	state.BrowserVariableHCL = types.StringValue(string(hclwrite.Format(variablesFile.Bytes())))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// hclValue dereferences the optional values of API models
+func hclValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *bool:
+		if v != nil {
+			return *v
+		}
+		return nil
+	case *int64:
+		if v != nil {
+			return *v
+		}
+		return nil
+	case *string:
+		if v != nil {
+			return *v
+		}
+		return nil
+	}
+	return value
+}
+
+// isEmptyHCLValue returns whether a value is the default of its attribute, so it can be left out of the rendered HCL
+func isEmptyHCLValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	case int64:
+		return v == 0
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case []map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// writeHCLBlock appends a block whose attributes are sorted by key. Lists of objects are written as nested blocks,
+// after the attributes.
+func writeHCLBlock(body *hclwrite.Body, name string, values map[string]interface{}) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	blockBody := body.AppendNewBlock(name, nil).Body()
+	nestedBlocks := make(map[string][]map[string]interface{})
+	var nestedBlockKeys []string
+	for _, key := range keys {
+		switch value := values[key].(type) {
+		case nil:
+		case []map[string]interface{}:
+			nestedBlocks[key] = value
+			nestedBlockKeys = append(nestedBlockKeys, key)
+		case []interface{}:
+			if len(value) > 0 {
+				if _, ok := value[0].(map[string]interface{}); ok {
+					for _, v := range value {
+						nestedBlocks[key] = append(nestedBlocks[key], v.(map[string]interface{}))
+					}
+					nestedBlockKeys = append(nestedBlockKeys, key)
+					continue
+				}
+			}
+			setHCLAttribute(blockBody, key, value)
+		default:
+			setHCLAttribute(blockBody, key, value)
+		}
+	}
+	for _, key := range nestedBlockKeys {
+		for _, nested := range nestedBlocks[key] {
+			writeHCLBlock(blockBody, key, nested)
+		}
+	}
+}
+
+func setHCLAttribute(body *hclwrite.Body, key string, value interface{}) {
+	body.SetAttributeValue(key, hclCtyValue(value))
+}
+
+// hclCtyValue converts a decoded step value to a cty value. Values which are neither primitives nor lists are
+// written as JSON strings.
+func hclCtyValue(value interface{}) cty.Value {
+	switch v := value.(type) {
+	case string:
+		return cty.StringVal(v)
+	case bool:
+		return cty.BoolVal(v)
+	case int:
+		return cty.NumberIntVal(int64(v))
+	case int64:
+		return cty.NumberIntVal(v)
+	case float64:
+		return cty.NumberFloatVal(v)
+	case []interface{}:
+		if len(v) == 0 {
+			return cty.EmptyTupleVal
+		}
+		items := make([]cty.Value, len(v))
+		for i, item := range v {
+			items[i] = hclCtyValue(item)
+		}
+		return cty.TupleVal(items)
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return cty.StringVal(utils.ConvertToString(value))
+}
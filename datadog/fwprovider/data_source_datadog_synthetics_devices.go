@@ -0,0 +1,113 @@
+package fwprovider
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ datasource.DataSource = &datadogSyntheticsDevicesDataSource{}
+)
+
+var (
+	syntheticsDeviceBrowsers    = []string{"chrome", "edge", "firefox"}
+	syntheticsDeviceFormFactors = []string{"laptop_large", "mobile_small", "tablet"}
+)
+
+type datadogSyntheticsDevicesDataSourceModel struct {
+	// Query Parameters
+	Browsers    types.Set `tfsdk:"browsers"`
+	FormFactors types.Set `tfsdk:"form_factors"`
+	// Results
+	ID        types.String `tfsdk:"id"`
+	DeviceIDs types.List   `tfsdk:"device_ids"`
+}
+
+func NewDatadogSyntheticsDevicesDataSource() datasource.DataSource {
+	return &datadogSyntheticsDevicesDataSource{}
+}
+
+type datadogSyntheticsDevicesDataSource struct{}
+
+func (d *datadogSyntheticsDevicesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "synthetics_devices"
+}
+
+func (d *datadogSyntheticsDevicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to retrieve the device IDs supported by Synthetic browser tests, to be used in the `device_ids` of the `datadog_synthetics_test` resource.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"browsers": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only keep the devices of these browsers. Valid values are `chrome`, `edge`, `firefox`.",
+				Validators:  []validator.Set{setvalidator.ValueStringsAre(stringvalidator.OneOf(syntheticsDeviceBrowsers...))},
+			},
+			"form_factors": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only keep the devices of these form factors. Valid values are `laptop_large`, `mobile_small`, `tablet`.",
+				Validators:  []validator.Set{setvalidator.ValueStringsAre(stringvalidator.OneOf(syntheticsDeviceFormFactors...))},
+			},
+			// Computed values
+			"device_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The sorted IDs of the devices, in the `<browser>.<form_factor>` format.",
+			},
+		},
+	}
+}
+
+func (d *datadogSyntheticsDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogSyntheticsDevicesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var browsers, formFactors []string
+	resp.Diagnostics.Append(state.Browsers.ElementsAs(ctx, &browsers, false)...)
+	resp.Diagnostics.Append(state.FormFactors.ElementsAs(ctx, &formFactors, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var deviceIDs []string
+	var deviceID datadogV1.SyntheticsDeviceID
+	for _, id := range deviceID.GetAllowedValues() {
+		// Device IDs without a browser are legacy aliases of the Chrome ones
+		browser, formFactor, ok := strings.Cut(string(id), ".")
+		if !ok {
+			continue
+		}
+		if len(browsers) > 0 && !slices.Contains(browsers, browser) {
+			continue
+		}
+		if len(formFactors) > 0 && !slices.Contains(formFactors, formFactor) {
+			continue
+		}
+		deviceIDs = append(deviceIDs, string(id))
+	}
+	sort.Strings(deviceIDs)
+
+	state.ID = types.StringValue(utils.ConvertToSha256(strings.Join(deviceIDs, ",")))
+	state.DeviceIDs, _ = types.ListValueFrom(ctx, types.StringType, deviceIDs)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
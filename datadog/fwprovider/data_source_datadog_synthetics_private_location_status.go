@@ -0,0 +1,161 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+// syntheticsPrivateLocationWorkersQuery is the number of running workers reported by a private location
+const syntheticsPrivateLocationWorkersQuery = "sum:synthetics.pl.worker.running{location:%s}"
+
+var (
+	_ datasource.DataSource = &datadogSyntheticsPrivateLocationStatusDataSource{}
+)
+
+type datadogSyntheticsPrivateLocationStatusDataSourceModel struct {
+	// Query Parameters
+	PrivateLocationID types.String `tfsdk:"private_location_id"`
+	Lookback          types.Int64  `tfsdk:"lookback"`
+	// Results
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	RunningWorkers types.Int64  `tfsdk:"running_workers"`
+	LastSeen       types.String `tfsdk:"last_seen"`
+	Healthy        types.Bool   `tfsdk:"healthy"`
+}
+
+func NewDatadogSyntheticsPrivateLocationStatusDataSource() datasource.DataSource {
+	return &datadogSyntheticsPrivateLocationStatusDataSource{}
+}
+
+type datadogSyntheticsPrivateLocationStatusDataSource struct {
+	Api        *datadogV1.SyntheticsApi
+	MetricsApi *datadogV1.MetricsApi
+	Auth       context.Context
+	Now        func() time.Time
+}
+
+func (d *datadogSyntheticsPrivateLocationStatusDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	d.Api = providerData.DatadogApiInstances.GetSyntheticsApiV1()
+	d.MetricsApi = providerData.DatadogApiInstances.GetMetricsApiV1()
+	d.Auth = providerData.Auth
+	d.Now = providerData.Now
+}
+
+func (d *datadogSyntheticsPrivateLocationStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "synthetics_private_location_status"
+}
+
+func (d *datadogSyntheticsPrivateLocationStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to retrieve the health of a Synthetics private location, based on the `synthetics.pl.worker.running` metric reported by its workers.",
+		Attributes: map[string]schema.Attribute{
+			// Datasource ID
+			"id": utils.ResourceIDAttribute(),
+			// Datasource Parameters
+			"private_location_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the private location.",
+			},
+			"lookback": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of seconds in the past during which workers are searched for. Defaults to `900`.",
+				Validators:  []validator.Int64{int64validator.Between(60, 604800)},
+			},
+			// Computed values
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the private location.",
+			},
+			"running_workers": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of workers running at the latest data point, `0` if no worker reported during the lookback period.",
+			},
+			"last_seen": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time at which a running worker was last reported, in RFC3339 format. Not set if no worker reported during the lookback period.",
+			},
+			"healthy": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether at least one worker is running.",
+			},
+		},
+	}
+}
+
+func (d *datadogSyntheticsPrivateLocationStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datadogSyntheticsPrivateLocationStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.PrivateLocationID.ValueString()
+	privateLocation, httpResp, err := d.Api.GetPrivateLocation(d.Auth, id)
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error getting synthetics private location"))
+		return
+	}
+
+	lookback := int64(900)
+	if !state.Lookback.IsNull() {
+		lookback = state.Lookback.ValueInt64()
+	}
+	to := d.Now()
+	from := to.Add(-time.Duration(lookback) * time.Second)
+	metrics, httpResp, err := d.MetricsApi.QueryMetrics(d.Auth, from.Unix(), to.Unix(), fmt.Sprintf(syntheticsPrivateLocationWorkersQuery, id))
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error querying synthetics private location workers"))
+		return
+	}
+
+	runningWorkers, lastSeen := syntheticsPrivateLocationWorkers(metrics.GetSeries())
+
+	state.ID = types.StringValue(id)
+	state.Name = types.StringValue(privateLocation.GetName())
+	state.RunningWorkers = types.Int64Value(runningWorkers)
+	state.Healthy = types.BoolValue(runningWorkers > 0)
+	state.LastSeen = types.StringNull()
+	if !lastSeen.IsZero() {
+		state.LastSeen = types.StringValue(lastSeen.UTC().Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// syntheticsPrivateLocationWorkers returns the number of workers at the latest data point, and the time of the
+// latest data point with running workers.
+func syntheticsPrivateLocationWorkers(series []datadogV1.MetricsQueryMetadata) (int64, time.Time) {
+	var runningWorkers int64
+	var latest, lastSeen float64
+	for _, s := range series {
+		for _, point := range s.GetPointlist() {
+			if len(point) < 2 || point[0] == nil || point[1] == nil {
+				continue
+			}
+			timestamp, value := *point[0], *point[1]
+			if timestamp >= latest {
+				latest = timestamp
+				runningWorkers = int64(value)
+			}
+			if value > 0 && timestamp > lastSeen {
+				lastSeen = timestamp
+			}
+		}
+	}
+	if lastSeen == 0 {
+		return runningWorkers, time.Time{}
+	}
+	return runningWorkers, time.UnixMilli(int64(lastSeen))
+}
@@ -55,6 +55,9 @@ var Resources = []func() resource.Resource{
 	NewServiceAccountApplicationKeyResource,
 	NewSpansMetricResource,
 	NewSyntheticsConcurrencyCapResource,
+	NewSyntheticsTestRunResource,
+	NewSyntheticsTestJSONResource,
+	NewSyntheticsGlobalVariableRotationResource,
 	NewTeamLinkResource,
 	NewTeamMembershipResource,
 	NewTeamPermissionSettingResource,
@@ -73,9 +76,19 @@ var Datasources = []func() datasource.DataSource{
 	NewApplicationKeyDataSource,
 	NewDatadogApmRetentionFiltersOrderDataSource,
 	NewDatadogDashboardListDataSource,
+	NewDatadogDowntimeCoverageDataSource,
+	NewDatadogMonitorsSearchDataSource,
 	NewDatadogIntegrationAWSNamespaceRulesDatasource,
 	NewDatadogPowerpackDataSource,
 	NewDatadogServiceAccountDatasource,
+	NewDatadogSyntheticsPrivateLocationStatusDataSource,
+	NewDatadogSyntheticsDevicesDataSource,
+	NewDatadogSyntheticsBrowserTestHCLDataSource,
+	NewDatadogLogsCustomDestinationsDataSource,
+	NewDatadogMetricTagUsageDataSource,
+	NewDatadogLogsIndexRoutingDataSource,
+	NewDatadogLogsIndexBudgetDataSource,
+	NewDatadogLogsPipelineSimulationDataSource,
 	NewDatadogTeamDataSource,
 	NewDatadogTeamMembershipsDataSource,
 	NewHostsDataSource,
@@ -110,11 +123,13 @@ type ProviderSchema struct {
 	HttpClientRetryBackoffBase       types.Int64  `tfsdk:"http_client_retry_backoff_base"`
 	HttpClientRetryMaxRetries        types.Int64  `tfsdk:"http_client_retry_max_retries"`
 	DefaultTags                      types.List   `tfsdk:"default_tags"`
+	SyntheticsDefaults               types.List   `tfsdk:"synthetics_defaults"`
 }
 
 func New() provider.Provider {
 	return &FrameworkProvider{
 		ConfigureCallbackFunc: defaultConfigureFunc,
+		Now:                   time.Now,
 	}
 }
 
@@ -203,6 +218,60 @@ func (p *FrameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 					},
 				},
 			},
+			"synthetics_defaults": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				Description: "Configuration block containing default settings inherited by `datadog_synthetics_test` resources which don't set them.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"locations": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Array of locations used to run tests which don't set `locations`.",
+						},
+						"request_headers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Header name and value map used by HTTP and browser tests which don't set `request_headers`.",
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"retry": schema.ListNestedBlock{
+							Validators: []validator.List{
+								listvalidator.SizeAtMost(1),
+							},
+							Description: "Retry options used by tests whose `options_list` doesn't set `retry`.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"count": schema.Int64Attribute{
+										Optional:    true,
+										Description: "Number of retries needed to consider a location as failed before sending a notification alert. Defaults to `0`.",
+									},
+									"interval": schema.Int64Attribute{
+										Optional:    true,
+										Description: "Interval between a failed test and the next retry in milliseconds. Defaults to `300`.",
+									},
+								},
+							},
+						},
+						"monitor_options": schema.ListNestedBlock{
+							Validators: []validator.List{
+								listvalidator.SizeAtMost(1),
+							},
+							Description: "Monitor options used by tests whose `options_list` doesn't set `monitor_options`.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"renotify_interval": schema.Int64Attribute{
+										Optional:    true,
+										Description: "Specify a renotification frequency in minutes. Defaults to `0`.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -2,20 +2,28 @@ package fwprovider
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/planmodifiers"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/rrule"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	frameworkPath "github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -24,11 +32,24 @@ import (
 var (
 	_ resource.ResourceWithConfigure   = &DowntimeScheduleResource{}
 	_ resource.ResourceWithImportState = &DowntimeScheduleResource{}
+	_ resource.ResourceWithModifyPlan  = &DowntimeScheduleResource{}
 )
 
+var downtimeActiveWindowType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"start": types.StringType,
+		"end":   types.StringType,
+	},
+}
+
+// legacyDowntimeImportPrefix marks import IDs of `datadog_downtime` resources being migrated to this resource
+const legacyDowntimeImportPrefix = "legacy:"
+
 type DowntimeScheduleResource struct {
-	Api  *datadogV2.DowntimesApi
-	Auth context.Context
+	Api       *datadogV2.DowntimesApi
+	LegacyApi *datadogV1.DowntimesApi
+	Auth      context.Context
+	Now       func() time.Time
 }
 
 type DowntimeScheduleModel struct {
@@ -42,6 +63,8 @@ type DowntimeScheduleModel struct {
 	MonitorIdentifier                  *MonitorIdentifierModel             `tfsdk:"monitor_identifier"`
 	DowntimeScheduleRecurrenceSchedule *DowntimeScheduleRecurrenceSchedule `tfsdk:"recurring_schedule"`
 	DowntimeScheduleOneTimeSchedule    *DowntimeScheduleOneTimeSchedule    `tfsdk:"one_time_schedule"`
+	NextActiveWindowsCount             types.Int64                         `tfsdk:"next_active_windows_count"`
+	NextActiveWindows                  types.List                          `tfsdk:"next_active_windows"`
 }
 
 type MonitorIdentifierModel struct {
@@ -71,7 +94,9 @@ func NewDowntimeScheduleResource() resource.Resource {
 func (r *DowntimeScheduleResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
 	providerData, _ := request.ProviderData.(*FrameworkProvider)
 	r.Api = providerData.DatadogApiInstances.GetDowntimesApiV2()
+	r.LegacyApi = providerData.DatadogApiInstances.GetDowntimesApiV1()
 	r.Auth = providerData.Auth
+	r.Now = providerData.Now
 }
 
 func (r *DowntimeScheduleResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -112,6 +137,18 @@ func (r *DowntimeScheduleResource) Schema(_ context.Context, _ resource.SchemaRe
 				Description: "Actions that will trigger a monitor notification if the downtime is in the `notify_end_types` state.",
 				ElementType: types.StringType,
 			},
+			"next_active_windows_count": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(5),
+				Description: "Number of upcoming active windows to compute in `next_active_windows`.",
+				Validators:  []validator.Int64{int64validator.Between(0, 100)},
+			},
+			"next_active_windows": schema.ListAttribute{
+				Computed:    true,
+				Description: "The next windows during which the downtime is active, computed locally from the schedule and resolved in `display_timezone`. A window already in progress is included. `end` is empty for a one-time downtime which never ends.",
+				ElementType: downtimeActiveWindowType,
+			},
 			"id": utils.ResourceIDAttribute(),
 		},
 		Blocks: map[string]schema.Block{
@@ -168,6 +205,7 @@ func (r *DowntimeScheduleResource) Schema(_ context.Context, _ resource.SchemaRe
 								"rrule": schema.StringAttribute{
 									Required:    true,
 									Description: "The `RRULE` standard for defining recurring events. For example, to have a recurring event on the first day of each month, set the type to `rrule` and set the `FREQ` to `MONTHLY` and `BYMONTHDAY` to `1`. Most common `rrule` options from the [iCalendar Spec](https://tools.ietf.org/html/rfc5545) are supported.  **Note**: Attributes specifying the duration in `RRULE` are not supported (for example, `DTSTART`, `DTEND`, `DURATION`). More examples available in this [downtime guide](https://docs.datadoghq.com/monitors/guide/suppress-alert-with-downtimes/?tab=api).",
+									Validators:  []validator.String{validators.RRuleValidator()},
 								},
 								"start": schema.StringAttribute{
 									Optional:    true,
@@ -186,9 +224,237 @@ func (r *DowntimeScheduleResource) Schema(_ context.Context, _ resource.SchemaRe
 }
 
 func (r *DowntimeScheduleResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if legacyID, ok := strings.CutPrefix(request.ID, legacyDowntimeImportPrefix); ok {
+		// Adopt the downtime schedule backing a `datadog_downtime`, so migrating doesn't cancel active mutes
+		id, err := r.findMigratedDowntime(ctx, legacyID)
+		if err != nil {
+			response.Diagnostics.AddError(fmt.Sprintf("error migrating downtime %s", legacyID), err.Error())
+			return
+		}
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, frameworkPath.Root("id"), id)...)
+		return
+	}
 	resource.ImportStatePassthroughID(ctx, frameworkPath.Root("id"), request, response)
 }
 
+// findMigratedDowntime returns the ID of the downtime schedule equivalent to a v1 downtime
+func (r *DowntimeScheduleResource) findMigratedDowntime(ctx context.Context, legacyID string) (string, error) {
+	id, err := strconv.ParseInt(legacyID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("expected the ID of a `datadog_downtime` resource, got %q", legacyID)
+	}
+	legacy, httpResp, err := r.LegacyApi.GetDowntime(r.Auth, id)
+	if err != nil {
+		return "", utils.TranslateClientError(err, httpResp, "error getting downtime")
+	}
+	if canceled, ok := legacy.GetCanceledOk(); ok && canceled != nil {
+		return "", fmt.Errorf("downtime %d is canceled, there is nothing to migrate", id)
+	}
+	expected, err := buildDowntimeScheduleFromLegacy(ctx, &legacy)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	response, _ := r.Api.ListDowntimesWithPagination(r.Auth)
+	for paginationResult := range response {
+		if paginationResult.Error != nil {
+			return "", utils.TranslateClientError(paginationResult.Error, nil, "error listing downtimes")
+		}
+		attributes := paginationResult.Item.GetAttributes()
+		if status := attributes.GetStatus(); status == "canceled" || status == "ended" {
+			continue
+		}
+		var candidate DowntimeScheduleModel
+		r.updateStateFromData(ctx, &candidate, paginationResult.Item)
+		if legacyDowntimeMatches(ctx, expected, &candidate) {
+			matches = append(matches, paginationResult.Item.GetId())
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return "", fmt.Errorf("no downtime schedule matches downtime %d. Create the following resource before removing the `datadog_downtime` one, so monitors stay muted:\n\n%s", id, renderLegacyDowntimeSchedule(ctx, expected))
+	default:
+		return "", fmt.Errorf("downtime %d matches several downtime schedules (%s). Import the one backing it using its ID instead of `legacy:%d`", id, strings.Join(matches, ", "), id)
+	}
+}
+
+// buildDowntimeScheduleFromLegacy maps a v1 downtime to the equivalent downtime schedule. The v1 `scope` list is
+// combined with `AND`, and the `recurrence` block is converted to an `rrule` whose duration is the length of the
+// first occurrence.
+func buildDowntimeScheduleFromLegacy(ctx context.Context, legacy *datadogV1.Downtime) (*DowntimeScheduleModel, error) {
+	state := &DowntimeScheduleModel{
+		Scope:                         types.StringValue(strings.Join(legacy.GetScope(), " AND ")),
+		Message:                       types.StringNull(),
+		MuteFirstRecoveryNotification: types.BoolValue(legacy.GetMuteFirstRecoveryNotification()),
+		DisplayTimezone:               types.StringValue("UTC"),
+		MonitorIdentifier:             &MonitorIdentifierModel{},
+	}
+	if message := strings.TrimSpace(legacy.GetMessage()); message != "" {
+		state.Message = types.StringValue(message)
+	}
+
+	location := time.UTC
+	if timezone := legacy.GetTimezone(); timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+		location = loc
+		state.DisplayTimezone = types.StringValue(timezone)
+	}
+
+	if monitorID, ok := legacy.GetMonitorIdOk(); ok && monitorID != nil {
+		state.MonitorIdentifier.DowntimeMonitorIdentifierId = types.Int64Value(*monitorID)
+		state.MonitorIdentifier.DowntimeMonitorIdentifierTags = types.SetNull(types.StringType)
+	} else {
+		monitorTags := legacy.GetMonitorTags()
+		if len(monitorTags) == 0 {
+			monitorTags = []string{"*"}
+		}
+		state.MonitorIdentifier.DowntimeMonitorIdentifierId = types.Int64Null()
+		state.MonitorIdentifier.DowntimeMonitorIdentifierTags, _ = types.SetValueFrom(ctx, types.StringType, monitorTags)
+	}
+
+	if recurrence, ok := legacy.GetRecurrenceOk(); ok && recurrence != nil {
+		if legacy.GetEnd() <= legacy.GetStart() {
+			return nil, fmt.Errorf("recurring downtimes without an end can't be migrated, a recurrence duration is required")
+		}
+		rule, err := rrule.FromLegacyRecurrence(rrule.LegacyRecurrence{
+			Type:             recurrence.GetType(),
+			Period:           int(recurrence.GetPeriod()),
+			WeekDays:         recurrence.GetWeekDays(),
+			UntilDate:        recurrence.GetUntilDate(),
+			UntilOccurrences: int(recurrence.GetUntilOccurrences()),
+			Rrule:            recurrence.GetRrule(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		duration := time.Duration(legacy.GetEnd()-legacy.GetStart()) * time.Second
+		state.DowntimeScheduleRecurrenceSchedule = &DowntimeScheduleRecurrenceSchedule{
+			Timezone: types.StringValue(location.String()),
+			Recurrences: []*RecurrencesModel{{
+				Duration: types.StringValue(rrule.FormatDuration(duration)),
+				Rrule:    types.StringValue(rule),
+				Start:    types.StringValue(time.Unix(legacy.GetStart(), 0).In(location).Format("2006-01-02T15:04:05")),
+			}},
+		}
+	} else {
+		state.DowntimeScheduleOneTimeSchedule = &DowntimeScheduleOneTimeSchedule{
+			Start: types.StringValue(time.Unix(legacy.GetStart(), 0).UTC().Format("2006-01-02T15:04:05Z")),
+			End:   types.StringNull(),
+		}
+		if end := legacy.GetEnd(); end > 0 {
+			state.DowntimeScheduleOneTimeSchedule.End = types.StringValue(time.Unix(end, 0).UTC().Format("2006-01-02T15:04:05Z"))
+		}
+	}
+	return state, nil
+}
+
+// legacyDowntimeMatches reports whether a downtime schedule targets the same monitors and scope, from the same start,
+// as the expected one
+func legacyDowntimeMatches(ctx context.Context, expected, candidate *DowntimeScheduleModel) bool {
+	if strings.Join(strings.Fields(expected.Scope.ValueString()), " ") != strings.Join(strings.Fields(candidate.Scope.ValueString()), " ") {
+		return false
+	}
+	if strings.TrimSpace(expected.Message.ValueString()) != strings.TrimSpace(candidate.Message.ValueString()) {
+		return false
+	}
+	if (expected.DowntimeScheduleRecurrenceSchedule == nil) != (candidate.DowntimeScheduleRecurrenceSchedule == nil) {
+		return false
+	}
+	if candidate.MonitorIdentifier == nil {
+		return false
+	}
+	// Downtimes created with the v1 API keep their start, which tells apart downtimes muting the same monitors
+	expectedStart, expectedOk := downtimeScheduleStart(expected)
+	candidateStart, candidateOk := downtimeScheduleStart(candidate)
+	if !expectedOk || !candidateOk || !expectedStart.Equal(candidateStart) {
+		return false
+	}
+	if !expected.MonitorIdentifier.DowntimeMonitorIdentifierId.IsNull() {
+		return expected.MonitorIdentifier.DowntimeMonitorIdentifierId.Equal(candidate.MonitorIdentifier.DowntimeMonitorIdentifierId)
+	}
+	var expectedTags, candidateTags []string
+	expected.MonitorIdentifier.DowntimeMonitorIdentifierTags.ElementsAs(ctx, &expectedTags, false)
+	candidate.MonitorIdentifier.DowntimeMonitorIdentifierTags.ElementsAs(ctx, &candidateTags, false)
+	sort.Strings(expectedTags)
+	sort.Strings(candidateTags)
+	return strings.Join(expectedTags, ",") == strings.Join(candidateTags, ",")
+}
+
+// downtimeScheduleStart returns the start of the first recurrence or of the one-time schedule of a downtime schedule
+func downtimeScheduleStart(state *DowntimeScheduleModel) (time.Time, bool) {
+	if recurring := state.DowntimeScheduleRecurrenceSchedule; recurring != nil {
+		if len(recurring.Recurrences) == 0 || recurring.Recurrences[0].Start.IsNull() {
+			return time.Time{}, false
+		}
+		location := time.UTC
+		if loc, err := time.LoadLocation(recurring.Timezone.ValueString()); err == nil {
+			location = loc
+		}
+		start, err := parseDowntimeRecurrenceStart(recurring.Recurrences[0].Start.ValueString(), location)
+		return start, err == nil
+	}
+	if oneTime := state.DowntimeScheduleOneTimeSchedule; oneTime != nil && !oneTime.Start.IsNull() {
+		start, err := time.Parse(time.RFC3339, oneTime.Start.ValueString())
+		return start, err == nil
+	}
+	return time.Time{}, false
+}
+
+// renderLegacyDowntimeSchedule renders the configuration of a downtime schedule built from a v1 downtime
+func renderLegacyDowntimeSchedule(ctx context.Context, state *DowntimeScheduleModel) string {
+	var b strings.Builder
+	b.WriteString("resource \"datadog_downtime_schedule\" \"migrated\" {\n")
+	fmt.Fprintf(&b, "  scope            = %q\n", state.Scope.ValueString())
+	fmt.Fprintf(&b, "  display_timezone = %q\n", state.DisplayTimezone.ValueString())
+	if !state.Message.IsNull() {
+		fmt.Fprintf(&b, "  message          = %q\n", state.Message.ValueString())
+	}
+	if state.MuteFirstRecoveryNotification.ValueBool() {
+		b.WriteString("  mute_first_recovery_notification = true\n")
+	}
+	b.WriteString("\n  monitor_identifier {\n")
+	if !state.MonitorIdentifier.DowntimeMonitorIdentifierId.IsNull() {
+		fmt.Fprintf(&b, "    monitor_id = %d\n", state.MonitorIdentifier.DowntimeMonitorIdentifierId.ValueInt64())
+	} else {
+		var tags []string
+		state.MonitorIdentifier.DowntimeMonitorIdentifierTags.ElementsAs(ctx, &tags, false)
+		quoted := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			quoted = append(quoted, strconv.Quote(tag))
+		}
+		fmt.Fprintf(&b, "    monitor_tags = [%s]\n", strings.Join(quoted, ", "))
+	}
+	b.WriteString("  }\n")
+	if recurring := state.DowntimeScheduleRecurrenceSchedule; recurring != nil {
+		b.WriteString("\n  recurring_schedule {\n")
+		fmt.Fprintf(&b, "    timezone = %q\n", recurring.Timezone.ValueString())
+		for _, recurrence := range recurring.Recurrences {
+			b.WriteString("\n    recurrence {\n")
+			fmt.Fprintf(&b, "      duration = %q\n", recurrence.Duration.ValueString())
+			fmt.Fprintf(&b, "      rrule    = %q\n", recurrence.Rrule.ValueString())
+			fmt.Fprintf(&b, "      start    = %q\n", recurrence.Start.ValueString())
+			b.WriteString("    }\n")
+		}
+		b.WriteString("  }\n")
+	} else if oneTime := state.DowntimeScheduleOneTimeSchedule; oneTime != nil {
+		b.WriteString("\n  one_time_schedule {\n")
+		fmt.Fprintf(&b, "    start = %q\n", oneTime.Start.ValueString())
+		if !oneTime.End.IsNull() {
+			fmt.Fprintf(&b, "    end   = %q\n", oneTime.End.ValueString())
+		}
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
 func (r *DowntimeScheduleResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
 	var state DowntimeScheduleModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
@@ -208,6 +474,7 @@ func (r *DowntimeScheduleResource) Read(ctx context.Context, request resource.Re
 	}
 
 	r.updateState(ctx, &state, &resp)
+	response.Diagnostics.Append(r.setNextActiveWindows(ctx, &state)...)
 
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
@@ -232,6 +499,10 @@ func (r *DowntimeScheduleResource) Create(ctx context.Context, request resource.
 		return
 	}
 	r.updateState(ctx, &state, &resp)
+	// Keep the windows shown in the plan, they are refreshed on the next read
+	if state.NextActiveWindows.IsUnknown() {
+		response.Diagnostics.Append(r.setNextActiveWindows(ctx, &state)...)
+	}
 
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
@@ -258,6 +529,10 @@ func (r *DowntimeScheduleResource) Update(ctx context.Context, request resource.
 		return
 	}
 	r.updateState(ctx, &state, &resp)
+	// Keep the windows shown in the plan, they are refreshed on the next read
+	if state.NextActiveWindows.IsUnknown() {
+		response.Diagnostics.Append(r.setNextActiveWindows(ctx, &state)...)
+	}
 
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
@@ -282,10 +557,219 @@ func (r *DowntimeScheduleResource) Delete(ctx context.Context, request resource.
 	}
 }
 
+func (r *DowntimeScheduleResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DowntimeScheduleModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var state *DowntimeScheduleModel
+	if !request.State.Raw.IsNull() {
+		state = &DowntimeScheduleModel{}
+		response.Diagnostics.Append(request.State.Get(ctx, state)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		// Computed values which aren't set in the configuration are unknown in the plan, the API keeps them unchanged
+		if plan.DisplayTimezone.IsUnknown() {
+			plan.DisplayTimezone = state.DisplayTimezone
+		}
+		if plan.DowntimeScheduleRecurrenceSchedule != nil && state.DowntimeScheduleRecurrenceSchedule != nil {
+			if plan.DowntimeScheduleRecurrenceSchedule.Timezone.IsUnknown() {
+				plan.DowntimeScheduleRecurrenceSchedule.Timezone = state.DowntimeScheduleRecurrenceSchedule.Timezone
+			}
+			for i, recurrence := range plan.DowntimeScheduleRecurrenceSchedule.Recurrences {
+				if recurrence.Start.IsUnknown() && i < len(state.DowntimeScheduleRecurrenceSchedule.Recurrences) {
+					recurrence.Start = state.DowntimeScheduleRecurrenceSchedule.Recurrences[i].Start
+				}
+			}
+		}
+		if plan.DowntimeScheduleOneTimeSchedule != nil && state.DowntimeScheduleOneTimeSchedule != nil && plan.DowntimeScheduleOneTimeSchedule.Start.IsUnknown() {
+			plan.DowntimeScheduleOneTimeSchedule.Start = state.DowntimeScheduleOneTimeSchedule.Start
+		}
+	}
+
+	windows, known, diags := r.buildNextActiveWindows(ctx, &plan)
+	response.Diagnostics.Append(diags...)
+	if !known || response.Diagnostics.HasError() {
+		return
+	}
+
+	if state != nil {
+		// Avoid showing a diff when the schedule didn't change but a window ended since the last refresh
+		stateWindows, stateKnown, _ := r.buildNextActiveWindows(ctx, state)
+		if stateKnown && stateWindows.Equal(windows) && !state.NextActiveWindows.IsNull() {
+			windows = state.NextActiveWindows
+		}
+	}
+
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, frameworkPath.Root("next_active_windows"), windows)...)
+}
+
+// setNextActiveWindows computes `next_active_windows` from the schedule stored in the state
+func (r *DowntimeScheduleResource) setNextActiveWindows(ctx context.Context, state *DowntimeScheduleModel) diag.Diagnostics {
+	if state.NextActiveWindowsCount.IsNull() {
+		// Imported resources don't have the default set yet
+		state.NextActiveWindowsCount = types.Int64Value(5)
+	}
+	windows, known, diags := r.buildNextActiveWindows(ctx, state)
+	if !known {
+		windows = types.ListNull(downtimeActiveWindowType)
+	}
+	state.NextActiveWindows = windows
+	return diags
+}
+
+// buildNextActiveWindows returns the next active windows of a downtime, and whether they can be computed
+// with the known values of the model.
+func (r *DowntimeScheduleResource) buildNextActiveWindows(ctx context.Context, state *DowntimeScheduleModel) (types.List, bool, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	if state.NextActiveWindowsCount.IsUnknown() {
+		return types.ListUnknown(downtimeActiveWindowType), false, diags
+	}
+	count := 5
+	if !state.NextActiveWindowsCount.IsNull() {
+		count = int(state.NextActiveWindowsCount.ValueInt64())
+	}
+
+	windows, known, err := buildDowntimeActiveWindows(state.DowntimeScheduleRecurrenceSchedule, state.DowntimeScheduleOneTimeSchedule, r.Now(), time.Time{}, count)
+	if err != nil {
+		diags.AddError("error computing next_active_windows", err.Error())
+		return types.ListNull(downtimeActiveWindowType), false, diags
+	}
+	if !known {
+		return types.ListUnknown(downtimeActiveWindowType), false, diags
+	}
+
+	value, d := flattenDowntimeActiveWindows(ctx, windows, state.DisplayTimezone)
+	diags.Append(d...)
+	return value, true, diags
+}
+
+// buildDowntimeActiveWindows returns up to n windows, sorted by start, during which a downtime schedule is active and
+// which end after `after`. When `before` is not zero, only windows starting before it are returned. The boolean result
+// is false when the schedule contains values which aren't known yet.
+func buildDowntimeActiveWindows(recurring *DowntimeScheduleRecurrenceSchedule, oneTime *DowntimeScheduleOneTimeSchedule, after, before time.Time, n int) ([]rrule.Window, bool, error) {
+	var windows []rrule.Window
+
+	if recurring != nil {
+		location := time.UTC
+		if !recurring.Timezone.IsNull() && !recurring.Timezone.IsUnknown() {
+			loc, err := time.LoadLocation(recurring.Timezone.ValueString())
+			if err != nil {
+				return nil, true, fmt.Errorf("invalid recurring_schedule.timezone %q: %w", recurring.Timezone.ValueString(), err)
+			}
+			location = loc
+		}
+		for _, recurrence := range recurring.Recurrences {
+			if recurrence.Rrule.IsUnknown() || recurrence.Duration.IsUnknown() {
+				return nil, false, nil
+			}
+			rule, err := rrule.Parse(recurrence.Rrule.ValueString())
+			if err != nil {
+				return nil, true, err
+			}
+			duration, err := rrule.ParseDuration(recurrence.Duration.ValueString())
+			if err != nil {
+				return nil, true, err
+			}
+			// The downtime starts the moment it is created when no start is provided
+			start := after.In(location)
+			if !recurrence.Start.IsNull() && !recurrence.Start.IsUnknown() {
+				start, err = parseDowntimeRecurrenceStart(recurrence.Start.ValueString(), location)
+				if err != nil {
+					return nil, true, err
+				}
+			}
+			windows = append(windows, rule.Windows(start, duration, after, n)...)
+		}
+	} else if oneTime != nil {
+		if oneTime.End.IsUnknown() {
+			return nil, false, nil
+		}
+		window := rrule.Window{Start: after}
+		if !oneTime.Start.IsNull() && !oneTime.Start.IsUnknown() {
+			start, err := time.Parse(time.RFC3339, oneTime.Start.ValueString())
+			if err != nil {
+				return nil, true, err
+			}
+			window.Start = start
+		}
+		if !oneTime.End.IsNull() {
+			end, err := time.Parse(time.RFC3339, oneTime.End.ValueString())
+			if err != nil {
+				return nil, true, err
+			}
+			window.End = end
+		}
+		if window.End.IsZero() || window.End.After(after) {
+			windows = append(windows, window)
+		}
+	}
+
+	sort.SliceStable(windows, func(i, j int) bool { return windows[i].Start.Before(windows[j].Start) })
+	result := make([]rrule.Window, 0, len(windows))
+	for _, window := range windows {
+		if len(result) >= n || (!before.IsZero() && !window.Start.Before(before)) {
+			break
+		}
+		result = append(result, window)
+	}
+	return result, true, nil
+}
+
+// parseDowntimeRecurrenceStart parses a recurrence start, which is expressed without UTC offset in the schedule's timezone
+func parseDowntimeRecurrenceStart(value string, location *time.Location) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if start, err := time.ParseInLocation(layout, value, location); err == nil {
+			return start, nil
+		}
+	}
+	start, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return start, fmt.Errorf("invalid recurrence start %q: must be an ISO-8601 datetime", value)
+	}
+	return start.In(location), nil
+}
+
+func flattenDowntimeActiveWindows(ctx context.Context, windows []rrule.Window, displayTimezone types.String) (types.List, diag.Diagnostics) {
+	location := time.UTC
+	if !displayTimezone.IsNull() && !displayTimezone.IsUnknown() {
+		if loc, err := time.LoadLocation(displayTimezone.ValueString()); err == nil {
+			location = loc
+		}
+	}
+
+	type activeWindow struct {
+		Start types.String `tfsdk:"start"`
+		End   types.String `tfsdk:"end"`
+	}
+	values := make([]activeWindow, 0, len(windows))
+	for _, window := range windows {
+		value := activeWindow{
+			Start: types.StringValue(window.Start.In(location).Format(time.RFC3339)),
+			End:   types.StringValue(""),
+		}
+		if !window.End.IsZero() {
+			value.End = types.StringValue(window.End.In(location).Format(time.RFC3339))
+		}
+		values = append(values, value)
+	}
+	return types.ListValueFrom(ctx, downtimeActiveWindowType, values)
+}
+
 func (r *DowntimeScheduleResource) updateState(ctx context.Context, state *DowntimeScheduleModel, resp *datadogV2.DowntimeResponse) {
-	state.ID = types.StringValue(resp.Data.GetId())
+	r.updateStateFromData(ctx, state, resp.GetData())
+}
+
+func (r *DowntimeScheduleResource) updateStateFromData(ctx context.Context, state *DowntimeScheduleModel, data datadogV2.DowntimeResponseData) {
+	state.ID = types.StringValue(data.GetId())
 
-	data := resp.GetData()
 	attributes := data.GetAttributes()
 
 	if displayTimezone, ok := attributes.GetDisplayTimezoneOk(); ok && displayTimezone != nil {
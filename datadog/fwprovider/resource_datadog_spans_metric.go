@@ -2,8 +2,10 @@ package fwprovider
 
 import (
 	"context"
+	"slices"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	frameworkPath "github.com/hashicorp/terraform-plugin-framework/path"
@@ -22,19 +24,22 @@ import (
 var (
 	_ resource.ResourceWithConfigure   = &spansMetricResource{}
 	_ resource.ResourceWithImportState = &spansMetricResource{}
+	_ resource.ResourceWithModifyPlan  = &spansMetricResource{}
 )
 
 type spansMetricResource struct {
-	Api  *datadogV2.SpansMetricsApi
-	Auth context.Context
+	Api      *datadogV2.SpansMetricsApi
+	SpansApi *datadogV2.SpansApi
+	Auth     context.Context
 }
 
 type spansMetricModel struct {
-	ID      types.String    `tfsdk:"id"`
-	Name    types.String    `tfsdk:"name"`
-	GroupBy []*groupByModel `tfsdk:"group_by"`
-	Compute *computeModel   `tfsdk:"compute"`
-	Filter  *filterModel    `tfsdk:"filter"`
+	ID               types.String           `tfsdk:"id"`
+	Name             types.String           `tfsdk:"name"`
+	GroupBy          []*groupByModel        `tfsdk:"group_by"`
+	Compute          *computeModel          `tfsdk:"compute"`
+	Filter           *filterModel           `tfsdk:"filter"`
+	CardinalityCheck *cardinalityCheckModel `tfsdk:"cardinality_check"`
 }
 
 type groupByModel struct {
@@ -52,6 +57,11 @@ type filterModel struct {
 	Query types.String `tfsdk:"query"`
 }
 
+type cardinalityCheckModel struct {
+	Limit types.Int64 `tfsdk:"limit"`
+	Hours types.Int64 `tfsdk:"hours"`
+}
+
 func NewSpansMetricResource() resource.Resource {
 	return &spansMetricResource{}
 }
@@ -59,6 +69,7 @@ func NewSpansMetricResource() resource.Resource {
 func (r *spansMetricResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
 	providerData := request.ProviderData.(*FrameworkProvider)
 	r.Api = providerData.DatadogApiInstances.GetSpansMetricsApiV2()
+	r.SpansApi = providerData.DatadogApiInstances.GetSpansApiV2()
 	r.Auth = providerData.Auth
 }
 
@@ -138,10 +149,93 @@ func (r *spansMetricResource) Schema(_ context.Context, _ resource.SchemaRequest
 					objectvalidator.IsRequired(),
 				},
 			},
+			"cardinality_check": schema.SingleNestedBlock{
+				Description: "Check during plan that the `group_by` paths don't project more series than a limit. The number of distinct values of each path is searched in the indexed spans matching the filter, and a warning is shown when their product exceeds the limit, which doesn't fail the plan, unlike `datadog_logs_metric`. The check runs when the metric is created or its `filter` or `group_by` change. This is never sent to Datadog.",
+				Attributes: map[string]schema.Attribute{
+					"limit": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Maximum number of series of the metric.",
+						Validators:  []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"hours": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Number of hours of spans searched for distinct values. Defaults to `24`.",
+						Validators:  []validator.Int64{int64validator.Between(1, 720)},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(frameworkPath.MatchRelative().AtName("limit")),
+				},
+			},
 		},
 	}
 }
 
+func (r *spansMetricResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var check *cardinalityCheckModel
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, frameworkPath.Root("cardinality_check"), &check)...)
+	if response.Diagnostics.HasError() || check == nil || check.Limit.IsNull() || check.Limit.IsUnknown() || check.Hours.IsUnknown() {
+		return
+	}
+
+	paths, query, known := spansMetricCardinalityInputs(ctx, request.Plan.GetAttribute)
+	if !known || len(paths) == 0 {
+		// Values depending on other resources can't be checked yet
+		return
+	}
+	if !request.State.Raw.IsNull() {
+		statePaths, stateQuery, _ := spansMetricCardinalityInputs(ctx, request.State.GetAttribute)
+		if slices.Equal(paths, statePaths) && query == stateQuery {
+			return
+		}
+	}
+
+	hours := 24
+	if !check.Hours.IsNull() {
+		hours = int(check.Hours.ValueInt64())
+	}
+	distinctValues, httpResp, err := utils.GetSpansDistinctValues(r.Auth, r.SpansApi, query, paths, hours)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error estimating the cardinality of the spans metric"))
+		return
+	}
+	if err := utils.CheckMetricCardinality(distinctValues, check.Limit.ValueInt64()); err != nil {
+		response.Diagnostics.AddAttributeWarning(frameworkPath.Root("group_by"), "spans metric cardinality above the limit", err.Error())
+	}
+}
+
+// spansMetricCardinalityInputs returns the sorted group by paths and the filter query of a plan or state, and whether
+// they are known
+func spansMetricCardinalityInputs(ctx context.Context, getAttribute func(context.Context, frameworkPath.Path, interface{}) diag.Diagnostics) ([]string, string, bool) {
+	var groupBys types.Set
+	var query types.String
+	if getAttribute(ctx, frameworkPath.Root("group_by"), &groupBys).HasError() || getAttribute(ctx, frameworkPath.Root("filter").AtName("query"), &query).HasError() {
+		return nil, "", false
+	}
+	if groupBys.IsUnknown() || query.IsUnknown() {
+		return nil, "", false
+	}
+
+	var paths []string
+	for _, element := range groupBys.Elements() {
+		groupBy, ok := element.(types.Object)
+		if !ok || groupBy.IsUnknown() {
+			return nil, "", false
+		}
+		path, ok := groupBy.Attributes()["path"].(types.String)
+		if !ok || path.IsUnknown() {
+			return nil, "", false
+		}
+		paths = append(paths, path.ValueString())
+	}
+	slices.Sort(paths)
+	return paths, query.ValueString(), true
+}
+
 func (r *spansMetricResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, frameworkPath.Root("id"), request, response)
 }
@@ -0,0 +1,162 @@
+package fwprovider
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+var (
+	_ resource.ResourceWithConfigure  = &syntheticsGlobalVariableRotationResource{}
+	_ resource.ResourceWithModifyPlan = &syntheticsGlobalVariableRotationResource{}
+)
+
+type syntheticsGlobalVariableRotationResource struct {
+	Now func() time.Time
+}
+
+type syntheticsGlobalVariableRotationModel struct {
+	ID             types.String `tfsdk:"id"`
+	RotationDays   types.Int64  `tfsdk:"rotation_days"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	ValueVersion   types.Int64  `tfsdk:"value_version"`
+	RotatedAt      types.String `tfsdk:"rotated_at"`
+	NextRotationAt types.String `tfsdk:"next_rotation_at"`
+}
+
+func NewSyntheticsGlobalVariableRotationResource() resource.Resource {
+	return &syntheticsGlobalVariableRotationResource{}
+}
+
+func (r *syntheticsGlobalVariableRotationResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Now = providerData.Now
+}
+
+func (r *syntheticsGlobalVariableRotationResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "synthetics_global_variable_rotation"
+}
+
+func (r *syntheticsGlobalVariableRotationResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog resource scheduling the rotation of the value of a synthetics global variable. Use its `value_version` as the `value_version` of a `datadog_synthetics_global_variable` using `value_wo`, so that the value is sent again from the configuration, for example from a secret manager, when the rotation is due. No value is stored in the state.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"rotation_days": schema.Int64Attribute{
+				Description: "Number of days between two rotations. When not set, the value is only rotated when `triggers` change.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 3650)},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values which, when changed, rotate the value.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"value_version": schema.Int64Attribute{
+				Description: "Version of the value, incremented on each rotation.",
+				Computed:    true,
+			},
+			"rotated_at": schema.StringAttribute{
+				Description: "Time of the last rotation, in RFC3339 format.",
+				Computed:    true,
+			},
+			"next_rotation_at": schema.StringAttribute{
+				Description: "Time from which the next plan rotates the value, in RFC3339 format. Not set when `rotation_days` is not set.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *syntheticsGlobalVariableRotationResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() || request.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state syntheticsGlobalVariableRotationModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	due := !plan.Triggers.Equal(state.Triggers)
+	if next := nextSyntheticsGlobalVariableRotation(state.RotatedAt, plan.RotationDays); !next.IsNull() {
+		nextRotation, err := time.Parse(time.RFC3339, next.ValueString())
+		due = due || (err == nil && !r.Now().Before(nextRotation))
+	}
+
+	plan.ID = state.ID
+	if due {
+		plan.ValueVersion = types.Int64Unknown()
+		plan.RotatedAt = types.StringUnknown()
+		plan.NextRotationAt = types.StringUnknown()
+	} else {
+		plan.ValueVersion = state.ValueVersion
+		plan.RotatedAt = state.RotatedAt
+		plan.NextRotationAt = nextSyntheticsGlobalVariableRotation(state.RotatedAt, plan.RotationDays)
+	}
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+}
+
+func (r *syntheticsGlobalVariableRotationResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	// The rotation schedule only exists in the state
+}
+
+func (r *syntheticsGlobalVariableRotationResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var state syntheticsGlobalVariableRotationModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(uuid.NewString())
+	r.rotate(&state, 1)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *syntheticsGlobalVariableRotationResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state syntheticsGlobalVariableRotationModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.RotatedAt.IsUnknown() {
+		r.rotate(&plan, state.ValueVersion.ValueInt64()+1)
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &plan)...)
+}
+
+func (r *syntheticsGlobalVariableRotationResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	// Nothing to delete, the global variable is left with its last value
+}
+
+// rotate records a new version of the value and the rotation time
+func (r *syntheticsGlobalVariableRotationResource) rotate(state *syntheticsGlobalVariableRotationModel, version int64) {
+	state.ValueVersion = types.Int64Value(version)
+	state.RotatedAt = types.StringValue(r.Now().UTC().Format(time.RFC3339))
+	state.NextRotationAt = nextSyntheticsGlobalVariableRotation(state.RotatedAt, state.RotationDays)
+}
+
+func nextSyntheticsGlobalVariableRotation(rotatedAt types.String, rotationDays types.Int64) types.String {
+	if rotatedAt.IsNull() || rotatedAt.IsUnknown() || rotationDays.IsNull() || rotationDays.IsUnknown() {
+		return types.StringNull()
+	}
+	lastRotation, err := time.Parse(time.RFC3339, rotatedAt.ValueString())
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(lastRotation.AddDate(0, 0, int(rotationDays.ValueInt64())).Format(time.RFC3339))
+}
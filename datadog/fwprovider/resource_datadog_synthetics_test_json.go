@@ -0,0 +1,427 @@
+package fwprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+const syntheticsTestsPath = "/api/v1/synthetics/tests"
+
+// syntheticsTestComputedFields are removed from test definitions before they are sent or compared.
+// Arrays are traversed, so `steps.public_id` removes the ID of every step.
+var syntheticsTestComputedFields = []string{
+	"public_id",
+	"monitor_id",
+	"created_at",
+	"created_by",
+	"creator",
+	"deleted_at",
+	"modified_at",
+	"modified_by",
+	"org_id",
+	"monitor_json",
+	"config.steps.id",
+	"config.steps.public_id",
+	"steps.id",
+	"steps.public_id",
+}
+
+// syntheticsTestSensitiveFields are keys holding credentials, which the API obfuscates or omits in its responses
+var syntheticsTestSensitiveFields = map[string]bool{
+	"accessKey":    true,
+	"clientSecret": true,
+	"content":      true,
+	"password":     true,
+	"secretKey":    true,
+	"sessionToken": true,
+	"token":        true,
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &syntheticsTestJSONResource{}
+	_ resource.ResourceWithImportState = &syntheticsTestJSONResource{}
+)
+
+type syntheticsTestJSONResource struct {
+	Api  *datadog.APIClient
+	Auth context.Context
+}
+
+type syntheticsTestJSONModel struct {
+	ID        types.String `tfsdk:"id"`
+	Test      types.String `tfsdk:"test"`
+	MonitorID types.Int64  `tfsdk:"monitor_id"`
+}
+
+func NewSyntheticsTestJSONResource() resource.Resource {
+	return &syntheticsTestJSONResource{}
+}
+
+func (r *syntheticsTestJSONResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.HttpClient
+	r.Auth = providerData.Auth
+}
+
+func (r *syntheticsTestJSONResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "synthetics_test_json"
+}
+
+func (r *syntheticsTestJSONResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	const modifierDesc = "new test if the test type is updated"
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog synthetics test JSON resource. This can be used to create and manage Datadog API, browser and mobile synthetics tests using the JSON definition.",
+		Attributes: map[string]schema.Attribute{
+			"test": schema.StringAttribute{
+				Required:      true,
+				Sensitive:     true,
+				Description:   "The JSON formatted definition of the synthetics test. Computed fields such as `public_id`, `monitor_id` and step IDs are ignored, as well as the fields not set in the definition, which the API returns with their default value. The definition is sensitive as it can contain credentials, which are not returned by the API and are therefore kept from the configuration.",
+				Validators:    []validator.String{syntheticsTestJSONValidator{}},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(syntheticsTestJSONTypeChanged, modifierDesc, modifierDesc)},
+			},
+			"monitor_id": schema.Int64Attribute{
+				Computed:      true,
+				Description:   "ID of the monitor associated with the synthetics test.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			// Resource ID
+			"id": utils.ResourceIDAttribute(),
+		},
+	}
+}
+
+func syntheticsTestJSONTypeChanged(ctx context.Context, request planmodifier.StringRequest, response *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if request.StateValue.IsNull() || request.PlanValue.IsUnknown() {
+		return
+	}
+	oldTest, errO := expandSyntheticsTestJSON(request.StateValue.ValueString())
+	newTest, errN := expandSyntheticsTestJSON(request.PlanValue.ValueString())
+	if errO != nil || errN != nil {
+		return
+	}
+	response.RequiresReplace = oldTest["type"] != newTest["type"]
+}
+
+type syntheticsTestJSONValidator struct {
+}
+
+func (v syntheticsTestJSONValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v syntheticsTestJSONValidator) MarkdownDescription(_ context.Context) string {
+	return "test must be a JSON object with a `type` of `api`, `browser` or `mobile`"
+}
+
+func (v syntheticsTestJSONValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	test, err := expandSyntheticsTestJSON(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid synthetics test JSON", err.Error())
+		return
+	}
+	if _, err := syntheticsTestTypePath(test); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid synthetics test JSON", err.Error())
+	}
+}
+
+func (r *syntheticsTestJSONResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+}
+
+func (r *syntheticsTestJSONResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state syntheticsTestJSONModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+
+	// The type of imported tests is unknown, the generic endpoint is used to retrieve it
+	var prior map[string]interface{}
+	if !state.Test.IsNull() {
+		prior, _ = expandSyntheticsTestJSON(state.Test.ValueString())
+	}
+	if prior == nil {
+		respByte, httpResp, err := utils.SendRequest(r.Auth, r.Api, "GET", syntheticsTestsPath+"/"+id, nil)
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == 404 {
+				response.State.RemoveResource(ctx)
+				return
+			}
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error getting synthetics test"))
+			return
+		}
+		if prior, err = utils.ConvertResponseByteToMap(respByte); err != nil {
+			response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error getting synthetics test"))
+			return
+		}
+	}
+
+	typePath, err := syntheticsTestTypePath(prior)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error getting synthetics test"))
+		return
+	}
+	respByte, httpResp, err := utils.SendRequest(r.Auth, r.Api, "GET", typePath+"/"+id, nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error getting synthetics test"))
+		return
+	}
+	test, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error getting synthetics test"))
+		return
+	}
+
+	r.updateState(&state, test, prior)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *syntheticsTestJSONResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var state syntheticsTestJSONModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	body, typePath, err := buildSyntheticsTestJSONRequest(state.Test.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error building synthetics test"))
+		return
+	}
+	respByte, httpResp, err := utils.SendRequest(r.Auth, r.Api, "POST", typePath, &body)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error creating synthetics test"))
+		return
+	}
+	test, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error creating synthetics test"))
+		return
+	}
+	publicId, ok := test["public_id"].(string)
+	if !ok {
+		response.Diagnostics.AddError("error retrieving public_id from response", "")
+		return
+	}
+	state.ID = types.StringValue(publicId)
+
+	r.updateState(&state, test, nil)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *syntheticsTestJSONResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var state syntheticsTestJSONModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	body, typePath, err := buildSyntheticsTestJSONRequest(state.Test.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error building synthetics test"))
+		return
+	}
+	respByte, httpResp, err := utils.SendRequest(r.Auth, r.Api, "PUT", typePath+"/"+state.ID.ValueString(), &body)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error updating synthetics test"))
+		return
+	}
+	test, err := utils.ConvertResponseByteToMap(respByte)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error updating synthetics test"))
+		return
+	}
+
+	r.updateState(&state, test, nil)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *syntheticsTestJSONResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state syntheticsTestJSONModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	body := fmt.Sprintf(`{"public_ids":[%q]}`, state.ID.ValueString())
+	_, httpResp, err := utils.SendRequest(r.Auth, r.Api, "POST", syntheticsTestsPath+"/delete", &body)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == 404 {
+			return
+		}
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, "error deleting synthetics test"))
+		return
+	}
+}
+
+// updateState sets the monitor ID, and the test definition when it differs from the one in state.
+// On apply, the planned definition is kept as is; credentials missing from API responses are taken from prior.
+// Only the keys set in prior are compared, as the API returns defaults for the keys that are not set.
+func (r *syntheticsTestJSONResource) updateState(state *syntheticsTestJSONModel, test map[string]interface{}, prior map[string]interface{}) {
+	if monitorId, ok := test["monitor_id"].(float64); ok {
+		state.MonitorID = types.Int64Value(int64(monitorId))
+	} else {
+		state.MonitorID = types.Int64Null()
+	}
+
+	if prior == nil {
+		return
+	}
+	normalizeSyntheticsTestJSON(test)
+	normalizeSyntheticsTestJSON(prior)
+	restoreSyntheticsTestSensitiveValues(test, prior)
+	projected := projectSyntheticsTestJSON(test, prior)
+	if reflect.DeepEqual(projected, prior) {
+		return
+	}
+	if testString, err := json.Marshal(projected); err == nil {
+		state.Test = types.StringValue(string(testString))
+	}
+}
+
+// projectSyntheticsTestJSON returns the value of test restricted to the keys of the objects of prior. Array elements
+// are projected on the element of prior at the same index, and kept as is when prior has fewer elements.
+func projectSyntheticsTestJSON(test, prior interface{}) interface{} {
+	switch t := test.(type) {
+	case map[string]interface{}:
+		p, ok := prior.(map[string]interface{})
+		if !ok {
+			return test
+		}
+		projected := make(map[string]interface{}, len(p))
+		for key, priorValue := range p {
+			if value, ok := t[key]; ok {
+				projected[key] = projectSyntheticsTestJSON(value, priorValue)
+			}
+		}
+		return projected
+	case []interface{}:
+		p, ok := prior.([]interface{})
+		if !ok {
+			return test
+		}
+		projected := make([]interface{}, len(t))
+		for i, value := range t {
+			if i < len(p) {
+				projected[i] = projectSyntheticsTestJSON(value, p[i])
+			} else {
+				projected[i] = value
+			}
+		}
+		return projected
+	}
+	return test
+}
+
+func expandSyntheticsTestJSON(test string) (map[string]interface{}, error) {
+	var testMap map[string]interface{}
+	if err := json.Unmarshal([]byte(test), &testMap); err != nil {
+		return nil, err
+	}
+	return testMap, nil
+}
+
+func syntheticsTestTypePath(test map[string]interface{}) (string, error) {
+	switch testType, _ := test["type"].(string); testType {
+	case "api", "browser", "mobile":
+		return syntheticsTestsPath + "/" + testType, nil
+	default:
+		return "", fmt.Errorf("unsupported synthetics test type %q, must be one of `api`, `browser` or `mobile`", testType)
+	}
+}
+
+func buildSyntheticsTestJSONRequest(testString string) (string, string, error) {
+	test, err := expandSyntheticsTestJSON(testString)
+	if err != nil {
+		return "", "", err
+	}
+	typePath, err := syntheticsTestTypePath(test)
+	if err != nil {
+		return "", "", err
+	}
+	normalizeSyntheticsTestJSON(test)
+	body, err := json.Marshal(test)
+	if err != nil {
+		return "", "", err
+	}
+	return string(body), typePath, nil
+}
+
+func normalizeSyntheticsTestJSON(test map[string]interface{}) {
+	for _, f := range syntheticsTestComputedFields {
+		deleteSyntheticsTestKey(test, strings.Split(f, "."))
+	}
+}
+
+// deleteSyntheticsTestKey deletes a nested key, applying the rest of the path to every element of traversed arrays
+func deleteSyntheticsTestKey(value interface{}, keyList []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(keyList) == 1 {
+			delete(v, keyList[0])
+		} else {
+			deleteSyntheticsTestKey(v[keyList[0]], keyList[1:])
+		}
+	case []interface{}:
+		for _, item := range v {
+			deleteSyntheticsTestKey(item, keyList)
+		}
+	}
+}
+
+// restoreSyntheticsTestSensitiveValues copies the credentials and secure variable examples of prior into test
+func restoreSyntheticsTestSensitiveValues(test, prior interface{}) {
+	switch t := test.(type) {
+	case map[string]interface{}:
+		p, ok := prior.(map[string]interface{})
+		if !ok {
+			return
+		}
+		secure, _ := t["secure"].(bool)
+		for key, priorValue := range p {
+			if syntheticsTestSensitiveFields[key] || (secure && (key == "example" || key == "pattern")) {
+				if _, isString := priorValue.(string); isString {
+					t[key] = priorValue
+					continue
+				}
+			}
+			if value, ok := t[key]; ok {
+				restoreSyntheticsTestSensitiveValues(value, priorValue)
+			}
+		}
+	case []interface{}:
+		p, ok := prior.([]interface{})
+		if !ok {
+			return
+		}
+		for i := range t {
+			if i < len(p) {
+				restoreSyntheticsTestSensitiveValues(t[i], p[i])
+			}
+		}
+	}
+}
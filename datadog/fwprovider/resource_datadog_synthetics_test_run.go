@@ -0,0 +1,334 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+// syntheticsTestRunPollInterval is the delay between two checks of the results of a batch
+const syntheticsTestRunPollInterval = 10 * time.Second
+
+var syntheticsTestRunResultType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"public_id": types.StringType,
+		"test_name": types.StringType,
+		"result_id": types.StringType,
+		"location":  types.StringType,
+		"device":    types.StringType,
+		"status":    types.StringType,
+	},
+}
+
+var (
+	_ resource.ResourceWithConfigure = &syntheticsTestRunResource{}
+)
+
+type syntheticsTestRunResource struct {
+	Api  *datadogV1.SyntheticsApi
+	Auth context.Context
+}
+
+type syntheticsTestRunModel struct {
+	ID       types.String                  `tfsdk:"id"`
+	Triggers types.Map                     `tfsdk:"triggers"`
+	Timeout  types.Int64                   `tfsdk:"timeout"`
+	BatchID  types.String                  `tfsdk:"batch_id"`
+	Status   types.String                  `tfsdk:"status"`
+	Results  types.List                    `tfsdk:"results"`
+	Tests    []*syntheticsTestRunTestModel `tfsdk:"test"`
+}
+
+type syntheticsTestRunTestModel struct {
+	PublicID  types.String `tfsdk:"public_id"`
+	StartURL  types.String `tfsdk:"start_url"`
+	Variables types.Map    `tfsdk:"variables"`
+	Locations types.Set    `tfsdk:"locations"`
+}
+
+func NewSyntheticsTestRunResource() resource.Resource {
+	return &syntheticsTestRunResource{}
+}
+
+func (r *syntheticsTestRunResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	providerData, _ := request.ProviderData.(*FrameworkProvider)
+	r.Api = providerData.DatadogApiInstances.GetSyntheticsApiV1()
+	r.Auth = providerData.Auth
+}
+
+func (r *syntheticsTestRunResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "synthetics_test_run"
+}
+
+func (r *syntheticsTestRunResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Provides a Datadog resource triggering Synthetic tests and waiting for their results, failing the apply when a blocking test fails. The tests run again when the resource is replaced, for example when `triggers` change.",
+		Attributes: map[string]schema.Attribute{
+			"id": utils.ResourceIDAttribute(),
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values which, when changed, trigger a new run of the tests.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "Maximum time to wait for the results of the tests (in seconds).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(600),
+				Validators:  []validator.Int64{int64validator.Between(30, 7200)},
+			},
+			"batch_id": schema.StringAttribute{
+				Description: "ID of the batch of test runs.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Overall status of the run: `passed`, `failed` or `timed_out`. Failures of tests with the `non_blocking` execution rule are ignored.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"results": schema.ListAttribute{
+				Description: "Result of each test run.",
+				Computed:    true,
+				ElementType: syntheticsTestRunResultType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"test": schema.ListNestedBlock{
+				Description: "Synthetic test to run.",
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"public_id": schema.StringAttribute{
+							Description: "Public ID of the Synthetic test.",
+							Required:    true,
+						},
+						"start_url": schema.StringAttribute{
+							Description: "Starting URL overriding the one of the test, for browser and HTTP tests.",
+							Optional:    true,
+						},
+						"variables": schema.MapAttribute{
+							Description: "Variables overriding the ones of the test.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"locations": schema.SetAttribute{
+							Description: "Locations overriding the ones of the test.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *syntheticsTestRunResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var state syntheticsTestRunModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := r.buildSyntheticsTestRunRequestBody(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	resp, httpResp, err := r.Api.TriggerCITests(r.Auth, *body)
+	if err != nil {
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(utils.TranslateClientError(err, httpResp, ""), "error triggering synthetics tests"))
+		return
+	}
+	if err := utils.CheckForUnparsed(resp); err != nil {
+		response.Diagnostics.AddError("response contains unparsedObject", err.Error())
+		return
+	}
+
+	batchID := resp.GetBatchId()
+	state.ID = types.StringValue(batchID)
+	state.BatchID = types.StringValue(batchID)
+
+	results, finished, err := r.waitForBatch(ctx, batchID, time.Duration(state.Timeout.ValueInt64())*time.Second)
+	state.Results, diags = flattenSyntheticsTestRunResults(results)
+	response.Diagnostics.Append(diags...)
+
+	// The state is saved even when the run fails, so that the batch can be looked up. The resource is then
+	// tainted and the tests run again on the next apply.
+	switch {
+	case err != nil:
+		state.Status = types.StringValue("failed")
+		response.Diagnostics.Append(utils.FrameworkErrorDiag(err, fmt.Sprintf("error getting the results of batch %s", batchID)))
+	case !finished:
+		state.Status = types.StringValue("timed_out")
+		response.Diagnostics.AddError("synthetics tests timed out", fmt.Sprintf("the results of batch %s were not available after %d seconds", batchID, state.Timeout.ValueInt64()))
+	default:
+		failures := syntheticsTestRunFailures(results)
+		state.Status = types.StringValue("passed")
+		if len(failures) > 0 {
+			state.Status = types.StringValue("failed")
+			response.Diagnostics.AddError("synthetics tests failed", fmt.Sprintf("batch %s has failing blocking tests:\n%s", batchID, strings.Join(failures, "\n")))
+		}
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *syntheticsTestRunResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	// A run is not refreshed: its results are the ones recorded when the tests were triggered.
+	var state syntheticsTestRunModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *syntheticsTestRunResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	// Only `timeout` can be updated in place, which doesn't trigger a new run.
+	var state syntheticsTestRunModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func (r *syntheticsTestRunResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+}
+
+func (r *syntheticsTestRunResource) buildSyntheticsTestRunRequestBody(ctx context.Context, state *syntheticsTestRunModel) (*datadogV1.SyntheticsCITestBody, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	tests := make([]datadogV1.SyntheticsCITest, 0, len(state.Tests))
+	for _, t := range state.Tests {
+		test := datadogV1.NewSyntheticsCITest(t.PublicID.ValueString())
+		if !t.StartURL.IsNull() {
+			test.SetStartUrl(t.StartURL.ValueString())
+		}
+		if !t.Variables.IsNull() {
+			variables := make(map[string]string)
+			diags.Append(t.Variables.ElementsAs(ctx, &variables, false)...)
+			test.SetVariables(variables)
+		}
+		if !t.Locations.IsNull() {
+			var locations []string
+			diags.Append(t.Locations.ElementsAs(ctx, &locations, false)...)
+			test.SetLocations(locations)
+		}
+		tests = append(tests, *test)
+	}
+
+	body := datadogV1.NewSyntheticsCITestBodyWithDefaults()
+	body.SetTests(tests)
+	return body, diags
+}
+
+// waitForBatch polls the results of a batch until all of them are known or the timeout is reached. It returns the
+// latest results and whether all of them are final.
+func (r *syntheticsTestRunResource) waitForBatch(ctx context.Context, batchID string, timeout time.Duration) ([]datadogV1.SyntheticsBatchResult, bool, error) {
+	deadline := time.After(timeout)
+	var results []datadogV1.SyntheticsBatchResult
+	for {
+		batch, httpResp, err := r.Api.GetSyntheticsCIBatch(r.Auth, batchID)
+		// the batch may not be available right after the tests are triggered
+		if err != nil && (httpResp == nil || httpResp.StatusCode != 404) {
+			return results, false, utils.TranslateClientError(err, httpResp, "")
+		}
+		if err == nil {
+			data := batch.GetData()
+			results = data.GetResults()
+			if syntheticsTestRunFinished(results) {
+				return results, true, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return results, false, ctx.Err()
+		case <-deadline:
+			return results, false, nil
+		case <-time.After(syntheticsTestRunPollInterval):
+		}
+	}
+}
+
+func syntheticsTestRunFinished(results []datadogV1.SyntheticsBatchResult) bool {
+	if len(results) == 0 {
+		return false
+	}
+	for _, result := range results {
+		switch string(result.GetStatus()) {
+		case string(datadogV1.SYNTHETICSSTATUS_PASSED), string(datadogV1.SYNTHETICSSTATUS_FAILED), string(datadogV1.SYNTHETICSSTATUS_SKIPPED):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// syntheticsTestRunFailures describes the failed results of blocking tests.
+func syntheticsTestRunFailures(results []datadogV1.SyntheticsBatchResult) []string {
+	var failures []string
+	for _, result := range results {
+		if result.GetStatus() != datadogV1.SYNTHETICSSTATUS_FAILED || result.GetExecutionRule() == datadogV1.SYNTHETICSTESTEXECUTIONRULE_NON_BLOCKING {
+			continue
+		}
+		failures = append(failures, fmt.Sprintf("  %s (%s) failed in %s, result %s", result.GetTestName(), result.GetTestPublicId(), result.GetLocation(), result.GetResultId()))
+	}
+	return failures
+}
+
+func flattenSyntheticsTestRunResults(results []datadogV1.SyntheticsBatchResult) (types.List, diag.Diagnostics) {
+	values := make([]attr.Value, 0, len(results))
+	diags := diag.Diagnostics{}
+	for _, result := range results {
+		value, d := types.ObjectValue(syntheticsTestRunResultType.AttrTypes, map[string]attr.Value{
+			"public_id": types.StringValue(result.GetTestPublicId()),
+			"test_name": types.StringValue(result.GetTestName()),
+			"result_id": types.StringValue(result.GetResultId()),
+			"location":  types.StringValue(result.GetLocation()),
+			"device":    types.StringValue(string(result.GetDevice())),
+			"status":    types.StringValue(string(result.GetStatus())),
+		})
+		diags.Append(d...)
+		values = append(values, value)
+	}
+	list, d := types.ListValue(syntheticsTestRunResultType, values)
+	diags.Append(d...)
+	return list, diags
+}
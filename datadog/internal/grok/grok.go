@@ -0,0 +1,738 @@
+// Package grok implements an offline version of the Datadog grok parser, used to check match rules
+// against sample logs without sending logs to Datadog.
+//
+// Match and support rules are compiled into regular expressions, with the Datadog matchers and filters.
+// As Go regular expressions are used, lookarounds and backreferences in `regex` matchers are not supported.
+package grok
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxDepth is the maximum number of nested support rules
+const maxDepth = 32
+
+// Parser parses logs with match rules, in order, the same way the grok parser processor does.
+type Parser struct {
+	rules []*rule
+}
+
+type rule struct {
+	name     string
+	re       *regexp.Regexp
+	captures map[string]*capture
+}
+
+// capture describes a group of the regular expression of a rule whose value is extracted
+type capture struct {
+	attribute string
+	convert   func(string) (interface{}, bool)
+	filter    *call
+}
+
+// call is a matcher or a filter with its arguments, e.g. `date("yyyy-MM-dd")`
+type call struct {
+	name string
+	args []string
+}
+
+type compiler struct {
+	supportRules map[string]string
+	captures     map[string]*capture
+}
+
+var ruleNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// NewParser compiles match rules and support rules, one rule per line with the name of the rule
+// followed by its pattern.
+func NewParser(matchRules, supportRules string) (*Parser, error) {
+	c := &compiler{supportRules: make(map[string]string)}
+	supportRuleList, err := parseRules(supportRules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid support rules: %w", err)
+	}
+	for _, r := range supportRuleList {
+		c.supportRules[r[0]] = r[1]
+	}
+
+	matchRuleList, err := parseRules(matchRules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match rules: %w", err)
+	}
+	if len(matchRuleList) == 0 {
+		return nil, fmt.Errorf("no match rule defined")
+	}
+
+	p := &Parser{}
+	for _, r := range matchRuleList {
+		c.captures = make(map[string]*capture)
+		pattern, err := c.expand(r[1], nil)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", r[0], err)
+		}
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", r[0], err)
+		}
+		p.rules = append(p.rules, &rule{name: r[0], re: re, captures: c.captures})
+	}
+	return p, nil
+}
+
+// Parse parses a log with the first matching rule, and returns the name of the rule and the extracted attributes.
+func (p *Parser) Parse(log string) (string, map[string]interface{}, bool) {
+	for _, r := range p.rules {
+		match := r.re.FindStringSubmatchIndex(log)
+		if match == nil {
+			continue
+		}
+
+		attributes := make(map[string]interface{})
+		for i, name := range r.re.SubexpNames() {
+			c, ok := r.captures[name]
+			if !ok || match[2*i] < 0 {
+				continue
+			}
+			raw := log[match[2*i]:match[2*i+1]]
+			var value interface{} = raw
+			if c.convert != nil {
+				if converted, ok := c.convert(raw); ok {
+					value = converted
+				}
+			}
+			if c.filter != nil {
+				value = applyFilter(c.filter, value)
+			}
+			setAttribute(attributes, c.attribute, value)
+		}
+		return r.name, attributes, true
+	}
+	return "", nil, false
+}
+
+// RuleNames returns the names of the match rules, in order.
+func (p *Parser) RuleNames() []string {
+	names := make([]string, len(p.rules))
+	for i, r := range p.rules {
+		names[i] = r.name
+	}
+	return names
+}
+
+func parseRules(rules string) ([][2]string, error) {
+	var parsed [][2]string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(rules, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		separator := strings.IndexAny(line, " \t")
+		if separator < 0 || !ruleNameRegex.MatchString(line[:separator]) {
+			return nil, fmt.Errorf("%q must be a rule name followed by a pattern", line)
+		}
+		name, pattern := line[:separator], strings.TrimSpace(line[separator:])
+		if seen[name] {
+			return nil, fmt.Errorf("rule %s is defined twice", name)
+		}
+		seen[name] = true
+		parsed = append(parsed, [2]string{name, pattern})
+	}
+	return parsed, nil
+}
+
+// expand replaces the `%{matcher:attribute:filter}` references of a pattern by regular expressions
+func (c *compiler) expand(pattern string, stack []string) (string, error) {
+	if len(stack) > maxDepth {
+		return "", fmt.Errorf("too many nested support rules")
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(pattern, "%{")
+		if start < 0 {
+			b.WriteString(pattern)
+			return b.String(), nil
+		}
+		end := findClosing(pattern, start+2, '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated reference %q", pattern[start:])
+		}
+		b.WriteString(pattern[:start])
+
+		re, err := c.expandReference(pattern[start+2:end], stack)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(re)
+		pattern = pattern[end+1:]
+	}
+}
+
+func (c *compiler) expandReference(reference string, stack []string) (string, error) {
+	parts := splitTopLevel(reference, ':')
+	if len(parts) > 3 {
+		return "", fmt.Errorf("invalid reference %%{%s}", reference)
+	}
+	m, err := parseCall(parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	var re string
+	var convert func(string) (interface{}, bool)
+	if supportRule, ok := c.supportRules[m.name]; ok {
+		for _, name := range stack {
+			if name == m.name {
+				return "", fmt.Errorf("support rule %s references itself", m.name)
+			}
+		}
+		expanded, err := c.expand(supportRule, append(stack, m.name))
+		if err != nil {
+			return "", fmt.Errorf("support rule %s: %w", m.name, err)
+		}
+		re = expanded
+	} else if newMatcher, ok := matchers[m.name]; ok {
+		re, convert, err = newMatcher(m.args)
+		if err != nil {
+			return "", fmt.Errorf("matcher %s: %w", m.name, err)
+		}
+	} else {
+		return "", fmt.Errorf("unknown matcher or support rule %s", m.name)
+	}
+
+	var attribute string
+	var filter *call
+	if len(parts) > 1 {
+		attribute = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 {
+		if filter, err = parseCall(parts[2]); err != nil {
+			return "", err
+		}
+		if _, ok := filters[filter.name]; !ok {
+			return "", fmt.Errorf("unknown filter %s", filter.name)
+		}
+	}
+	if attribute == "" && filter == nil {
+		return "(?:" + re + ")", nil
+	}
+
+	group := fmt.Sprintf("g%d", len(c.captures))
+	c.captures[group] = &capture{attribute: attribute, convert: convert, filter: filter}
+	return "(?P<" + group + ">" + re + ")", nil
+}
+
+// findClosing returns the index of the closing character matching an opening one before `from`,
+// skipping quoted strings and nested parentheses.
+func findClosing(s string, from int, closing byte) int {
+	depth := 0
+	var quote byte
+	for i := from; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '(':
+			depth++
+		case s[i] == ')':
+			depth--
+		case s[i] == closing && depth == 0:
+			return i
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits a string on a separator which is not in a quoted string or in parentheses
+func splitTopLevel(s string, separator byte) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	last := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '(':
+			depth++
+		case s[i] == ')':
+			depth--
+		case s[i] == separator && depth == 0:
+			parts = append(parts, s[last:i])
+			last = i + 1
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// parseCall parses a matcher or a filter, e.g. `date("yyyy-MM-dd", "UTC")`
+func parseCall(s string) (*call, error) {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '(')
+	if open < 0 {
+		return &call{name: s}, nil
+	}
+	if !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid arguments in %q", s)
+	}
+
+	c := &call{name: strings.TrimSpace(s[:open])}
+	arguments := s[open+1 : len(s)-1]
+	if strings.TrimSpace(arguments) == "" {
+		return c, nil
+	}
+	for _, arg := range splitTopLevel(arguments, ',') {
+		arg = strings.TrimSpace(arg)
+		if len(arg) >= 2 && (arg[0] == '"' || arg[0] == '\'') && arg[len(arg)-1] == arg[0] {
+			arg = unescapeQuoted(arg[1 : len(arg)-1])
+		}
+		c.args = append(c.args, arg)
+	}
+	return c, nil
+}
+
+func unescapeQuoted(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\'') {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// setAttribute sets an attribute in a map, creating the parent objects of dotted attributes.
+// The values of objects extracted without attribute name are added at the root.
+func setAttribute(attributes map[string]interface{}, attribute string, value interface{}) {
+	if value == nil {
+		return
+	}
+	if attribute == "" {
+		if object, ok := value.(map[string]interface{}); ok {
+			for k, v := range object {
+				attributes[k] = v
+			}
+		}
+		return
+	}
+
+	keys := strings.Split(attribute, ".")
+	current := attributes
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+/*
+ * Matchers
+ */
+
+const (
+	numberRegex             = `[+-]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?`
+	integerRegex            = `[+-]?\d+`
+	doubleQuotedStringRegex = `"(?:[^"\\]|\\.)*"`
+	singleQuotedStringRegex = `'(?:[^'\\]|\\.)*'`
+	ipv4Regex               = `(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)`
+	ipv6Regex               = `(?:[0-9A-Fa-f]{0,4}:){2,7}(?:[0-9A-Fa-f]{1,4}|` + ipv4Regex + `)?`
+	hostnameRegex           = `[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)*`
+)
+
+type matcher func(args []string) (string, func(string) (interface{}, bool), error)
+
+// fixedMatcher is a matcher without arguments
+func fixedMatcher(re string, convert func(string) (interface{}, bool)) matcher {
+	return func(args []string) (string, func(string) (interface{}, bool), error) {
+		if len(args) > 0 {
+			return "", nil, fmt.Errorf("no argument expected")
+		}
+		return re, convert, nil
+	}
+}
+
+var matchers map[string]matcher
+
+func init() {
+	matchers = map[string]matcher{
+		"data":               fixedMatcher(`(?s:.*?)`, nil),
+		"notSpace":           fixedMatcher(`\S+`, nil),
+		"word":               fixedMatcher(`\b\w+\b`, nil),
+		"eol":                fixedMatcher(`(?:\r?\n|$)`, nil),
+		"number":             fixedMatcher(numberRegex, convertNumber),
+		"numberStr":          fixedMatcher(numberRegex, nil),
+		"numberExt":          fixedMatcher(`(?:`+numberRegex+`|[+-]?Infinity|NaN)`, convertNumber),
+		"numberExtStr":       fixedMatcher(`(?:`+numberRegex+`|[+-]?Infinity|NaN)`, nil),
+		"integer":            fixedMatcher(integerRegex, convertInteger),
+		"integerStr":         fixedMatcher(integerRegex, nil),
+		"integerExt":         fixedMatcher(`(?:`+integerRegex+`|[+-]?\d+(?:\.\d+)?[eE][+-]?\d+)`, convertInteger),
+		"integerExtStr":      fixedMatcher(`(?:`+integerRegex+`|[+-]?\d+(?:\.\d+)?[eE][+-]?\d+)`, nil),
+		"doubleQuotedString": fixedMatcher(doubleQuotedStringRegex, nil),
+		"singleQuotedString": fixedMatcher(singleQuotedStringRegex, nil),
+		"quotedString":       fixedMatcher(`(?:`+doubleQuotedStringRegex+`|`+singleQuotedStringRegex+`)`, nil),
+		"uuid":               fixedMatcher(`[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}`, nil),
+		"mac":                fixedMatcher(`(?:[0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}`, nil),
+		"ipv4":               fixedMatcher(ipv4Regex, nil),
+		"ipv6":               fixedMatcher(ipv6Regex, nil),
+		"ip":                 fixedMatcher(`(?:`+ipv6Regex+`|`+ipv4Regex+`)`, nil),
+		"hostname":           fixedMatcher(hostnameRegex, nil),
+		"ipOrHost":           fixedMatcher(`(?:`+ipv6Regex+`|`+hostnameRegex+`)`, nil),
+		"port":               fixedMatcher(`\d{1,5}`, convertInteger),
+		"regex":              regexMatcher,
+		"boolean":            booleanMatcher,
+		"date":               dateMatcher,
+	}
+}
+
+func convertNumber(s string) (interface{}, bool) {
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil
+}
+
+func convertInteger(s string) (interface{}, bool) {
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return v, true
+	}
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return int64(v), true
+	}
+	return nil, false
+}
+
+func regexMatcher(args []string) (string, func(string) (interface{}, bool), error) {
+	if len(args) != 1 {
+		return "", nil, fmt.Errorf("one argument expected")
+	}
+	if _, err := regexp.Compile(args[0]); err != nil {
+		return "", nil, err
+	}
+	return args[0], nil, nil
+}
+
+func booleanMatcher(args []string) (string, func(string) (interface{}, bool), error) {
+	trueValue, falseValue := "true", "false"
+	switch len(args) {
+	case 0:
+	case 2:
+		trueValue, falseValue = args[0], args[1]
+	default:
+		return "", nil, fmt.Errorf("zero or two arguments expected")
+	}
+	re := `(?i:` + regexp.QuoteMeta(trueValue) + `|` + regexp.QuoteMeta(falseValue) + `)`
+	return re, func(s string) (interface{}, bool) {
+		return strings.EqualFold(s, trueValue), true
+	}, nil
+}
+
+// dateMatcher matches dates in a Java date format, with an optional time zone, and extracts them as
+// timestamps in milliseconds.
+func dateMatcher(args []string) (string, func(string) (interface{}, bool), error) {
+	if len(args) < 1 || len(args) > 3 {
+		return "", nil, fmt.Errorf("a date format and an optional time zone expected")
+	}
+	location := time.UTC
+	if len(args) > 1 {
+		l, err := time.LoadLocation(args[1])
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid time zone %s", args[1])
+		}
+		location = l
+	}
+
+	re, layout, err := convertJavaDateFormat(args[0])
+	if err != nil {
+		return "", nil, err
+	}
+	return re, func(s string) (interface{}, bool) {
+		t, err := time.ParseInLocation(layout, s, location)
+		if err != nil {
+			return nil, false
+		}
+		return t.UnixMilli(), true
+	}, nil
+}
+
+// javaDateTokens maps the letters of Java date formats, by number of repetitions, to a regular expression
+// and a Go time layout
+var javaDateTokens = map[byte][]struct {
+	re     string
+	layout string
+}{
+	'y': {{`\d{4}`, "2006"}, {`\d{2}`, "06"}, {`\d{4}`, "2006"}, {`\d{4}`, "2006"}},
+	'M': {{`\d{1,2}`, "1"}, {`\d{2}`, "01"}, {`[A-Za-z]{3}`, "Jan"}, {`[A-Za-z]+`, "January"}},
+	'd': {{`\d{1,2}`, "2"}, {`\d{2}`, "02"}},
+	'H': {{`\d{1,2}`, "15"}, {`\d{2}`, "15"}},
+	'h': {{`\d{1,2}`, "3"}, {`\d{2}`, "03"}},
+	'm': {{`\d{1,2}`, "4"}, {`\d{2}`, "04"}},
+	's': {{`\d{1,2}`, "5"}, {`\d{2}`, "05"}},
+	'E': {{`[A-Za-z]{3}`, "Mon"}, {`[A-Za-z]{3}`, "Mon"}, {`[A-Za-z]{3}`, "Mon"}, {`[A-Za-z]+`, "Monday"}},
+	'a': {{`[AaPp][Mm]`, "PM"}},
+	'Z': {{`[+-]\d{4}`, "-0700"}, {`[+-]\d{2}:\d{2}`, "-07:00"}},
+	'X': {{`(?:Z|[+-]\d{2})`, "Z07"}, {`(?:Z|[+-]\d{4})`, "Z0700"}, {`(?:Z|[+-]\d{2}:\d{2})`, "Z07:00"}},
+	'z': {{`[A-Z]{2,5}`, "MST"}},
+}
+
+func convertJavaDateFormat(format string) (string, string, error) {
+	var re, layout strings.Builder
+	for i := 0; i < len(format); {
+		ch := format[i]
+		if ch == '\'' {
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end < 0 {
+				return "", "", fmt.Errorf("unterminated quote in date format %s", format)
+			}
+			literal := format[i+1 : i+1+end]
+			if literal == "" {
+				literal = "'"
+			}
+			re.WriteString(regexp.QuoteMeta(literal))
+			layout.WriteString(literal)
+			i += end + 2
+			continue
+		}
+
+		count := 1
+		for i+count < len(format) && format[i+count] == ch {
+			count++
+		}
+		if ch == 'S' {
+			// Go only parses fractional seconds after a dot or a comma, which the format already contains
+			re.WriteString(fmt.Sprintf(`\d{%d}`, count))
+			layout.WriteString(strings.Repeat("0", count))
+		} else if tokens, ok := javaDateTokens[ch]; ok {
+			token := tokens[min(count, len(tokens))-1]
+			re.WriteString(token.re)
+			layout.WriteString(token.layout)
+		} else if (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') {
+			return "", "", fmt.Errorf("unsupported letter %c in date format %s", ch, format)
+		} else {
+			re.WriteString(regexp.QuoteMeta(format[i : i+count]))
+			layout.WriteString(format[i : i+count])
+		}
+		i += count
+	}
+	return re.String(), layout.String(), nil
+}
+
+/*
+ * Filters
+ */
+
+var filters map[string]func(args []string, value interface{}) interface{}
+
+func init() {
+	filters = map[string]func(args []string, value interface{}) interface{}{
+		"number":             numberFilter,
+		"integer":            integerFilter,
+		"boolean":            booleanFilter,
+		"nullIf":             nullIfFilter,
+		"lowercase":          func(_ []string, value interface{}) interface{} { return strings.ToLower(fmt.Sprint(value)) },
+		"uppercase":          func(_ []string, value interface{}) interface{} { return strings.ToUpper(fmt.Sprint(value)) },
+		"json":               jsonFilter,
+		"keyvalue":           keyValueFilter,
+		"decodeuricomponent": decodeURIComponentFilter,
+		"querystring":        queryStringFilter,
+		"scale":              scaleFilter,
+		"array":              arrayFilter,
+		// The following filters are not evaluated offline, the matched value is kept as is
+		"url":       keepFilter,
+		"useragent": keepFilter,
+		"xml":       keepFilter,
+		"csv":       keepFilter,
+		"rubyhash":  keepFilter,
+	}
+}
+
+func applyFilter(filter *call, value interface{}) interface{} {
+	return filters[filter.name](filter.args, value)
+}
+
+func keepFilter(_ []string, value interface{}) interface{} {
+	return value
+}
+
+func numberFilter(_ []string, value interface{}) interface{} {
+	if v, ok := convertNumber(fmt.Sprint(value)); ok {
+		return v
+	}
+	return nil
+}
+
+func integerFilter(_ []string, value interface{}) interface{} {
+	if v, ok := convertInteger(fmt.Sprint(value)); ok {
+		return v
+	}
+	return nil
+}
+
+func booleanFilter(args []string, value interface{}) interface{} {
+	s := fmt.Sprint(value)
+	if len(args) == 2 {
+		switch {
+		case strings.EqualFold(s, args[0]):
+			return true
+		case strings.EqualFold(s, args[1]):
+			return false
+		}
+		return nil
+	}
+	if v, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+		return v
+	}
+	return nil
+}
+
+func nullIfFilter(args []string, value interface{}) interface{} {
+	if len(args) == 1 && fmt.Sprint(value) == args[0] {
+		return nil
+	}
+	return value
+}
+
+func jsonFilter(_ []string, value interface{}) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(fmt.Sprint(value)), &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
+
+// keyValueFilter extracts `key=value` pairs, with the optional separator, additional allowed characters
+// and quoting strings arguments of the filter.
+func keyValueFilter(args []string, value interface{}) interface{} {
+	separator, allowed := "=", ""
+	quotes := []string{`""`, `''`, `<>`}
+	if len(args) > 0 && args[0] != "" {
+		separator = args[0]
+	}
+	if len(args) > 1 {
+		allowed = args[1]
+	}
+	if len(args) > 2 && args[2] != "" {
+		quotes = nil
+		for i := 0; i+1 < len(args[2]); i += 2 {
+			quotes = append(quotes, args[2][i:i+2])
+		}
+	}
+
+	chars := `[\w.\-@` + regexp.QuoteMeta(allowed) + `]`
+	valuePatterns := []string{chars + `*`}
+	for _, q := range quotes {
+		open, closing := regexp.QuoteMeta(q[:1]), regexp.QuoteMeta(q[1:])
+		valuePatterns = append([]string{open + `[^` + closing + `]*` + closing}, valuePatterns...)
+	}
+	re, err := regexp.Compile(`(` + chars + `+)` + regexp.QuoteMeta(separator) + `(` + strings.Join(valuePatterns, "|") + `)`)
+	if err != nil {
+		return nil
+	}
+
+	result := make(map[string]interface{})
+	for _, match := range re.FindAllStringSubmatch(fmt.Sprint(value), -1) {
+		v := match[2]
+		for _, q := range quotes {
+			if len(v) >= 2 && strings.HasPrefix(v, q[:1]) && strings.HasSuffix(v, q[1:]) {
+				v = v[1 : len(v)-1]
+				break
+			}
+		}
+		if v != "" {
+			result[match[1]] = v
+		}
+	}
+	return result
+}
+
+func decodeURIComponentFilter(_ []string, value interface{}) interface{} {
+	decoded, err := url.PathUnescape(fmt.Sprint(value))
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+func queryStringFilter(_ []string, value interface{}) interface{} {
+	query, err := url.ParseQuery(strings.TrimPrefix(fmt.Sprint(value), "?"))
+	if err != nil {
+		return nil
+	}
+	result := make(map[string]interface{})
+	for k, v := range query {
+		result[k] = v[0]
+	}
+	return result
+}
+
+func scaleFilter(args []string, value interface{}) interface{} {
+	if len(args) != 1 {
+		return nil
+	}
+	factor, err := strconv.ParseFloat(args[0], 64)
+	if err != nil {
+		return nil
+	}
+	v, ok := convertNumber(fmt.Sprint(value))
+	if !ok {
+		return nil
+	}
+	return v.(float64) * factor
+}
+
+// arrayFilter splits a list, with the optional opening and closing characters and separator arguments
+// of the filter, and an optional filter applied to each element.
+func arrayFilter(args []string, value interface{}) interface{} {
+	separator := ","
+	var elementFilter *call
+	if len(args) > 0 {
+		if _, ok := filters[args[len(args)-1]]; ok {
+			elementFilter = &call{name: args[len(args)-1]}
+			args = args[:len(args)-1]
+		}
+	}
+
+	s := strings.TrimSpace(fmt.Sprint(value))
+	switch len(args) {
+	case 1:
+		separator = args[0]
+	case 2:
+		if len(args[0]) == 2 {
+			s = strings.TrimSuffix(strings.TrimPrefix(s, args[0][:1]), args[0][1:])
+		}
+		separator = args[1]
+	}
+
+	elements := make([]interface{}, 0)
+	if s == "" {
+		return elements
+	}
+	for _, element := range strings.Split(s, separator) {
+		var v interface{} = strings.TrimSpace(element)
+		if elementFilter != nil {
+			v = applyFilter(elementFilter, v)
+		}
+		elements = append(elements, v)
+	}
+	return elements
+}
@@ -0,0 +1,125 @@
+package grok
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewParserValidation(t *testing.T) {
+	cases := map[string]struct {
+		matchRules   string
+		supportRules string
+		valid        bool
+	}{
+		"simple":                  {`rule %{word:user} connected`, "", true},
+		"support rule":            {`rule %{user} connected`, `user %{word:user.name}`, true},
+		"filter with arguments":   {`rule %{data:attrs:keyvalue("=", "/:")}`, "", true},
+		"date with colons":        {`rule %{date("yyyy-MM-dd HH:mm:ss"):date}`, "", true},
+		"regex with braces":       {`rule %{regex("[a-z]{3}"):code}`, "", true},
+		"tab separator":           {"rule\t%{word:user}", "", true},
+		"no match rule":           {"", "", false},
+		"missing pattern":         {"rule", "", false},
+		"duplicate rule":          {"rule %{word}\nrule %{word}", "", false},
+		"unknown matcher":         {`rule %{unknown:user}`, "", false},
+		"unknown filter":          {`rule %{word:user:unknown}`, "", false},
+		"unterminated reference":  {`rule %{word:user`, "", false},
+		"recursive support rules": {`rule %{a}`, "a %{b}\nb %{a}", false},
+		"invalid regex":           {`rule %{regex("[a-z"):code}`, "", false},
+		"lookahead":               {`rule %{regex("(?=a)a"):code}`, "", false},
+		"invalid date format":     {`rule %{date("yyyy-MM-dd'T"):date}`, "", false},
+		"invalid time zone":       {`rule %{date("yyyy", "Mars/Olympus"):date}`, "", false},
+	}
+	for name, tc := range cases {
+		_, err := NewParser(tc.matchRules, tc.supportRules)
+		if tc.valid && err != nil {
+			t.Errorf("%s: expected rules to be valid, got %s", name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected rules to be invalid", name)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		matchRules   string
+		supportRules string
+		log          string
+		rule         string
+		attributes   map[string]interface{}
+	}{
+		"words and integers": {
+			matchRules: `rule %{word:user} connected on %{integer:port}`,
+			log:        "john connected on 8080",
+			rule:       "rule",
+			attributes: map[string]interface{}{"user": "john", "port": int64(8080)},
+		},
+		"first matching rule": {
+			matchRules: "login %{word:user} logged in\nlogout %{word:user} logged out",
+			log:        "john logged out",
+			rule:       "logout",
+			attributes: map[string]interface{}{"user": "john"},
+		},
+		"nested attributes from support rule": {
+			matchRules:   `rule %{_client} %{number:duration:scale(1000)}`,
+			supportRules: `_client %{ip:network.client.ip}:%{port:network.client.port}`,
+			log:          "10.0.0.1:443 0.25",
+			rule:         "rule",
+			attributes: map[string]interface{}{
+				"network":  map[string]interface{}{"client": map[string]interface{}{"ip": "10.0.0.1", "port": int64(443)}},
+				"duration": 250.0,
+			},
+		},
+		"date": {
+			matchRules: `rule \[%{date("dd/MMM/yyyy:HH:mm:ss Z"):date}\] %{data:message}`,
+			log:        "[06/Mar/2013:01:36:30 +0900] GET /",
+			rule:       "rule",
+			attributes: map[string]interface{}{"date": int64(1362501390000), "message": "GET /"},
+		},
+		"keyvalue at root": {
+			matchRules: `rule %{data::keyvalue}`,
+			log:        `user=john status="not found" empty=`,
+			rule:       "rule",
+			attributes: map[string]interface{}{"user": "john", "status": "not found"},
+		},
+		"json and nullIf": {
+			matchRules: `rule %{notSpace:user:nullIf("-")} %{data:payload:json}`,
+			log:        `- {"a": [1, true]}`,
+			rule:       "rule",
+			attributes: map[string]interface{}{"payload": map[string]interface{}{"a": []interface{}{1.0, true}}},
+		},
+		"array and querystring": {
+			matchRules: `rule %{notSpace:ids:array("[]", ",", integer)} %{notSpace:query:querystring}`,
+			log:        "[1,2,3] ?a=b&c=d",
+			rule:       "rule",
+			attributes: map[string]interface{}{
+				"ids":   []interface{}{int64(1), int64(2), int64(3)},
+				"query": map[string]interface{}{"a": "b", "c": "d"},
+			},
+		},
+		"no match": {
+			matchRules: `rule %{integer:port}`,
+			log:        "not a port",
+		},
+	}
+	for name, tc := range cases {
+		p, err := NewParser(tc.matchRules, tc.supportRules)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		rule, attributes, ok := p.Parse(tc.log)
+		if tc.rule == "" {
+			if ok {
+				t.Errorf("%s: expected no rule to match, got %s", name, rule)
+			}
+			continue
+		}
+		if !ok || rule != tc.rule {
+			t.Errorf("%s: expected rule %s to match, got %q", name, tc.rule, rule)
+			continue
+		}
+		if !reflect.DeepEqual(attributes, tc.attributes) {
+			t.Errorf("%s: expected attributes %#v, got %#v", name, tc.attributes, attributes)
+		}
+	}
+}
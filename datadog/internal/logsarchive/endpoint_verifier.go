@@ -0,0 +1,68 @@
+package logsarchive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// EndpointVerifier checks the format of the destinations, then that their bucket or container exists on a storage
+// endpoint, such as a local S3, GCS or Azure Blob Storage emulator. Destinations whose type has no endpoint are only
+// checked for format.
+type EndpointVerifier struct {
+	// S3Endpoint is the URL of an S3 API served with path-style addressing, e.g. `http://localhost:9000`
+	S3Endpoint string
+	// GCSEndpoint is the URL of a GCS JSON API, e.g. `http://localhost:4443`
+	GCSEndpoint string
+	// AzureEndpoint is the URL of a Blob Storage API addressing accounts by path, e.g. `http://localhost:10000`
+	AzureEndpoint string
+	// Client defaults to http.DefaultClient
+	Client *http.Client
+}
+
+// Verify implements Verifier.
+func (v *EndpointVerifier) Verify(ctx context.Context, destination Destination) error {
+	if err := Validate(destination); err != nil {
+		return err
+	}
+
+	// Buckets and containers support HEAD requests, except in the GCS JSON API
+	method := http.MethodHead
+	var endpoint, resource string
+	switch destination.Type {
+	case S3:
+		endpoint, resource = v.S3Endpoint, url.PathEscape(destination.Bucket)
+	case GCS:
+		method = http.MethodGet
+		endpoint, resource = v.GCSEndpoint, "storage/v1/b/"+url.PathEscape(destination.Bucket)
+	case Azure:
+		endpoint, resource = v.AzureEndpoint, url.PathEscape(destination.StorageAccount)+"/"+url.PathEscape(destination.Bucket)+"?restype=container"
+	}
+	if endpoint == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(endpoint, "/")+"/"+resource, nil)
+	if err != nil {
+		return err
+	}
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error reaching %s: %w", endpoint, err)
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%s bucket %q does not exist on %s", destination.Type, destination.Bucket, endpoint)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("error checking %s bucket %q on %s: %s", destination.Type, destination.Bucket, endpoint, resp.Status)
+	}
+	return nil
+}
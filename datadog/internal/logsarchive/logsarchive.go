@@ -0,0 +1,177 @@
+// Package logsarchive implements preflight checks of the destinations of logs archives, so that misconfigured
+// buckets, containers and credentials are reported before logs are sent to them.
+package logsarchive
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// Destination types
+const (
+	S3    = "s3"
+	GCS   = "gcs"
+	Azure = "azure"
+)
+
+// Destination is the destination of a logs archive. Only the fields of its type are set.
+type Destination struct {
+	Type string
+	// Bucket is the S3 or GCS bucket, or the Azure container
+	Bucket string
+	Path   string
+	// S3
+	AccountID string
+	RoleName  string
+	// GCS
+	ClientEmail string
+	ProjectID   string
+	// Azure
+	StorageAccount string
+	ClientID       string
+	TenantID       string
+}
+
+// Verifier checks that a destination can receive archives.
+type Verifier interface {
+	Verify(ctx context.Context, destination Destination) error
+}
+
+// FormatVerifier checks the format of the destinations, without reaching them.
+type FormatVerifier struct{}
+
+// Verify implements Verifier.
+func (FormatVerifier) Verify(_ context.Context, destination Destination) error {
+	return Validate(destination)
+}
+
+var (
+	s3BucketRegex          = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	iamRoleNameRegex       = regexp.MustCompile(`^[\w+=,.@-]{1,64}$`)
+	gcsBucketRegex         = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,220}[a-z0-9]$`)
+	gcpProjectIDRegex      = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+	azureStorageRegex      = regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+	azureContainerRegex    = regexp.MustCompile(`^[a-z0-9](-?[a-z0-9])+$`)
+	uuidRegex              = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	gcpServiceAccountRegex = regexp.MustCompile(`\.gserviceaccount\.com$`)
+)
+
+// Validate checks the naming rules of the bucket, the syntax of the path and the format of the credentials of a
+// destination.
+func Validate(destination Destination) error {
+	var err error
+	switch destination.Type {
+	case S3:
+		err = validateS3(destination)
+	case GCS:
+		err = validateGCS(destination)
+	case Azure:
+		err = validateAzure(destination)
+	default:
+		return fmt.Errorf("unknown destination type %q", destination.Type)
+	}
+	if err != nil {
+		return err
+	}
+	return ValidatePath(destination.Path)
+}
+
+func validateS3(destination Destination) error {
+	bucket := destination.Bucket
+	switch {
+	case !s3BucketRegex.MatchString(bucket):
+		return fmt.Errorf("invalid S3 bucket %q: it must be 3 to 63 lowercase letters, numbers, dots and hyphens, starting and ending with a letter or number", bucket)
+	case strings.Contains(bucket, ".."), strings.Contains(bucket, ".-"), strings.Contains(bucket, "-."):
+		return fmt.Errorf("invalid S3 bucket %q: dots can't be adjacent to another dot or a hyphen", bucket)
+	case net.ParseIP(bucket) != nil:
+		return fmt.Errorf("invalid S3 bucket %q: it can't be formatted as an IP address", bucket)
+	case strings.HasPrefix(bucket, "xn--"), strings.HasPrefix(bucket, "sthree-"):
+		return fmt.Errorf("invalid S3 bucket %q: the prefixes `xn--` and `sthree-` are reserved", bucket)
+	case strings.HasSuffix(bucket, "-s3alias"), strings.HasSuffix(bucket, "--ol-s3"):
+		return fmt.Errorf("invalid S3 bucket %q: the suffixes `-s3alias` and `--ol-s3` are reserved", bucket)
+	}
+
+	roleName := destination.RoleName
+	if strings.HasPrefix(roleName, "arn:") {
+		return fmt.Errorf("invalid AWS role name %q: use the name of the role, not its ARN", roleName)
+	}
+	if !iamRoleNameRegex.MatchString(roleName) {
+		return fmt.Errorf("invalid AWS role name %q: it must be 1 to 64 letters, numbers and `+=,.@_-` characters", roleName)
+	}
+	return nil
+}
+
+func validateGCS(destination Destination) error {
+	bucket := destination.Bucket
+	if !gcsBucketRegex.MatchString(bucket) {
+		return fmt.Errorf("invalid GCS bucket %q: it must be 3 to 222 lowercase letters, numbers, dots, hyphens and underscores, starting and ending with a letter or number", bucket)
+	}
+	for _, component := range strings.Split(bucket, ".") {
+		if len(component) == 0 || len(component) > 63 {
+			return fmt.Errorf("invalid GCS bucket %q: each dot-separated component must be 1 to 63 characters", bucket)
+		}
+	}
+	if !strings.Contains(bucket, ".") && len(bucket) > 63 {
+		return fmt.Errorf("invalid GCS bucket %q: it can't be longer than 63 characters without dots", bucket)
+	}
+	if net.ParseIP(bucket) != nil {
+		return fmt.Errorf("invalid GCS bucket %q: it can't be formatted as an IP address", bucket)
+	}
+	if strings.HasPrefix(bucket, "goog") || strings.Contains(bucket, "google") {
+		return fmt.Errorf("invalid GCS bucket %q: it can't start with `goog` or contain `google`", bucket)
+	}
+
+	if address, err := mail.ParseAddress(destination.ClientEmail); err != nil || address.Address != destination.ClientEmail || !gcpServiceAccountRegex.MatchString(destination.ClientEmail) {
+		return fmt.Errorf("invalid GCP client email %q: it must be the email of a service account, ending with `.gserviceaccount.com`", destination.ClientEmail)
+	}
+	if destination.ProjectID != "" && !gcpProjectIDRegex.MatchString(destination.ProjectID) {
+		return fmt.Errorf("invalid GCP project id %q: it must be 6 to 30 lowercase letters, numbers and hyphens, starting with a letter", destination.ProjectID)
+	}
+	return nil
+}
+
+func validateAzure(destination Destination) error {
+	if !azureStorageRegex.MatchString(destination.StorageAccount) {
+		return fmt.Errorf("invalid Azure storage account %q: it must be 3 to 24 lowercase letters and numbers", destination.StorageAccount)
+	}
+	container := destination.Bucket
+	if len(container) < 3 || len(container) > 63 || !azureContainerRegex.MatchString(container) {
+		return fmt.Errorf("invalid Azure container %q: it must be 3 to 63 lowercase letters, numbers and single hyphens, starting and ending with a letter or number", container)
+	}
+	if !uuidRegex.MatchString(destination.ClientID) {
+		return fmt.Errorf("invalid Azure client id %q: it must be a UUID", destination.ClientID)
+	}
+	if !uuidRegex.MatchString(destination.TenantID) {
+		return fmt.Errorf("invalid Azure tenant id %q: it must be a UUID", destination.TenantID)
+	}
+	return nil
+}
+
+// ValidatePath checks the syntax of the path of archives in their bucket. The path is optional.
+func ValidatePath(path string) error {
+	if len(path) > 1024 {
+		return fmt.Errorf("invalid path %q: it can't be longer than 1024 characters", path)
+	}
+	for _, r := range path {
+		if r < 0x20 || r == 0x7f || r == '\\' {
+			return fmt.Errorf("invalid path %q: it can't contain backslashes or control characters", path)
+		}
+	}
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil
+	}
+	for _, segment := range strings.Split(trimmed, "/") {
+		switch segment {
+		case "":
+			return fmt.Errorf("invalid path %q: it can't contain empty segments", path)
+		case ".", "..":
+			return fmt.Errorf("invalid path %q: it can't contain relative segments", path)
+		}
+	}
+	return nil
+}
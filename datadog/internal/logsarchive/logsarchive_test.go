@@ -0,0 +1,101 @@
+package logsarchive
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	s3 := func(bucket, path, roleName string) Destination {
+		return Destination{Type: S3, Bucket: bucket, Path: path, AccountID: "123456789012", RoleName: roleName}
+	}
+	gcs := func(bucket, clientEmail, projectID string) Destination {
+		return Destination{Type: GCS, Bucket: bucket, ClientEmail: clientEmail, ProjectID: projectID}
+	}
+	azure := func(storageAccount, container, clientID string) Destination {
+		return Destination{Type: Azure, Bucket: container, StorageAccount: storageAccount, ClientID: clientID, TenantID: "0c4b52d8-5d0e-4b8a-9d8b-4b0b7f1c2e3a"}
+	}
+	const serviceAccount = "archives@my-project.iam.gserviceaccount.com"
+	const clientID = "a1b2c3d4-e5f6-7890-abcd-ef1234567890"
+
+	cases := map[string]struct {
+		destination Destination
+		valid       bool
+	}{
+		"s3":                      {s3("my-bucket.logs", "/archives/prod", "datadog-archives"), true},
+		"s3 uppercase bucket":     {s3("My-Bucket", "", "datadog-archives"), false},
+		"s3 short bucket":         {s3("ab", "", "datadog-archives"), false},
+		"s3 adjacent dots":        {s3("my..bucket", "", "datadog-archives"), false},
+		"s3 dot hyphen":           {s3("my.-bucket", "", "datadog-archives"), false},
+		"s3 ip bucket":            {s3("192.168.1.1", "", "datadog-archives"), false},
+		"s3 reserved prefix":      {s3("xn--bucket", "", "datadog-archives"), false},
+		"s3 reserved suffix":      {s3("bucket-s3alias", "", "datadog-archives"), false},
+		"s3 role arn":             {s3("my-bucket", "", "arn:aws:iam::123456789012:role/datadog-archives"), false},
+		"s3 role with space":      {s3("my-bucket", "", "datadog archives"), false},
+		"gcs":                     {gcs("my_bucket.example.com", serviceAccount, "my-project"), true},
+		"gcs without project":     {gcs("my-bucket", serviceAccount, ""), true},
+		"gcs google bucket":       {gcs("my-google-bucket", serviceAccount, ""), false},
+		"gcs long component":      {gcs(strings.Repeat("a", 64)+".example.com", serviceAccount, ""), false},
+		"gcs user email":          {gcs("my-bucket", "someone@example.com", ""), false},
+		"gcs invalid project":     {gcs("my-bucket", serviceAccount, "1project"), false},
+		"azure":                   {azure("mystorage", "my-container", clientID), true},
+		"azure storage hyphen":    {azure("my-storage", "my-container", clientID), false},
+		"azure double hyphen":     {azure("mystorage", "my--container", clientID), false},
+		"azure client id":         {azure("mystorage", "my-container", "my-client"), false},
+		"path relative segment":   {s3("my-bucket", "/archives/../prod", "datadog-archives"), false},
+		"path empty segment":      {s3("my-bucket", "archives//prod", "datadog-archives"), false},
+		"path backslash":          {s3("my-bucket", "archives\\prod", "datadog-archives"), false},
+		"path trailing slash":     {s3("my-bucket", "/archives/", "datadog-archives"), true},
+		"unknown destination":     {Destination{Type: "ftp"}, false},
+		"s3 bucket ending hyphen": {s3("my-bucket-", "", "datadog-archives"), false},
+	}
+	for name, tc := range cases {
+		err := Validate(tc.destination)
+		if tc.valid != (err == nil) {
+			t.Errorf("%s: expected valid %t, got error %v", name, tc.valid, err)
+		}
+	}
+}
+
+func TestEndpointVerifier(t *testing.T) {
+	// Stand-in for the S3, GCS and Azure emulators, only knowing the `logs` bucket of the `mystorage` account
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/logs":
+		case r.Method == http.MethodGet && r.URL.Path == "/storage/v1/b/logs":
+		case r.Method == http.MethodHead && r.URL.Path == "/mystorage/logs" && r.URL.Query().Get("restype") == "container":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	verifier := &EndpointVerifier{S3Endpoint: server.URL, GCSEndpoint: server.URL + "/", AzureEndpoint: server.URL, Client: server.Client()}
+	cases := map[string]struct {
+		destination Destination
+		valid       bool
+	}{
+		"existing s3 bucket":       {Destination{Type: S3, Bucket: "logs", RoleName: "datadog"}, true},
+		"missing s3 bucket":        {Destination{Type: S3, Bucket: "other", RoleName: "datadog"}, false},
+		"invalid s3 bucket":        {Destination{Type: S3, Bucket: "Logs", RoleName: "datadog"}, false},
+		"existing gcs bucket":      {Destination{Type: GCS, Bucket: "logs", ClientEmail: "a@b.iam.gserviceaccount.com"}, true},
+		"missing gcs bucket":       {Destination{Type: GCS, Bucket: "other", ClientEmail: "a@b.iam.gserviceaccount.com"}, false},
+		"existing azure container": {Destination{Type: Azure, Bucket: "logs", StorageAccount: "mystorage", ClientID: "a1b2c3d4-e5f6-7890-abcd-ef1234567890", TenantID: "a1b2c3d4-e5f6-7890-abcd-ef1234567890"}, true},
+		"missing azure container":  {Destination{Type: Azure, Bucket: "logs", StorageAccount: "otherstorage", ClientID: "a1b2c3d4-e5f6-7890-abcd-ef1234567890", TenantID: "a1b2c3d4-e5f6-7890-abcd-ef1234567890"}, false},
+	}
+	for name, tc := range cases {
+		err := verifier.Verify(context.Background(), tc.destination)
+		if tc.valid != (err == nil) {
+			t.Errorf("%s: expected valid %t, got error %v", name, tc.valid, err)
+		}
+	}
+
+	// Destinations without endpoint are only checked for format
+	formatOnly := &EndpointVerifier{}
+	if err := formatOnly.Verify(context.Background(), Destination{Type: S3, Bucket: "other", RoleName: "datadog"}); err != nil {
+		t.Errorf("expected no error without endpoint, got %v", err)
+	}
+}
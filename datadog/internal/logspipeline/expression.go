@@ -0,0 +1,150 @@
+package logspipeline
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// expression evaluates the expressions of arithmetic processors, with the `+`, `-`, `*`, `/` and `%` operators,
+// parentheses, numbers and attributes.
+type expression struct {
+	input          string
+	pos            int
+	log            map[string]interface{}
+	replaceMissing bool
+	// missing is set when an attribute is missing, or is not a number, and missing attributes are not replaced
+	missing bool
+}
+
+func (e *expression) evaluate() (float64, error) {
+	value, err := e.parseSum()
+	if err != nil {
+		return 0, err
+	}
+	e.skipSpaces()
+	if e.pos < len(e.input) {
+		return 0, fmt.Errorf("unexpected %q in expression at position %d", e.input[e.pos:], e.pos)
+	}
+	return value, nil
+}
+
+func (e *expression) skipSpaces() {
+	for e.pos < len(e.input) && unicode.IsSpace(rune(e.input[e.pos])) {
+		e.pos++
+	}
+}
+
+func (e *expression) parseSum() (float64, error) {
+	value, err := e.parseProduct()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		e.skipSpaces()
+		if e.pos >= len(e.input) || (e.input[e.pos] != '+' && e.input[e.pos] != '-') {
+			return value, nil
+		}
+		operator := e.input[e.pos]
+		e.pos++
+		operand, err := e.parseProduct()
+		if err != nil {
+			return 0, err
+		}
+		if operator == '+' {
+			value += operand
+		} else {
+			value -= operand
+		}
+	}
+}
+
+func (e *expression) parseProduct() (float64, error) {
+	value, err := e.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		e.skipSpaces()
+		if e.pos >= len(e.input) || (e.input[e.pos] != '*' && e.input[e.pos] != '/' && e.input[e.pos] != '%') {
+			return value, nil
+		}
+		operator := e.input[e.pos]
+		e.pos++
+		operand, err := e.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		switch operator {
+		case '*':
+			value *= operand
+		case '/':
+			value /= operand
+		case '%':
+			value = math.Mod(value, operand)
+		}
+	}
+}
+
+func (e *expression) parseUnary() (float64, error) {
+	e.skipSpaces()
+	if e.pos < len(e.input) && e.input[e.pos] == '-' {
+		e.pos++
+		value, err := e.parseUnary()
+		return -value, err
+	}
+	return e.parsePrimary()
+}
+
+func (e *expression) parsePrimary() (float64, error) {
+	if e.pos >= len(e.input) {
+		return 0, fmt.Errorf("unexpected end of expression")
+	}
+	if e.input[e.pos] == '(' {
+		e.pos++
+		value, err := e.parseSum()
+		if err != nil {
+			return 0, err
+		}
+		e.skipSpaces()
+		if e.pos >= len(e.input) || e.input[e.pos] != ')' {
+			return 0, fmt.Errorf("missing closing parenthesis in expression at position %d", e.pos)
+		}
+		e.pos++
+		return value, nil
+	}
+
+	start := e.pos
+	for e.pos < len(e.input) && isOperandChar(e.input[e.pos]) {
+		e.pos++
+	}
+	operand := e.input[start:e.pos]
+	if operand == "" {
+		return 0, fmt.Errorf("unexpected %q in expression at position %d", e.input[e.pos:e.pos+1], e.pos)
+	}
+	if unicode.IsDigit(rune(operand[0])) || operand[0] == '.' {
+		value, err := strconv.ParseFloat(operand, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q in expression", operand)
+		}
+		return value, nil
+	}
+
+	if value, ok := getAttribute(e.log, operand); ok {
+		if number, ok := value.(float64); ok {
+			return number, nil
+		}
+		if number, err := strconv.ParseFloat(fmt.Sprint(value), 64); err == nil {
+			return number, nil
+		}
+	}
+	if !e.replaceMissing {
+		e.missing = true
+	}
+	return 0, nil
+}
+
+func isOperandChar(c byte) bool {
+	return c == '.' || c == '_' || c == '@' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
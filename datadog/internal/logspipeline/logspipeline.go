@@ -0,0 +1,586 @@
+// Package logspipeline simulates Datadog log pipelines locally, to check the result of their processors on sample logs.
+//
+// Pipelines are read in the shape of the `datadog_logs_custom_pipeline` resource once encoded in JSON, where blocks are
+// lists of objects. Processors depending on data only available in Datadog, such as the GeoIP database or reference
+// tables, are skipped and reported.
+package logspipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/grok"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/logsquery"
+)
+
+// Result is the result of the simulation of pipelines on a log.
+type Result struct {
+	// Log is the processed log
+	Log map[string]interface{}
+	// MatchedPipelines are the names of the pipelines whose filter matched the log, nested ones being prefixed with
+	// the name of their parent
+	MatchedPipelines []string
+	// SkippedProcessors are the processors which could not be evaluated locally
+	SkippedProcessors []string
+}
+
+type processorFunc func(log map[string]interface{}, processor map[string]interface{}) error
+
+var processors map[string]processorFunc
+
+func init() {
+	processors = map[string]processorFunc{
+		"arithmetic_processor":     arithmeticProcessor,
+		"attribute_remapper":       attributeRemapper,
+		"category_processor":       categoryProcessor,
+		"date_remapper":            dateRemapper,
+		"grok_parser":              grokParser,
+		"lookup_processor":         lookupProcessor,
+		"message_remapper":         reservedAttributeRemapper("message"),
+		"service_remapper":         reservedAttributeRemapper("service"),
+		"status_remapper":          statusRemapper,
+		"string_builder_processor": stringBuilderProcessor,
+		"trace_id_remapper":        reservedAttributeRemapper("dd.trace_id"),
+		"url_parser":               urlParser,
+		"user_agent_parser":        userAgentParser,
+	}
+}
+
+// Simulate runs a log through pipelines, in order. The log is not modified.
+func Simulate(log map[string]interface{}, pipelines []map[string]interface{}) (*Result, error) {
+	result := &Result{Log: deepCopy(log).(map[string]interface{})}
+	for i, pipeline := range pipelines {
+		name := getString(pipeline, "name")
+		if name == "" {
+			name = fmt.Sprintf("pipeline %d", i)
+		}
+		if err := simulatePipeline(result, pipeline, name); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func simulatePipeline(result *Result, pipeline map[string]interface{}, name string) error {
+	if !getBool(pipeline, "is_enabled") {
+		return nil
+	}
+	query := ""
+	if filters := getObjects(pipeline, "filter"); len(filters) > 0 {
+		query = getString(filters[0], "query")
+	}
+	matches, err := matchQuery(query, result.Log)
+	if err != nil {
+		return fmt.Errorf("invalid filter of %s: %s", name, err)
+	}
+	if !matches {
+		return nil
+	}
+	result.MatchedPipelines = append(result.MatchedPipelines, name)
+
+	for i, processorBlock := range getObjects(pipeline, "processor") {
+		processorType, processor := processorOf(processorBlock)
+		if processor == nil || !getBool(processor, "is_enabled") {
+			continue
+		}
+		processorName := getString(processor, "name")
+		if processorName == "" {
+			processorName = fmt.Sprintf("%s %d", processorType, i)
+		}
+		processorName = name + " > " + processorName
+
+		if processorType == "pipeline" {
+			if err := simulatePipeline(result, processor, processorName); err != nil {
+				return err
+			}
+			continue
+		}
+		process, ok := processors[processorType]
+		if !ok {
+			result.SkippedProcessors = append(result.SkippedProcessors, processorName)
+			continue
+		}
+		if err := process(result.Log, processor); err != nil {
+			return fmt.Errorf("error in processor %s: %s", processorName, err)
+		}
+	}
+	return nil
+}
+
+// processorOf returns the type and the content of a processor block, which has a single non-empty processor
+func processorOf(processorBlock map[string]interface{}) (string, map[string]interface{}) {
+	for processorType := range processorBlock {
+		if objects := getObjects(processorBlock, processorType); len(objects) > 0 {
+			return processorType, objects[0]
+		}
+	}
+	return "", nil
+}
+
+func matchQuery(query string, log map[string]interface{}) (bool, error) {
+	node, err := logsquery.Parse(query)
+	if err != nil {
+		return false, err
+	}
+	return node.Match(log), nil
+}
+
+/*
+ * Processors
+ */
+
+func arithmeticProcessor(log map[string]interface{}, processor map[string]interface{}) error {
+	e := &expression{input: getString(processor, "expression"), log: log, replaceMissing: getBool(processor, "is_replace_missing")}
+	value, err := e.evaluate()
+	if err != nil {
+		return err
+	}
+	if e.missing || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	setAttribute(log, getString(processor, "target"), value)
+	return nil
+}
+
+func attributeRemapper(log map[string]interface{}, processor map[string]interface{}) error {
+	target := getString(processor, "target")
+	targetIsTag := getString(processor, "target_type") == "tag"
+	if targetIsTag {
+		if _, ok := tagValue(log, target); ok && !getBool(processor, "override_on_conflict") {
+			return nil
+		}
+	} else if _, ok := getAttribute(log, target); ok && !getBool(processor, "override_on_conflict") {
+		return nil
+	}
+
+	for _, source := range getStrings(processor, "sources") {
+		var value interface{}
+		var ok bool
+		if getString(processor, "source_type") == "tag" {
+			value, ok = tagValue(log, source)
+			if ok && !getBool(processor, "preserve_source") {
+				removeTag(log, source)
+			}
+		} else {
+			value, ok = getAttribute(log, source)
+			if ok && !getBool(processor, "preserve_source") {
+				deleteAttribute(log, source)
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		value = convertValue(value, getString(processor, "target_format"))
+		if targetIsTag {
+			removeTag(log, target)
+			addTag(log, target+":"+fmt.Sprint(value))
+		} else {
+			setAttribute(log, target, value)
+		}
+		return nil
+	}
+	return nil
+}
+
+func categoryProcessor(log map[string]interface{}, processor map[string]interface{}) error {
+	for _, category := range getObjects(processor, "category") {
+		query := ""
+		if filters := getObjects(category, "filter"); len(filters) > 0 {
+			query = getString(filters[0], "query")
+		}
+		matches, err := matchQuery(query, log)
+		if err != nil {
+			return fmt.Errorf("invalid filter of category %s: %s", getString(category, "name"), err)
+		}
+		if matches {
+			setAttribute(log, getString(processor, "target"), getString(category, "name"))
+			return nil
+		}
+	}
+	return nil
+}
+
+// dateFormats are the date formats recognized by the date remapper, besides UNIX timestamps
+var dateFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05,999",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	"02/Jan/2006:15:04:05 -0700",
+	"Jan _2 15:04:05",
+	"Jan _2 2006 15:04:05",
+}
+
+func dateRemapper(log map[string]interface{}, processor map[string]interface{}) error {
+	for _, source := range getStrings(processor, "sources") {
+		value, ok := getAttribute(log, source)
+		if !ok {
+			continue
+		}
+		if date, ok := parseDate(value); ok {
+			log["timestamp"] = date.UTC().Format(time.RFC3339Nano)
+			return nil
+		}
+	}
+	return nil
+}
+
+func parseDate(value interface{}) (time.Time, bool) {
+	s := fmt.Sprint(value)
+	if number, err := strconv.ParseFloat(s, 64); err == nil {
+		// Small UNIX timestamps are in seconds, larger ones in milliseconds
+		if math.Abs(number) < 1e11 {
+			number *= 1000
+		}
+		return time.UnixMilli(int64(number)), true
+	}
+	for _, format := range dateFormats {
+		if date, err := time.Parse(format, s); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func grokParser(log map[string]interface{}, processor map[string]interface{}) error {
+	rules := getObjects(processor, "grok")
+	if len(rules) == 0 {
+		return nil
+	}
+	parser, err := grok.NewParser(getString(rules[0], "match_rules"), getString(rules[0], "support_rules"))
+	if err != nil {
+		return err
+	}
+	value, ok := getAttribute(log, getString(processor, "source"))
+	if !ok {
+		return nil
+	}
+	if _, attributes, ok := parser.Parse(fmt.Sprint(value)); ok {
+		mergeAttributes(log, attributes)
+	}
+	return nil
+}
+
+func lookupProcessor(log map[string]interface{}, processor map[string]interface{}) error {
+	value, ok := getAttribute(log, getString(processor, "source"))
+	if !ok {
+		return nil
+	}
+	for _, entry := range getStrings(processor, "lookup_table") {
+		key, lookup, _ := strings.Cut(entry, ",")
+		if strings.TrimSpace(key) == fmt.Sprint(value) {
+			setAttribute(log, getString(processor, "target"), strings.TrimSpace(lookup))
+			return nil
+		}
+	}
+	if defaultLookup := getString(processor, "default_lookup"); defaultLookup != "" {
+		setAttribute(log, getString(processor, "target"), defaultLookup)
+	}
+	return nil
+}
+
+// reservedAttributeRemapper returns a remapper setting a reserved attribute from the first source found
+func reservedAttributeRemapper(attribute string) processorFunc {
+	return func(log map[string]interface{}, processor map[string]interface{}) error {
+		for _, source := range getStrings(processor, "sources") {
+			if value, ok := getAttribute(log, source); ok {
+				setAttribute(log, attribute, fmt.Sprint(value))
+				return nil
+			}
+		}
+		return nil
+	}
+}
+
+// statusPrefixes map the prefixes of status values to statuses, in the order the status remapper checks them
+var statusPrefixes = []struct {
+	prefix string
+	status string
+}{
+	{"emerg", "emergency"},
+	{"f", "emergency"},
+	{"a", "alert"},
+	{"c", "critical"},
+	{"e", "error"},
+	{"w", "warning"},
+	{"n", "notice"},
+	{"i", "info"},
+	{"d", "debug"},
+	{"trace", "debug"},
+	{"verbose", "debug"},
+	{"o", "ok"},
+	{"s", "ok"},
+}
+
+// syslogStatuses are the statuses of the syslog severity numbers
+var syslogStatuses = []string{"emergency", "alert", "critical", "error", "warning", "notice", "info", "debug"}
+
+func statusRemapper(log map[string]interface{}, processor map[string]interface{}) error {
+	for _, source := range getStrings(processor, "sources") {
+		value, ok := getAttribute(log, source)
+		if !ok {
+			continue
+		}
+		log["status"] = normalizeStatus(fmt.Sprint(value))
+		return nil
+	}
+	return nil
+}
+
+func normalizeStatus(value string) string {
+	if severity, err := strconv.Atoi(value); err == nil {
+		if severity >= 0 && severity < len(syslogStatuses) {
+			return syslogStatuses[severity]
+		}
+		return "info"
+	}
+	value = strings.ToLower(value)
+	for _, p := range statusPrefixes {
+		if strings.HasPrefix(value, p.prefix) {
+			return p.status
+		}
+	}
+	return "info"
+}
+
+func stringBuilderProcessor(log map[string]interface{}, processor map[string]interface{}) error {
+	template := getString(processor, "template")
+	var b strings.Builder
+	for {
+		start := strings.Index(template, "%{")
+		if start < 0 {
+			b.WriteString(template)
+			break
+		}
+		end := strings.Index(template[start:], "}")
+		if end < 0 {
+			b.WriteString(template)
+			break
+		}
+		b.WriteString(template[:start])
+		attribute := template[start+2 : start+end]
+		value, ok := getAttribute(log, attribute)
+		if !ok {
+			if !getBool(processor, "is_replace_missing") {
+				return nil
+			}
+		} else if _, isObject := value.(map[string]interface{}); isObject {
+			encoded, _ := json.Marshal(value)
+			b.Write(encoded)
+		} else {
+			b.WriteString(fmt.Sprint(value))
+		}
+		template = template[start+end+1:]
+	}
+	setAttribute(log, getString(processor, "target"), b.String())
+	return nil
+}
+
+func urlParser(log map[string]interface{}, processor map[string]interface{}) error {
+	for _, source := range getStrings(processor, "sources") {
+		value, ok := getAttribute(log, source)
+		if !ok {
+			continue
+		}
+		parsed, err := url.Parse(fmt.Sprint(value))
+		if err != nil {
+			continue
+		}
+		path := parsed.Path
+		if getBool(processor, "normalize_ending_slashes") && len(path) > 1 {
+			path = strings.TrimRight(path, "/")
+		}
+		details := map[string]interface{}{
+			"scheme": parsed.Scheme,
+			"host":   parsed.Hostname(),
+			"path":   path,
+		}
+		if port, err := strconv.Atoi(parsed.Port()); err == nil {
+			details["port"] = float64(port)
+		}
+		if query := parsed.Query(); len(query) > 0 {
+			queryString := make(map[string]interface{})
+			for key, values := range query {
+				queryString[key] = values[0]
+			}
+			details["queryString"] = queryString
+		}
+		setAttribute(log, getString(processor, "target"), details)
+		return nil
+	}
+	return nil
+}
+
+/*
+ * Attributes
+ */
+
+// getAttribute returns the value of an attribute, either a dotted path or a key containing dots
+func getAttribute(log map[string]interface{}, attribute string) (interface{}, bool) {
+	if value, ok := log[attribute]; ok {
+		return value, value != nil
+	}
+	key, rest, nested := strings.Cut(attribute, ".")
+	if !nested {
+		return nil, false
+	}
+	if object, ok := log[key].(map[string]interface{}); ok {
+		return getAttribute(object, rest)
+	}
+	return nil, false
+}
+
+func setAttribute(log map[string]interface{}, attribute string, value interface{}) {
+	if attribute == "" {
+		return
+	}
+	keys := strings.Split(attribute, ".")
+	current := log
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+func deleteAttribute(log map[string]interface{}, attribute string) {
+	if _, ok := log[attribute]; ok {
+		delete(log, attribute)
+		return
+	}
+	key, rest, nested := strings.Cut(attribute, ".")
+	if object, ok := log[key].(map[string]interface{}); ok && nested {
+		deleteAttribute(object, rest)
+	}
+}
+
+// mergeAttributes deeply merges attributes into a log, the way parsers add their extracted attributes
+func mergeAttributes(log map[string]interface{}, attributes map[string]interface{}) {
+	for key, value := range attributes {
+		object, isObject := value.(map[string]interface{})
+		existing, existingIsObject := log[key].(map[string]interface{})
+		if isObject && existingIsObject {
+			mergeAttributes(existing, object)
+		} else {
+			log[key] = value
+		}
+	}
+}
+
+func convertValue(value interface{}, format string) interface{} {
+	switch format {
+	case "string":
+		return fmt.Sprint(value)
+	case "integer":
+		if number, err := strconv.ParseFloat(fmt.Sprint(value), 64); err == nil {
+			return math.Trunc(number)
+		}
+	case "double":
+		if number, err := strconv.ParseFloat(fmt.Sprint(value), 64); err == nil {
+			return number
+		}
+	}
+	return value
+}
+
+/*
+ * Tags
+ */
+
+func tagValue(log map[string]interface{}, key string) (string, bool) {
+	for _, tag := range logsquery.Tags(log) {
+		if k, value, _ := strings.Cut(tag, ":"); k == key {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func removeTag(log map[string]interface{}, key string) {
+	var tags []string
+	for _, tag := range logsquery.Tags(log) {
+		if k, _, _ := strings.Cut(tag, ":"); k != key {
+			tags = append(tags, tag)
+		}
+	}
+	setTags(log, tags)
+}
+
+func addTag(log map[string]interface{}, tag string) {
+	setTags(log, append(logsquery.Tags(log), tag))
+}
+
+// setTags sets the tags of a log as `ddtags`, the way they are sent to the intake
+func setTags(log map[string]interface{}, tags []string) {
+	delete(log, "tags")
+	log["ddtags"] = strings.Join(tags, ",")
+}
+
+/*
+ * Getters of JSON objects
+ */
+
+func getString(object map[string]interface{}, key string) string {
+	s, _ := object[key].(string)
+	return s
+}
+
+func getBool(object map[string]interface{}, key string) bool {
+	b, _ := object[key].(bool)
+	return b
+}
+
+func getStrings(object map[string]interface{}, key string) []string {
+	var values []string
+	array, _ := object[key].([]interface{})
+	for _, value := range array {
+		if s, ok := value.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+func getObjects(object map[string]interface{}, key string) []map[string]interface{} {
+	var objects []map[string]interface{}
+	array, _ := object[key].([]interface{})
+	for _, value := range array {
+		if o, ok := value.(map[string]interface{}); ok {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			copied[key] = deepCopy(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, child := range v {
+			copied[i] = deepCopy(child)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,187 @@
+package logspipeline
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	cases := map[string]struct {
+		log       string
+		pipelines string
+		expected  string
+		matched   []string
+		skipped   []string
+	}{
+		"filter not matching": {
+			log:       `{"message": "hello", "ddsource": "java"}`,
+			pipelines: `[{"name": "nginx", "is_enabled": true, "filter": [{"query": "source:nginx"}], "processor": [{"status_remapper": [{"is_enabled": true, "sources": ["level"]}]}]}]`,
+			expected:  `{"message": "hello", "ddsource": "java"}`,
+		},
+		"disabled pipeline": {
+			log:       `{"message": "hello", "level": "warn"}`,
+			pipelines: `[{"name": "all", "is_enabled": false, "filter": [{"query": "*"}], "processor": [{"status_remapper": [{"is_enabled": true, "sources": ["level"]}]}]}]`,
+			expected:  `{"message": "hello", "level": "warn"}`,
+		},
+		"disabled processor": {
+			log:       `{"message": "hello", "level": "warn"}`,
+			pipelines: `[{"name": "all", "is_enabled": true, "filter": [{"query": "*"}], "processor": [{"status_remapper": [{"is_enabled": false, "sources": ["level"]}]}]}]`,
+			expected:  `{"message": "hello", "level": "warn"}`,
+			matched:   []string{"all"},
+		},
+		"remappers": {
+			log: `{"msg": "hello", "level": "WARN", "app": "web", "time": 1700000000, "trace": "123"}`,
+			pipelines: `[{"name": "all", "is_enabled": true, "filter": [{"query": ""}], "processor": [
+				{"message_remapper": [{"is_enabled": true, "sources": ["msg"]}]},
+				{"status_remapper": [{"is_enabled": true, "sources": ["level"]}]},
+				{"service_remapper": [{"is_enabled": true, "sources": ["missing", "app"]}]},
+				{"date_remapper": [{"is_enabled": true, "sources": ["time"]}]},
+				{"trace_id_remapper": [{"is_enabled": true, "sources": ["trace"]}]}
+			]}]`,
+			expected: `{"msg": "hello", "message": "hello", "level": "WARN", "status": "warning", "app": "web", "service": "web",
+				"time": 1700000000, "timestamp": "2023-11-14T22:13:20Z", "trace": "123", "dd": {"trace_id": "123"}}`,
+			matched: []string{"all"},
+		},
+		"attribute remapper": {
+			log: `{"message": "hello", "duration": "12.5", "user": {"name": "john"}, "ddtags": "env:prod,team:core"}`,
+			pipelines: `[{"name": "all", "is_enabled": true, "filter": [{"query": "*"}], "processor": [
+				{"attribute_remapper": [{"is_enabled": true, "sources": ["duration"], "source_type": "attribute", "target": "http.duration", "target_type": "attribute", "target_format": "double"}]},
+				{"attribute_remapper": [{"is_enabled": true, "sources": ["user.name"], "source_type": "attribute", "target": "usr.name", "target_type": "attribute", "preserve_source": true}]},
+				{"attribute_remapper": [{"is_enabled": true, "sources": ["team"], "source_type": "tag", "target": "owner", "target_type": "tag"}]}
+			]}]`,
+			expected: `{"message": "hello", "http": {"duration": 12.5}, "user": {"name": "john"}, "usr": {"name": "john"}, "ddtags": "env:prod,owner:core"}`,
+			matched:  []string{"all"},
+		},
+		"arithmetic and string builder": {
+			log: `{"message": "hello", "bytes": 2048, "user": "john"}`,
+			pipelines: `[{"name": "all", "is_enabled": true, "filter": [{"query": "*"}], "processor": [
+				{"arithmetic_processor": [{"is_enabled": true, "expression": "(bytes + 1024) / 1024", "target": "kilobytes"}]},
+				{"arithmetic_processor": [{"is_enabled": true, "expression": "missing * 2", "target": "skipped"}]},
+				{"arithmetic_processor": [{"is_enabled": true, "expression": "missing * 2", "target": "replaced", "is_replace_missing": true}]},
+				{"string_builder_processor": [{"is_enabled": true, "template": "%{user} sent %{kilobytes}KB", "target": "summary"}]},
+				{"string_builder_processor": [{"is_enabled": true, "template": "%{missing} sent", "target": "skipped"}]}
+			]}]`,
+			expected: `{"message": "hello", "bytes": 2048, "user": "john", "kilobytes": 3, "replaced": 0, "summary": "john sent 3KB"}`,
+			matched:  []string{"all"},
+		},
+		"category and lookup": {
+			log: `{"message": "hello", "http": {"status_code": 503}, "country": "FR"}`,
+			pipelines: `[{"name": "all", "is_enabled": true, "filter": [{"query": "*"}], "processor": [
+				{"category_processor": [{"is_enabled": true, "target": "http.status_category", "category": [
+					{"name": "OK", "filter": [{"query": "@http.status_code:[200 TO 299]"}]},
+					{"name": "Error", "filter": [{"query": "@http.status_code:[500 TO 599]"}]}
+				]}]},
+				{"lookup_processor": [{"is_enabled": true, "source": "country", "target": "country_name", "lookup_table": ["FR,France", "DE,Germany"]}]},
+				{"lookup_processor": [{"is_enabled": true, "source": "message", "target": "unknown", "lookup_table": ["a,b"], "default_lookup": "none"}]}
+			]}]`,
+			expected: `{"message": "hello", "http": {"status_code": 503, "status_category": "Error"}, "country": "FR", "country_name": "France", "unknown": "none"}`,
+			matched:  []string{"all"},
+		},
+		"parsers": {
+			log: `{"message": "john GET https://example.com:8443/api/?page=2", "agent": "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"}`,
+			pipelines: `[{"name": "all", "is_enabled": true, "filter": [{"query": "*"}], "processor": [
+				{"grok_parser": [{"is_enabled": true, "source": "message", "grok": [{"match_rules": "rule %{word:usr.name} %{word:http.method} %{notSpace:http.url}", "support_rules": ""}]}]},
+				{"url_parser": [{"is_enabled": true, "sources": ["http.url"], "target": "http.url_details", "normalize_ending_slashes": true}]},
+				{"user_agent_parser": [{"is_enabled": true, "sources": ["agent"], "target": "http.useragent_details"}]}
+			]}]`,
+			expected: `{"message": "john GET https://example.com:8443/api/?page=2", "agent": "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+				"usr": {"name": "john"}, "http": {"method": "GET", "url": "https://example.com:8443/api/?page=2",
+				"url_details": {"scheme": "https", "host": "example.com", "port": 8443, "path": "/api", "queryString": {"page": "2"}},
+				"useragent_details": {"browser": {"family": "Chrome", "major": "120", "minor": "0", "patch": "0"}, "os": {"family": "Mac OS X", "major": "10", "minor": "15", "patch": "7"}, "device": {"family": "Other", "category": "Desktop"}}}}`,
+			matched: []string{"all"},
+		},
+		"nested pipelines and skipped processors": {
+			log: `{"message": "hello", "ddsource": "nginx", "network": {"client": {"ip": "1.2.3.4"}}, "level": "3"}`,
+			pipelines: `[{"name": "nginx", "is_enabled": true, "filter": [{"query": "source:nginx"}], "processor": [
+				{"geo_ip_parser": [{"name": "geo", "is_enabled": true, "sources": ["network.client.ip"], "target": "network.client.geoip"}], "status_remapper": []},
+				{"pipeline": [{"name": "errors", "is_enabled": true, "filter": [{"query": "@level:3"}], "processor": [
+					{"status_remapper": [{"is_enabled": true, "sources": ["level"]}]}
+				]}]},
+				{"pipeline": [{"name": "other", "is_enabled": true, "filter": [{"query": "@level:4"}], "processor": []}]}
+			]}]`,
+			expected: `{"message": "hello", "ddsource": "nginx", "network": {"client": {"ip": "1.2.3.4"}}, "level": "3", "status": "error"}`,
+			matched:  []string{"nginx", "nginx > errors"},
+			skipped:  []string{"nginx > geo"},
+		},
+		"pipelines in order": {
+			log: `{"message": "hello", "level": "info"}`,
+			pipelines: `[
+				{"name": "first", "is_enabled": true, "filter": [{"query": "*"}], "processor": [{"status_remapper": [{"is_enabled": true, "sources": ["level"]}]}]},
+				{"name": "second", "is_enabled": true, "filter": [{"query": "status:info"}], "processor": [{"string_builder_processor": [{"is_enabled": true, "template": "seen", "target": "second"}]}]}
+			]`,
+			expected: `{"message": "hello", "level": "info", "status": "info", "second": "seen"}`,
+			matched:  []string{"first", "second"},
+		},
+	}
+	for name, tc := range cases {
+		var log, expected map[string]interface{}
+		var pipelines []map[string]interface{}
+		for _, v := range []struct {
+			s string
+			p interface{}
+		}{{tc.log, &log}, {tc.pipelines, &pipelines}, {tc.expected, &expected}} {
+			if err := json.Unmarshal([]byte(v.s), v.p); err != nil {
+				t.Fatalf("%s: invalid test case: %s", name, err)
+			}
+		}
+		result, err := Simulate(log, pipelines)
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result.Log, expected) {
+			actual, _ := json.Marshal(result.Log)
+			t.Errorf("%s: expected %s, got %s", name, tc.expected, actual)
+		}
+		if !reflect.DeepEqual(result.MatchedPipelines, tc.matched) {
+			t.Errorf("%s: expected matched pipelines %v, got %v", name, tc.matched, result.MatchedPipelines)
+		}
+		if !reflect.DeepEqual(result.SkippedProcessors, tc.skipped) {
+			t.Errorf("%s: expected skipped processors %v, got %v", name, tc.skipped, result.SkippedProcessors)
+		}
+	}
+}
+
+func TestSimulateErrors(t *testing.T) {
+	cases := map[string]string{
+		"invalid filter":     `[{"name": "p", "is_enabled": true, "filter": [{"query": "(source:nginx"}]}]`,
+		"invalid expression": `[{"name": "p", "is_enabled": true, "processor": [{"arithmetic_processor": [{"is_enabled": true, "expression": "a +", "target": "b"}]}]}]`,
+		"invalid grok":       `[{"name": "p", "is_enabled": true, "processor": [{"grok_parser": [{"is_enabled": true, "source": "message", "grok": [{"match_rules": "rule %{unknown}"}]}]}]}]`,
+	}
+	for name, p := range cases {
+		var pipelines []map[string]interface{}
+		if err := json.Unmarshal([]byte(p), &pipelines); err != nil {
+			t.Fatalf("%s: invalid test case: %s", name, err)
+		}
+		if _, err := Simulate(map[string]interface{}{"message": "hello"}, pipelines); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNormalizeStatus(t *testing.T) {
+	cases := map[string]string{
+		"0":         "emergency",
+		"3":         "error",
+		"7":         "debug",
+		"12":        "info",
+		"FATAL":     "emergency",
+		"emerg":     "emergency",
+		"Error":     "error",
+		"warn":      "warning",
+		"notice":    "notice",
+		"INFO":      "info",
+		"trace":     "debug",
+		"verbose":   "debug",
+		"OK":        "ok",
+		"Success":   "ok",
+		"something": "ok",
+		"unknown":   "info",
+	}
+	for value, expected := range cases {
+		if status := normalizeStatus(value); status != expected {
+			t.Errorf("expected %q to be normalized to %q, got %q", value, expected, status)
+		}
+	}
+}
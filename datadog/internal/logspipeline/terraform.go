@@ -0,0 +1,299 @@
+package logspipeline
+
+import (
+	"fmt"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+)
+
+// ReferenceTableLookupProcessorType differentiates the reference table lookup processor from the lookup processor, as
+// they share a `type` in the API.
+const ReferenceTableLookupProcessorType = "reference-table-" + string(datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR)
+
+// terraformProcessorTypes maps the types of processors to their block in the `processor` block
+var terraformProcessorTypes = map[string]string{
+	string(datadogV1.LOGSARITHMETICPROCESSORTYPE_ARITHMETIC_PROCESSOR):        "arithmetic_processor",
+	string(datadogV1.LOGSATTRIBUTEREMAPPERTYPE_ATTRIBUTE_REMAPPER):            "attribute_remapper",
+	string(datadogV1.LOGSCATEGORYPROCESSORTYPE_CATEGORY_PROCESSOR):            "category_processor",
+	string(datadogV1.LOGSDATEREMAPPERTYPE_DATE_REMAPPER):                      "date_remapper",
+	string(datadogV1.LOGSGEOIPPARSERTYPE_GEO_IP_PARSER):                       "geo_ip_parser",
+	string(datadogV1.LOGSGROKPARSERTYPE_GROK_PARSER):                          "grok_parser",
+	string(datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR):                "lookup_processor",
+	ReferenceTableLookupProcessorType:                                         "reference_table_lookup_processor",
+	string(datadogV1.LOGSMESSAGEREMAPPERTYPE_MESSAGE_REMAPPER):                "message_remapper",
+	string(datadogV1.LOGSPIPELINEPROCESSORTYPE_PIPELINE):                      "pipeline",
+	string(datadogV1.LOGSSERVICEREMAPPERTYPE_SERVICE_REMAPPER):                "service_remapper",
+	string(datadogV1.LOGSSTATUSREMAPPERTYPE_STATUS_REMAPPER):                  "status_remapper",
+	string(datadogV1.LOGSSTRINGBUILDERPROCESSORTYPE_STRING_BUILDER_PROCESSOR): "string_builder_processor",
+	string(datadogV1.LOGSTRACEREMAPPERTYPE_TRACE_ID_REMAPPER):                 "trace_id_remapper",
+	string(datadogV1.LOGSURLPARSERTYPE_URL_PARSER):                            "url_parser",
+	string(datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER):               "user_agent_parser",
+}
+
+// BuildTerraformProcessors converts the processors of a pipeline to their representation in the state of the
+// `datadog_logs_custom_pipeline` resource.
+func BuildTerraformProcessors(ddProcessors []datadogV1.LogsProcessor) ([]map[string]interface{}, error) {
+	tfProcessors := make([]map[string]interface{}, len(ddProcessors))
+	for i, ddProcessor := range ddProcessors {
+		tfProcessor, err := buildTerraformProcessor(ddProcessor)
+		if err != nil {
+			return nil, err
+		}
+		tfProcessors[i] = tfProcessor
+	}
+
+	return tfProcessors, nil
+}
+
+func buildTerraformProcessor(ddProcessor datadogV1.LogsProcessor) (map[string]interface{}, error) {
+	tfProcessor := make(map[string]interface{})
+	var processorType string
+	var err error
+	if ddProcessor.LogsArithmeticProcessor != nil {
+		tfProcessor = buildTerraformArithmeticProcessor(ddProcessor.LogsArithmeticProcessor)
+		processorType = string(datadogV1.LOGSARITHMETICPROCESSORTYPE_ARITHMETIC_PROCESSOR)
+	} else if ddProcessor.LogsAttributeRemapper != nil {
+		tfProcessor = buildTerraformAttributeRemapper(ddProcessor.LogsAttributeRemapper)
+		processorType = string(datadogV1.LOGSATTRIBUTEREMAPPERTYPE_ATTRIBUTE_REMAPPER)
+	} else if ddProcessor.LogsCategoryProcessor != nil {
+		tfProcessor = buildTerraformCategoryProcessor(ddProcessor.LogsCategoryProcessor)
+		processorType = string(datadogV1.LOGSCATEGORYPROCESSORTYPE_CATEGORY_PROCESSOR)
+	} else if ddProcessor.LogsDateRemapper != nil {
+		tfProcessor = buildTerraformDateRemapper(ddProcessor.LogsDateRemapper)
+		processorType = string(datadogV1.LOGSDATEREMAPPERTYPE_DATE_REMAPPER)
+	} else if ddProcessor.LogsMessageRemapper != nil {
+		tfProcessor = buildTerraformMessageRemapper(ddProcessor.LogsMessageRemapper)
+		processorType = string(datadogV1.LOGSMESSAGEREMAPPERTYPE_MESSAGE_REMAPPER)
+	} else if ddProcessor.LogsServiceRemapper != nil {
+		tfProcessor = buildTerraformServiceRemapper(ddProcessor.LogsServiceRemapper)
+		processorType = string(datadogV1.LOGSSERVICEREMAPPERTYPE_SERVICE_REMAPPER)
+	} else if ddProcessor.LogsStatusRemapper != nil {
+		tfProcessor = buildTerraformStatusRemapper(ddProcessor.LogsStatusRemapper)
+		processorType = string(datadogV1.LOGSSTATUSREMAPPERTYPE_STATUS_REMAPPER)
+	} else if ddProcessor.LogsTraceRemapper != nil {
+		tfProcessor = buildTerraformTraceRemapper(ddProcessor.LogsTraceRemapper)
+		processorType = string(datadogV1.LOGSTRACEREMAPPERTYPE_TRACE_ID_REMAPPER)
+	} else if ddProcessor.LogsGeoIPParser != nil {
+		tfProcessor = buildTerraformGeoIPParser(ddProcessor.LogsGeoIPParser)
+		processorType = string(datadogV1.LOGSGEOIPPARSERTYPE_GEO_IP_PARSER)
+	} else if ddProcessor.LogsGrokParser != nil {
+		tfProcessor = buildTerraformGrokParser(ddProcessor.LogsGrokParser)
+		processorType = string(datadogV1.LOGSGROKPARSERTYPE_GROK_PARSER)
+	} else if ddProcessor.LogsLookupProcessor != nil {
+		tfProcessor = buildTerraformLookupProcessor(ddProcessor.LogsLookupProcessor)
+		processorType = string(datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR)
+	} else if ddProcessor.ReferenceTableLogsLookupProcessor != nil {
+		tfProcessor = buildTerraformReferenceTableLookupProcessor(ddProcessor.ReferenceTableLogsLookupProcessor)
+		processorType = ReferenceTableLookupProcessorType
+	} else if ddProcessor.LogsPipelineProcessor != nil {
+		tfProcessor, err = buildTerraformNestedPipeline(ddProcessor.LogsPipelineProcessor)
+		processorType = string(datadogV1.LOGSPIPELINEPROCESSORTYPE_PIPELINE)
+	} else if ddProcessor.LogsStringBuilderProcessor != nil {
+		tfProcessor = buildTerraformStringBuilderProcessor(ddProcessor.LogsStringBuilderProcessor)
+		processorType = string(datadogV1.LOGSSTRINGBUILDERPROCESSORTYPE_STRING_BUILDER_PROCESSOR)
+	} else if ddProcessor.LogsURLParser != nil {
+		tfProcessor = buildTerraformURLParser(ddProcessor.LogsURLParser)
+		processorType = string(datadogV1.LOGSURLPARSERTYPE_URL_PARSER)
+	} else if ddProcessor.LogsUserAgentParser != nil {
+		tfProcessor = buildTerraformUserAgentParser(ddProcessor.LogsUserAgentParser)
+		processorType = string(datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER)
+	} else {
+		err = fmt.Errorf("failed to support datadogV1 processor type, %s", ddProcessor.GetActualInstance())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		terraformProcessorTypes[processorType]: []map[string]interface{}{tfProcessor},
+	}, nil
+}
+
+func buildTerraformUserAgentParser(ddUserAgent *datadogV1.LogsUserAgentParser) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":    ddUserAgent.Sources,
+		"target":     ddUserAgent.GetTarget(),
+		"is_encoded": ddUserAgent.GetIsEncoded(),
+		"name":       ddUserAgent.GetName(),
+		"is_enabled": ddUserAgent.GetIsEnabled(),
+	}
+}
+
+func buildTerraformURLParser(ddURL *datadogV1.LogsURLParser) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":                  ddURL.Sources,
+		"target":                   ddURL.GetTarget(),
+		"normalize_ending_slashes": ddURL.GetNormalizeEndingSlashes(),
+		"name":                     ddURL.GetName(),
+		"is_enabled":               ddURL.GetIsEnabled(),
+	}
+}
+
+func buildTerraformLookupProcessor(ddLookup *datadogV1.LogsLookupProcessor) map[string]interface{} {
+	tfProcessor := map[string]interface{}{
+		"source":       ddLookup.GetSource(),
+		"target":       ddLookup.GetTarget(),
+		"lookup_table": ddLookup.GetLookupTable(),
+		"name":         ddLookup.GetName(),
+		"is_enabled":   ddLookup.GetIsEnabled(),
+	}
+
+	if ddLookup.HasDefaultLookup() {
+		tfProcessor["default_lookup"] = ddLookup.GetDefaultLookup()
+	}
+
+	return tfProcessor
+}
+
+func buildTerraformReferenceTableLookupProcessor(ddLookup *datadogV1.ReferenceTableLogsLookupProcessor) map[string]interface{} {
+	return map[string]interface{}{
+		"source":                  ddLookup.GetSource(),
+		"target":                  ddLookup.GetTarget(),
+		"lookup_enrichment_table": ddLookup.GetLookupEnrichmentTable(),
+		"name":                    ddLookup.GetName(),
+		"is_enabled":              ddLookup.GetIsEnabled(),
+	}
+}
+
+func buildTerraformNestedPipeline(ddNested *datadogV1.LogsPipelineProcessor) (map[string]interface{}, error) {
+	tfProcessors, err := BuildTerraformProcessors(ddNested.GetProcessors())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"filter":     BuildTerraformFilter(ddNested.Filter),
+		"processor":  tfProcessors,
+		"name":       ddNested.GetName(),
+		"is_enabled": ddNested.GetIsEnabled(),
+	}, nil
+}
+
+func buildTerraformStringBuilderProcessor(ddStringBuilder *datadogV1.LogsStringBuilderProcessor) map[string]interface{} {
+	return map[string]interface{}{
+		"template":           ddStringBuilder.GetTemplate(),
+		"target":             ddStringBuilder.GetTarget(),
+		"is_replace_missing": ddStringBuilder.GetIsReplaceMissing(),
+		"name":               ddStringBuilder.GetName(),
+		"is_enabled":         ddStringBuilder.GetIsEnabled(),
+	}
+}
+
+func buildTerraformGeoIPParser(ddGeoIPParser *datadogV1.LogsGeoIPParser) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":    ddGeoIPParser.GetSources(),
+		"target":     ddGeoIPParser.GetTarget(),
+		"name":       ddGeoIPParser.GetName(),
+		"is_enabled": ddGeoIPParser.GetIsEnabled(),
+	}
+}
+
+func buildTerraformGrokParser(ddGrok *datadogV1.LogsGrokParser) map[string]interface{} {
+	return map[string]interface{}{
+		"samples":    ddGrok.GetSamples(),
+		"source":     ddGrok.GetSource(),
+		"grok":       buildTerraformGrokRule(&ddGrok.Grok),
+		"name":       ddGrok.GetName(),
+		"is_enabled": ddGrok.GetIsEnabled(),
+	}
+}
+
+func buildTerraformGrokRule(ddGrokRule *datadogV1.LogsGrokParserRules) []map[string]interface{} {
+	tfGrokRule := map[string]interface{}{
+		"support_rules": ddGrokRule.GetSupportRules(),
+		"match_rules":   ddGrokRule.GetMatchRules(),
+	}
+	return []map[string]interface{}{tfGrokRule}
+}
+
+func buildTerraformMessageRemapper(remapper *datadogV1.LogsMessageRemapper) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":    remapper.GetSources(),
+		"name":       remapper.GetName(),
+		"is_enabled": remapper.GetIsEnabled(),
+	}
+}
+
+func buildTerraformDateRemapper(remapper *datadogV1.LogsDateRemapper) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":    remapper.GetSources(),
+		"name":       remapper.GetName(),
+		"is_enabled": remapper.GetIsEnabled(),
+	}
+}
+
+func buildTerraformServiceRemapper(remapper *datadogV1.LogsServiceRemapper) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":    remapper.GetSources(),
+		"name":       remapper.GetName(),
+		"is_enabled": remapper.GetIsEnabled(),
+	}
+}
+
+func buildTerraformStatusRemapper(remapper *datadogV1.LogsStatusRemapper) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":    remapper.GetSources(),
+		"name":       remapper.GetName(),
+		"is_enabled": remapper.GetIsEnabled(),
+	}
+}
+
+func buildTerraformTraceRemapper(remapper *datadogV1.LogsTraceRemapper) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":    remapper.GetSources(),
+		"name":       remapper.GetName(),
+		"is_enabled": remapper.GetIsEnabled(),
+	}
+}
+
+func buildTerraformCategoryProcessor(ddCategory *datadogV1.LogsCategoryProcessor) map[string]interface{} {
+	return map[string]interface{}{
+		"target":     ddCategory.GetTarget(),
+		"category":   buildTerraformCategories(ddCategory.Categories),
+		"name":       ddCategory.GetName(),
+		"is_enabled": ddCategory.GetIsEnabled(),
+	}
+}
+
+func buildTerraformCategories(ddCategories []datadogV1.LogsCategoryProcessorCategory) []map[string]interface{} {
+	tfCategories := make([]map[string]interface{}, len(ddCategories))
+	for i, ddCategory := range ddCategories {
+		tfCategories[i] = map[string]interface{}{
+			"name":   ddCategory.GetName(),
+			"filter": BuildTerraformFilter(ddCategory.Filter),
+		}
+	}
+	return tfCategories
+}
+
+func buildTerraformAttributeRemapper(ddAttribute *datadogV1.LogsAttributeRemapper) map[string]interface{} {
+	return map[string]interface{}{
+		"sources":              ddAttribute.Sources,
+		"source_type":          ddAttribute.GetSourceType(),
+		"target":               ddAttribute.GetTarget(),
+		"target_type":          ddAttribute.GetTargetType(),
+		"target_format":        ddAttribute.GetTargetFormat(),
+		"preserve_source":      ddAttribute.GetPreserveSource(),
+		"override_on_conflict": ddAttribute.GetOverrideOnConflict(),
+		"name":                 ddAttribute.GetName(),
+		"is_enabled":           ddAttribute.GetIsEnabled(),
+	}
+}
+
+func buildTerraformArithmeticProcessor(ddArithmetic *datadogV1.LogsArithmeticProcessor) map[string]interface{} {
+
+	return map[string]interface{}{
+		"target":             ddArithmetic.GetTarget(),
+		"is_replace_missing": ddArithmetic.GetIsReplaceMissing(),
+		"expression":         ddArithmetic.GetExpression(),
+		"name":               ddArithmetic.GetName(),
+		"is_enabled":         ddArithmetic.GetIsEnabled(),
+	}
+}
+
+// BuildTerraformFilter converts the filter of a pipeline or a category to its representation in the state of the
+// `datadog_logs_custom_pipeline` resource.
+func BuildTerraformFilter(ddFilter *datadogV1.LogsFilter) []map[string]interface{} {
+	tfFilter := map[string]interface{}{
+		"query": ddFilter.GetQuery(),
+	}
+	return []map[string]interface{}{tfFilter}
+}
@@ -0,0 +1,105 @@
+package logspipeline
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// userAgentFamily detects a family of browsers, operating systems or devices, with the version captured in the
+// first group of its regular expression
+type userAgentFamily struct {
+	family string
+	re     *regexp.Regexp
+}
+
+// The families are checked in order, the most specific first, as most user agents mention several browsers.
+var (
+	userAgentBrowsers = []userAgentFamily{
+		{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/(\d+(?:\.\d+)*)`)},
+		{"Opera", regexp.MustCompile(`(?:OPR|Opera)/(\d+(?:\.\d+)*)`)},
+		{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/(\d+(?:\.\d+)*)`)},
+		{"Chrome Mobile", regexp.MustCompile(`Chrome/(\d+(?:\.\d+)*) Mobile`)},
+		{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/(\d+(?:\.\d+)*)`)},
+		{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/(\d+(?:\.\d+)*)`)},
+		{"Mobile Safari", regexp.MustCompile(`Version/(\d+(?:\.\d+)*).* Mobile/\S+ Safari/`)},
+		{"Safari", regexp.MustCompile(`Version/(\d+(?:\.\d+)*).* Safari/`)},
+		{"IE", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)(\d+(?:\.\d+)*)`)},
+		{"curl", regexp.MustCompile(`^curl/(\d+(?:\.\d+)*)`)},
+		{"Python Requests", regexp.MustCompile(`^python-requests/(\d+(?:\.\d+)*)`)},
+		{"Go-http-client", regexp.MustCompile(`^Go-http-client/(\d+(?:\.\d+)*)`)},
+	}
+	userAgentOSes = []userAgentFamily{
+		{"iOS", regexp.MustCompile(`(?:iPhone|iPad|iPod).* OS (\d+(?:_\d+)*)`)},
+		{"Android", regexp.MustCompile(`Android (\d+(?:\.\d+)*)`)},
+		{"Windows", regexp.MustCompile(`Windows NT (\d+(?:\.\d+)*)`)},
+		{"Mac OS X", regexp.MustCompile(`Mac OS X (\d+(?:[_.]\d+)*)`)},
+		{"Chrome OS", regexp.MustCompile(`CrOS \S+ (\d+(?:\.\d+)*)`)},
+		{"Ubuntu", regexp.MustCompile(`Ubuntu()`)},
+		{"Linux", regexp.MustCompile(`Linux()`)},
+	}
+	userAgentDevices = []struct {
+		family   string
+		category string
+		re       *regexp.Regexp
+	}{
+		{"Spider", "Bot", regexp.MustCompile(`(?i)bot|crawler|spider`)},
+		{"iPad", "Tablet", regexp.MustCompile(`iPad`)},
+		{"iPhone", "Mobile", regexp.MustCompile(`iPhone`)},
+		{"Generic Smartphone", "Mobile", regexp.MustCompile(`Mobile`)},
+		{"Generic Tablet", "Tablet", regexp.MustCompile(`Android|Tablet`)},
+		{"Other", "Desktop", regexp.MustCompile(`Windows|Macintosh|X11|CrOS`)},
+	}
+)
+
+// userAgentParser extracts the browser, operating system and device of user agents with heuristics, which cover
+// the most common user agents but not all the ones known to the user agent parser.
+func userAgentParser(log map[string]interface{}, processor map[string]interface{}) error {
+	for _, source := range getStrings(processor, "sources") {
+		value, ok := getAttribute(log, source)
+		if !ok {
+			continue
+		}
+		userAgent := fmt.Sprint(value)
+		if getBool(processor, "is_encoded") {
+			if decoded, err := url.QueryUnescape(userAgent); err == nil {
+				userAgent = decoded
+			}
+		}
+		setAttribute(log, getString(processor, "target"), map[string]interface{}{
+			"browser": detectUserAgentFamily(userAgentBrowsers, userAgent),
+			"os":      detectUserAgentFamily(userAgentOSes, userAgent),
+			"device":  detectUserAgentDevice(userAgent),
+		})
+		return nil
+	}
+	return nil
+}
+
+func detectUserAgentFamily(families []userAgentFamily, userAgent string) map[string]interface{} {
+	for _, f := range families {
+		match := f.re.FindStringSubmatch(userAgent)
+		if match == nil {
+			continue
+		}
+		result := map[string]interface{}{"family": f.family}
+		version := strings.FieldsFunc(match[1], func(r rune) bool { return r == '.' || r == '_' })
+		for i, key := range []string{"major", "minor", "patch"} {
+			if i < len(version) {
+				result[key] = version[i]
+			}
+		}
+		return result
+	}
+	return map[string]interface{}{"family": "Other"}
+}
+
+func detectUserAgentDevice(userAgent string) map[string]interface{} {
+	for _, d := range userAgentDevices {
+		if d.re.MatchString(userAgent) {
+			return map[string]interface{}{"family": d.family, "category": d.category}
+		}
+	}
+	return map[string]interface{}{"family": "Other", "category": "Other"}
+}
@@ -0,0 +1,661 @@
+// Package logsquery implements parsing of Datadog log search queries, as used by the filters of log pipelines
+// and indexes, and their evaluation against logs.
+package logsquery
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// reservedAttributes are the attributes searched without `@` prefix. Other keys without prefix are tags.
+var reservedAttributes = map[string]string{
+	"host":     "host",
+	"service":  "service",
+	"source":   "ddsource",
+	"status":   "status",
+	"trace_id": "dd.trace_id",
+}
+
+// Node is a node of a parsed query.
+type Node interface {
+	// Match returns whether a log, as a JSON object, matches the node
+	Match(log map[string]interface{}) bool
+	String() string
+}
+
+// And matches logs matching all of its nodes.
+type And struct{ Nodes []Node }
+
+// Or matches logs matching any of its nodes.
+type Or struct{ Nodes []Node }
+
+// Not matches logs not matching its node.
+type Not struct{ Node Node }
+
+// All matches every log, e.g. `*`.
+type All struct{}
+
+// Term matches a value of an attribute, a tag or the message.
+type Term struct {
+	// Key is empty for a full-text search, `@` followed by the path of an attribute, a reserved
+	// attribute such as `source`, or the key of a tag.
+	Key string
+	// Value may contain `*` and `?` wildcards. It is empty for comparisons and ranges.
+	Value string
+	// Operator is empty for a value, `>`, `>=`, `<` or `<=` for comparisons, or `range`.
+	Operator string
+	// Bound is the bound of comparisons, Low and High the bounds of ranges, `*` for unbounded ones.
+	Bound, Low, High string
+}
+
+// Parse parses a log search query. An empty query matches every log.
+func Parse(query string) (Node, error) {
+	p := &parser{input: query}
+	p.skipSpaces()
+	if p.done() {
+		return All{}, nil
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos:], p.pos)
+	}
+	return node, nil
+}
+
+/*
+ * Evaluation
+ */
+
+func (n And) Match(log map[string]interface{}) bool {
+	for _, node := range n.Nodes {
+		if !node.Match(log) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n Or) Match(log map[string]interface{}) bool {
+	for _, node := range n.Nodes {
+		if node.Match(log) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n Not) Match(log map[string]interface{}) bool {
+	return !n.Node.Match(log)
+}
+
+func (All) Match(map[string]interface{}) bool {
+	return true
+}
+
+func (t Term) Match(log map[string]interface{}) bool {
+	switch {
+	case t.Key == "":
+		message, _ := log["message"].(string)
+		return matchFullText(t.Value, message)
+	case strings.HasPrefix(t.Key, "@"):
+		return t.matchValues(lookup(log, t.Key[1:]), false)
+	}
+	if attribute, ok := reservedAttributes[t.Key]; ok {
+		values := lookup(log, attribute)
+		if t.Key == "source" && len(values) == 0 {
+			values = lookup(log, "source")
+		}
+		return t.matchValues(values, true)
+	}
+
+	for _, tag := range Tags(log) {
+		key, value, _ := strings.Cut(tag, ":")
+		if key == t.Key && t.matchValues([]interface{}{value}, false) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Term) matchValues(values []interface{}, caseInsensitive bool) bool {
+	for _, value := range values {
+		if t.matchValue(value, caseInsensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Term) matchValue(value interface{}, caseInsensitive bool) bool {
+	switch t.Operator {
+	case "":
+		if number, ok := value.(float64); ok {
+			if expected, err := strconv.ParseFloat(t.Value, 64); err == nil {
+				return number == expected
+			}
+		}
+		s := fmt.Sprint(value)
+		if caseInsensitive {
+			return matchWildcard(strings.ToLower(t.Value), strings.ToLower(s))
+		}
+		return matchWildcard(t.Value, s)
+	case "range":
+		return compare(value, ">=", t.Low) && compare(value, "<=", t.High)
+	}
+	return compare(value, t.Operator, t.Bound)
+}
+
+// compare compares a value with a bound, numerically when both are numbers
+func compare(value interface{}, operator string, bound string) bool {
+	if bound == "*" {
+		return true
+	}
+	var result int
+	number, isNumber := value.(float64)
+	if s, ok := value.(string); ok {
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			number, isNumber = n, true
+		}
+	}
+	if b, err := strconv.ParseFloat(bound, 64); err == nil && isNumber {
+		switch {
+		case number < b:
+			result = -1
+		case number > b:
+			result = 1
+		}
+	} else {
+		result = strings.Compare(fmt.Sprint(value), bound)
+	}
+
+	switch operator {
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	}
+	return false
+}
+
+func matchFullText(value, message string) bool {
+	value, message = strings.ToLower(value), strings.ToLower(message)
+	if !strings.ContainsAny(value, "*?") {
+		return strings.Contains(message, value)
+	}
+	re, err := regexp.Compile(wildcardToRegex(value, `\S`))
+	return err == nil && re.MatchString(message)
+}
+
+func matchWildcard(pattern, s string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == s
+	}
+	re, err := regexp.Compile("^" + wildcardToRegex(pattern, ".") + "$")
+	return err == nil && re.MatchString(s)
+}
+
+func wildcardToRegex(pattern string, any string) string {
+	var b strings.Builder
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(any + "*")
+		case '?':
+			b.WriteString(any)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// lookup returns the values of a dotted attribute path, flattening arrays
+func lookup(log map[string]interface{}, path string) []interface{} {
+	if value, ok := log[path]; ok {
+		return flatten(value)
+	}
+	current := []interface{}{log}
+	for _, key := range strings.Split(path, ".") {
+		var next []interface{}
+		for _, c := range current {
+			for _, v := range flatten(c) {
+				if object, ok := v.(map[string]interface{}); ok {
+					if child, ok := object[key]; ok {
+						next = append(next, child)
+					}
+				}
+			}
+		}
+		current = next
+	}
+	var values []interface{}
+	for _, c := range current {
+		values = append(values, flatten(c)...)
+	}
+	return values
+}
+
+func flatten(value interface{}) []interface{} {
+	if array, ok := value.([]interface{}); ok {
+		var values []interface{}
+		for _, v := range array {
+			values = append(values, flatten(v)...)
+		}
+		return values
+	}
+	if value == nil {
+		return nil
+	}
+	return []interface{}{value}
+}
+
+// Tags returns the tags of a log, from the comma separated `ddtags` attribute or the `tags` array.
+func Tags(log map[string]interface{}) []string {
+	var tags []string
+	if ddtags, ok := log["ddtags"].(string); ok {
+		for _, tag := range strings.Split(ddtags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	if array, ok := log["tags"].([]interface{}); ok {
+		for _, tag := range array {
+			if s, ok := tag.(string); ok {
+				tags = append(tags, s)
+			}
+		}
+	}
+	return tags
+}
+
+// LogFromFacets builds a log from facet values, keyed the way they are searched: `@` followed by the path of an
+// attribute, a reserved attribute such as `source`, `message` for the message, or the key of a tag.
+func LogFromFacets(facets map[string]string) map[string]interface{} {
+	log := make(map[string]interface{})
+	var tags []string
+	for key, value := range facets {
+		switch {
+		case key == "message":
+			log["message"] = value
+		case strings.HasPrefix(key, "@"):
+			setAttribute(log, key[1:], value)
+		case reservedAttributes[key] != "":
+			setAttribute(log, reservedAttributes[key], value)
+		default:
+			tags = append(tags, key+":"+value)
+		}
+	}
+	if len(tags) > 0 {
+		sort.Strings(tags)
+		log["ddtags"] = strings.Join(tags, ",")
+	}
+	return log
+}
+
+func setAttribute(log map[string]interface{}, attribute string, value interface{}) {
+	keys := strings.Split(attribute, ".")
+	current := log
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+/*
+ * String representation
+ */
+
+func (n And) String() string { return joinNodes(n.Nodes, " AND ") }
+
+func (n Or) String() string { return joinNodes(n.Nodes, " OR ") }
+
+func (n Not) String() string { return "NOT " + n.Node.String() }
+
+func (All) String() string { return "*" }
+
+func (t Term) String() string {
+	var value string
+	switch t.Operator {
+	case "":
+		value = quoteValue(t.Value)
+	case "range":
+		value = "[" + t.Low + " TO " + t.High + "]"
+	default:
+		value = t.Operator + t.Bound
+	}
+	if t.Key == "" {
+		return value
+	}
+	return t.Key + ":" + value
+}
+
+func joinNodes(nodes []Node, separator string) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = node.String()
+		if _, ok := node.(Term); !ok {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+func quoteValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t():\"") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+/*
+ * Parsing
+ */
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// keyword returns whether the input continues with an operator keyword followed by a separator
+func (p *parser) keyword(keyword string) bool {
+	end := p.pos + len(keyword)
+	if end > len(p.input) || p.input[p.pos:end] != keyword {
+		return false
+	}
+	return end == len(p.input) || unicode.IsSpace(rune(p.input[end])) || p.input[end] == '('
+}
+
+func (p *parser) parseOr() (Node, error) {
+	var nodes []Node
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		p.skipSpaces()
+		if !p.keyword("OR") {
+			break
+		}
+		p.pos += len("OR")
+		p.skipSpaces()
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return Or{Nodes: nodes}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	var nodes []Node
+	for {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		p.skipSpaces()
+		if p.keyword("AND") {
+			p.pos += len("AND")
+			p.skipSpaces()
+			continue
+		}
+		if p.done() || p.input[p.pos] == ')' || p.keyword("OR") {
+			break
+		}
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return And{Nodes: nodes}, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.keyword("NOT") {
+		p.pos += len("NOT")
+		p.skipSpaces()
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Node: node}, nil
+	}
+	if !p.done() && p.input[p.pos] == '-' {
+		p.pos++
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	if p.input[p.pos] == '(' {
+		p.pos++
+		p.skipSpaces()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.done() || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		p.pos++
+		return node, nil
+	}
+	if p.input[p.pos] == ')' {
+		return nil, fmt.Errorf("unexpected closing parenthesis at position %d", p.pos)
+	}
+
+	if p.input[p.pos] == '"' {
+		value, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return Term{Value: value}, nil
+	}
+
+	word := p.parseWord(true)
+	if word == "" {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos:p.pos+1], p.pos)
+	}
+	if p.done() || p.input[p.pos] != ':' {
+		if word == "*" {
+			return All{}, nil
+		}
+		return Term{Value: word}, nil
+	}
+	p.pos++
+	return p.parseValue(word)
+}
+
+// parseValue parses the value of a `key:value` term
+func (p *parser) parseValue(key string) (Node, error) {
+	if p.done() {
+		return nil, fmt.Errorf("missing value for %s", key)
+	}
+	switch p.input[p.pos] {
+	case '"':
+		value, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return Term{Key: key, Value: value}, nil
+	case '(':
+		// Values grouped with boolean operators, e.g. `service:(web OR api)`
+		p.pos++
+		p.skipSpaces()
+		node, err := p.parseValueGroup(key)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.done() || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		p.pos++
+		return node, nil
+	case '[', '{':
+		return p.parseRange(key)
+	case '>', '<':
+		operator := p.input[p.pos : p.pos+1]
+		p.pos++
+		if !p.done() && p.input[p.pos] == '=' {
+			operator += "="
+			p.pos++
+		}
+		bound := p.parseWord(false)
+		if bound == "" {
+			return nil, fmt.Errorf("missing value after %s%s", key, operator)
+		}
+		return Term{Key: key, Operator: operator, Bound: bound}, nil
+	}
+	value := p.parseWord(false)
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %s", key)
+	}
+	if key == "*" && value == "*" {
+		return All{}, nil
+	}
+	return Term{Key: key, Value: value}, nil
+}
+
+func (p *parser) parseValueGroup(key string) (Node, error) {
+	var or []Node
+	var and []Node
+	negate := false
+	for {
+		p.skipSpaces()
+		if p.done() {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		if p.input[p.pos] == ')' {
+			break
+		}
+		switch {
+		case p.keyword("OR"):
+			p.pos += len("OR")
+			or = append(or, groupNodes(and))
+			and = nil
+			continue
+		case p.keyword("AND"):
+			p.pos += len("AND")
+			continue
+		case p.keyword("NOT"):
+			p.pos += len("NOT")
+			negate = true
+			continue
+		}
+		node, err := p.parseValue(key)
+		if err != nil {
+			return nil, err
+		}
+		if negate {
+			node = Not{Node: node}
+			negate = false
+		}
+		and = append(and, node)
+	}
+	if len(and) == 0 {
+		return nil, fmt.Errorf("missing value for %s", key)
+	}
+	or = append(or, groupNodes(and))
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return Or{Nodes: or}, nil
+}
+
+func groupNodes(nodes []Node) Node {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+	return And{Nodes: nodes}
+}
+
+func (p *parser) parseRange(key string) (Node, error) {
+	p.pos++
+	end := strings.IndexAny(p.input[p.pos:], "]}")
+	if end < 0 {
+		return nil, fmt.Errorf("missing closing bracket for %s", key)
+	}
+	bounds := strings.Fields(p.input[p.pos : p.pos+end])
+	p.pos += end + 1
+	if len(bounds) != 3 || bounds[1] != "TO" {
+		return nil, fmt.Errorf("invalid range for %s, expected [low TO high]", key)
+	}
+	return Term{Key: key, Operator: "range", Low: bounds[0], High: bounds[2]}, nil
+}
+
+func (p *parser) parseQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.done() {
+		c := p.input[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.input):
+			b.WriteByte(p.input[p.pos+1])
+			p.pos += 2
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted string at position %d", start)
+}
+
+// parseWord parses an unquoted word, stopping at the key separator when parsing a key
+func (p *parser) parseWord(isKey bool) string {
+	var b strings.Builder
+	for !p.done() {
+		c := p.input[p.pos]
+		if c == '\\' && p.pos+1 < len(p.input) {
+			b.WriteByte(p.input[p.pos+1])
+			p.pos += 2
+			continue
+		}
+		if unicode.IsSpace(rune(c)) || c == '(' || c == ')' || (isKey && c == ':') {
+			break
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package logsquery
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseValidation(t *testing.T) {
+	cases := map[string]struct {
+		query string
+		valid bool
+	}{
+		"empty":                   {"", true},
+		"wildcard":                {"*", true},
+		"full text":               {"error", true},
+		"phrase":                  {`"connection refused"`, true},
+		"tag":                     {"env:prod", true},
+		"attribute":               {"@http.status_code:500", true},
+		"boolean operators":       {"source:nginx AND (status:error OR -service:web) NOT env:staging", true},
+		"grouped values":          {"service:(web OR api)", true},
+		"range":                   {"@duration:[100 TO 200]", true},
+		"comparison":              {"@duration:>=100", true},
+		"escaped":                 {`@path:\/api\/v1`, true},
+		"unbalanced parenthesis":  {"(source:nginx", false},
+		"unexpected parenthesis":  {"source:nginx)", false},
+		"unterminated phrase":     {`"connection`, false},
+		"missing value":           {"source:", false},
+		"missing operand":         {"source:nginx AND", false},
+		"invalid range":           {"@duration:[100 200]", false},
+		"missing comparison":      {"@duration:>", false},
+		"unclosed grouped values": {"service:(web OR api", false},
+	}
+	for name, tc := range cases {
+		_, err := Parse(tc.query)
+		if tc.valid && err != nil {
+			t.Errorf("%s: expected %q to be valid, got %s", name, tc.query, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected %q to be invalid", name, tc.query)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	log := `{
+		"message": "GET /api/v1/users failed: Connection refused",
+		"ddsource": "nginx",
+		"service": "Web",
+		"status": "error",
+		"host": "web-1",
+		"ddtags": "env:prod,team:core",
+		"http": {"status_code": 502, "method": "GET"},
+		"duration": 150,
+		"users": [{"id": "a"}, {"id": "b"}]
+	}`
+	cases := map[string]struct {
+		query   string
+		matches bool
+	}{
+		"empty":                     {"", true},
+		"wildcard":                  {"*", true},
+		"full text":                 {"refused", true},
+		"full text case":            {"CONNECTION", true},
+		"full text wildcard":        {"fail*", true},
+		"phrase":                    {`"connection refused"`, true},
+		"missing full text":         {"timeout", false},
+		"source":                    {"source:nginx", true},
+		"reserved case insensitive": {"service:web", true},
+		"reserved wildcard":         {"host:web-*", true},
+		"tag":                       {"env:prod", true},
+		"missing tag":               {"env:staging", false},
+		"attribute case sensitive":  {"@http.method:get", false},
+		"numeric attribute":         {"@http.status_code:502", true},
+		"attribute wildcard":        {"@http.status_code:5*", true},
+		"array attribute":           {"@users.id:b", true},
+		"range":                     {"@duration:[100 TO 200]", true},
+		"unbounded range":           {"@duration:[200 TO *]", false},
+		"comparison":                {"@http.status_code:>=500", true},
+		"failed comparison":         {"@duration:<100", false},
+		"and":                       {"source:nginx status:error", true},
+		"failed and":                {"source:nginx AND status:info", false},
+		"or":                        {"status:info OR env:prod", true},
+		"not":                       {"NOT env:staging", true},
+		"minus":                     {"-source:nginx", false},
+		"grouped values":            {"service:(api OR web)", true},
+		"failed grouped values":     {"service:(api OR db)", false},
+		"nested":                    {"(status:info OR status:error) -team:(web OR api)", true},
+	}
+	var parsedLog map[string]interface{}
+	if err := json.Unmarshal([]byte(log), &parsedLog); err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range cases {
+		query, err := Parse(tc.query)
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", name, err)
+			continue
+		}
+		if matches := query.Match(parsedLog); matches != tc.matches {
+			t.Errorf("%s: expected %q to match %t, got %t", name, tc.query, tc.matches, matches)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	cases := map[string]struct {
+		query    string
+		expected string
+	}{
+		"term":     {"source:nginx", "source:nginx"},
+		"phrase":   {`"connection refused"`, `"connection refused"`},
+		"implicit": {"source:nginx status:error", "source:nginx AND status:error"},
+		"nested":   {"a OR (b -c)", "a OR (b AND (NOT c))"},
+		"range":    {"@duration:[1 TO 2]", "@duration:[1 TO 2]"},
+		"grouped":  {"service:(web OR api)", "service:web OR service:api"},
+	}
+	for name, tc := range cases {
+		query, err := Parse(tc.query)
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", name, err)
+			continue
+		}
+		if s := query.String(); s != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, s)
+		}
+	}
+}
+
+func TestLogFromFacets(t *testing.T) {
+	facets := map[string]string{
+		"source":                 "nginx",
+		"service":                "web",
+		"message":                "GET /api failed",
+		"@http.status_code":      "503",
+		"@http.url_details.path": "/api",
+		"env":                    "prod",
+		"team":                   "core",
+	}
+	log := LogFromFacets(facets)
+	expected := `{"ddsource":"nginx","ddtags":"env:prod,team:core","http":{"status_code":"503","url_details":{"path":"/api"}},"message":"GET /api failed","service":"web"}`
+	if actual, _ := json.Marshal(log); string(actual) != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	for _, q := range []string{"source:nginx", "service:web", "failed", "@http.status_code:>=500", "@http.url_details.path:\\/api", "env:prod team:core"} {
+		query, err := Parse(q)
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if !query.Match(log) {
+			t.Errorf("expected %q to match the log built from facets", q)
+		}
+	}
+}
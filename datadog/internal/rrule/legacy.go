@@ -0,0 +1,95 @@
+package rrule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LegacyRecurrence is the recurrence of a v1 downtime.
+type LegacyRecurrence struct {
+	// Type is one of `days`, `weeks`, `months`, `years` or `rrule`
+	Type             string
+	Period           int
+	WeekDays         []string
+	UntilDate        int64
+	UntilOccurrences int
+	Rrule            string
+}
+
+var legacyFrequencies = map[string]string{
+	"days":   "DAILY",
+	"weeks":  "WEEKLY",
+	"months": "MONTHLY",
+	"years":  "YEARLY",
+}
+
+var legacyWeekDays = map[string]string{
+	"Mon": "MO",
+	"Tue": "TU",
+	"Wed": "WE",
+	"Thu": "TH",
+	"Fri": "FR",
+	"Sat": "SA",
+	"Sun": "SU",
+}
+
+// FromLegacyRecurrence converts a v1 downtime recurrence to the equivalent recurrence rule.
+func FromLegacyRecurrence(r LegacyRecurrence) (string, error) {
+	if r.Type == "rrule" {
+		if _, err := Parse(r.Rrule); err != nil {
+			return "", err
+		}
+		return r.Rrule, nil
+	}
+
+	freq, ok := legacyFrequencies[r.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported recurrence type %q", r.Type)
+	}
+	parts := []string{"FREQ=" + freq}
+	if r.Period > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Period))
+	}
+	if len(r.WeekDays) > 0 {
+		if r.Type != "weeks" {
+			return "", fmt.Errorf("week days are only supported with the `weeks` recurrence type")
+		}
+		days := make([]string, 0, len(r.WeekDays))
+		for _, weekDay := range r.WeekDays {
+			day, ok := legacyWeekDays[weekDay]
+			if !ok {
+				return "", fmt.Errorf("invalid week day %q", weekDay)
+			}
+			days = append(days, day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.UntilDate > 0 {
+		parts = append(parts, "UNTIL="+time.Unix(r.UntilDate, 0).UTC().Format(untilFormats[0]))
+	}
+	if r.UntilOccurrences > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.UntilOccurrences))
+	}
+
+	rule := strings.Join(parts, ";")
+	if _, err := Parse(rule); err != nil {
+		return "", err
+	}
+	return rule, nil
+}
+
+// FormatDuration formats a duration as a downtime recurrence duration, using the largest unit which represents it
+// exactly. Durations are rounded up to the minute.
+func FormatDuration(d time.Duration) string {
+	minutes := int64((d + time.Minute - 1) / time.Minute)
+	switch {
+	case minutes > 0 && minutes%(7*24*60) == 0:
+		return fmt.Sprintf("%dw", minutes/(7*24*60))
+	case minutes > 0 && minutes%(24*60) == 0:
+		return fmt.Sprintf("%dd", minutes/(24*60))
+	case minutes > 0 && minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
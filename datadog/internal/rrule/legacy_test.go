@@ -0,0 +1,52 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromLegacyRecurrence(t *testing.T) {
+	cases := map[string]struct {
+		recurrence LegacyRecurrence
+		expected   string
+		err        bool
+	}{
+		"daily":           {LegacyRecurrence{Type: "days", Period: 1}, "FREQ=DAILY", false},
+		"every two weeks": {LegacyRecurrence{Type: "weeks", Period: 2, WeekDays: []string{"Mon", "Fri"}}, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", false},
+		"until date":      {LegacyRecurrence{Type: "months", Period: 1, UntilDate: 1735689600}, "FREQ=MONTHLY;UNTIL=20250101T000000Z", false},
+		"occurrences":     {LegacyRecurrence{Type: "years", Period: 1, UntilOccurrences: 3}, "FREQ=YEARLY;COUNT=3", false},
+		"rrule":           {LegacyRecurrence{Type: "rrule", Rrule: "FREQ=MONTHLY;BYSETPOS=3;BYDAY=WE"}, "FREQ=MONTHLY;BYSETPOS=3;BYDAY=WE", false},
+		"invalid rrule":   {LegacyRecurrence{Type: "rrule", Rrule: "FREQ=SOMETIMES"}, "", true},
+		"unknown type":    {LegacyRecurrence{Type: "hours", Period: 1}, "", true},
+		"week days":       {LegacyRecurrence{Type: "days", Period: 1, WeekDays: []string{"Mon"}}, "", true},
+		"bad week day":    {LegacyRecurrence{Type: "weeks", Period: 1, WeekDays: []string{"Monday"}}, "", true},
+	}
+	for name, tc := range cases {
+		rule, err := FromLegacyRecurrence(tc.recurrence)
+		if tc.err != (err != nil) {
+			t.Errorf("%s: unexpected error state %v", name, err)
+		}
+		if rule != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, rule)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		90 * time.Second:    "2m",
+		45 * time.Minute:    "45m",
+		2 * time.Hour:       "2h",
+		36 * time.Hour:      "36h",
+		48 * time.Hour:      "2d",
+		14 * 24 * time.Hour: "2w",
+	}
+	for duration, expected := range cases {
+		if actual := FormatDuration(duration); actual != expected {
+			t.Errorf("%s: expected %q, got %q", duration, expected, actual)
+		}
+		if parsed, err := ParseDuration(FormatDuration(duration)); err != nil || parsed < duration {
+			t.Errorf("%s: %q does not round trip", duration, FormatDuration(duration))
+		}
+	}
+}
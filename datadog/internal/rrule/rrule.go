@@ -0,0 +1,658 @@
+// Package rrule implements parsing, validation and expansion of RFC 5545 recurrence rules
+// as accepted by the Datadog downtime and SLO correction APIs.
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ part of a recurrence rule.
+type Frequency int
+
+const (
+	Secondly Frequency = iota
+	Minutely
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencies = map[string]Frequency{
+	"SECONDLY": Secondly,
+	"MINUTELY": Minutely,
+	"HOURLY":   Hourly,
+	"DAILY":    Daily,
+	"WEEKLY":   Weekly,
+	"MONTHLY":  Monthly,
+	"YEARLY":   Yearly,
+}
+
+func (f Frequency) String() string {
+	for name, freq := range frequencies {
+		if freq == f {
+			return name
+		}
+	}
+	return fmt.Sprintf("Frequency(%d)", int(f))
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Weekday is a BYDAY entry, optionally prefixed by an ordinal such as `1MO` or `-1FR`.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByMonth    []int
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByDay      []Weekday
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+	Wkst       time.Weekday
+
+	parts []string
+	// untilFormat is the layout UNTIL was written in, floating and date-only values are resolved in the rule's timezone
+	untilFormat string
+}
+
+var untilFormats = []string{"20060102T150405Z", "20060102T150405", "20060102"}
+
+// Parse parses and validates a recurrence rule such as `FREQ=WEEKLY;BYDAY=MO,FR;INTERVAL=2`.
+// A leading `RRULE:` prefix is accepted.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("rrule must not be empty")
+	}
+
+	r := &Rule{Interval: 1, Wkst: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || key == "" || val == "" {
+			return nil, fmt.Errorf("invalid rrule part %q: expected KEY=VALUE", part)
+		}
+		key = strings.ToUpper(key)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate rrule part %q", key)
+		}
+		seen[key] = true
+		r.parts = append(r.parts, key)
+
+		var err error
+		switch key {
+		case "FREQ":
+			freq, ok := frequencies[strings.ToUpper(val)]
+			if !ok {
+				err = fmt.Errorf("invalid FREQ %q", val)
+			}
+			r.Freq = freq
+		case "INTERVAL":
+			r.Interval, err = parsePositive(key, val)
+		case "COUNT":
+			r.Count, err = parsePositive(key, val)
+		case "UNTIL":
+			r.Until, r.untilFormat, err = parseUntil(val)
+		case "BYMONTH":
+			r.ByMonth, err = parseIntList(key, val, 1, 12, false)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseIntList(key, val, 1, 31, true)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseIntList(key, val, 1, 366, true)
+		case "BYWEEKNO":
+			r.ByWeekNo, err = parseIntList(key, val, 1, 53, true)
+		case "BYHOUR":
+			r.ByHour, err = parseIntList(key, val, 0, 23, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseIntList(key, val, 0, 59, false)
+		case "BYSECOND":
+			r.BySecond, err = parseIntList(key, val, 0, 60, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseIntList(key, val, 1, 366, true)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(val)
+		case "WKST":
+			day, ok := weekdays[strings.ToUpper(val)]
+			if !ok {
+				err = fmt.Errorf("invalid WKST %q", val)
+			}
+			r.Wkst = day
+		case "DTSTART", "DTEND", "DURATION":
+			err = fmt.Errorf("%s is not supported in rrule, use the dedicated attributes instead", key)
+		default:
+			err = fmt.Errorf("unknown rrule part %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.validate(seen); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Parts returns the rule part names in the order they were declared.
+func (r *Rule) Parts() []string {
+	return append([]string(nil), r.parts...)
+}
+
+// CheckParts returns an error if the rule uses a part which isn't in the allowed list.
+func (r *Rule) CheckParts(allowed ...string) error {
+	for _, part := range r.parts {
+		found := false
+		for _, a := range allowed {
+			if strings.EqualFold(part, a) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("rrule part %s is not supported, supported parts are %s", part, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func (r *Rule) validate(seen map[string]bool) error {
+	if !seen["FREQ"] {
+		return fmt.Errorf("rrule must contain FREQ")
+	}
+	if seen["COUNT"] && seen["UNTIL"] {
+		return fmt.Errorf("COUNT and UNTIL must not both be set")
+	}
+	if len(r.ByWeekNo) > 0 && r.Freq != Yearly {
+		return fmt.Errorf("BYWEEKNO is only valid with FREQ=YEARLY")
+	}
+	if len(r.ByYearDay) > 0 && (r.Freq == Daily || r.Freq == Weekly || r.Freq == Monthly) {
+		return fmt.Errorf("BYYEARDAY is not valid with FREQ=%s", r.Freq)
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		return fmt.Errorf("BYMONTHDAY is not valid with FREQ=WEEKLY")
+	}
+	for _, wd := range r.ByDay {
+		if wd.N == 0 {
+			continue
+		}
+		if r.Freq != Monthly && r.Freq != Yearly {
+			return fmt.Errorf("BYDAY with an ordinal is only valid with FREQ=MONTHLY or FREQ=YEARLY")
+		}
+		if r.Freq == Yearly && len(r.ByWeekNo) > 0 {
+			return fmt.Errorf("BYDAY with an ordinal is not valid together with BYWEEKNO")
+		}
+	}
+	if len(r.BySetPos) > 0 && !r.hasByRule() {
+		return fmt.Errorf("BYSETPOS must be used together with another BYxxx rule part")
+	}
+	return nil
+}
+
+func (r *Rule) hasByRule() bool {
+	return len(r.ByMonth) > 0 || len(r.ByMonthDay) > 0 || len(r.ByYearDay) > 0 || len(r.ByWeekNo) > 0 ||
+		len(r.ByDay) > 0 || len(r.ByHour) > 0 || len(r.ByMinute) > 0 || len(r.BySecond) > 0
+}
+
+func parsePositive(key, val string) (int, error) {
+	i, err := strconv.Atoi(val)
+	if err != nil || i < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", key, val)
+	}
+	return i, nil
+}
+
+func parseUntil(val string) (time.Time, string, error) {
+	for _, format := range untilFormats {
+		if t, err := time.Parse(format, val); err == nil {
+			return t, format, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("invalid UNTIL %q: expected a date (YYYYMMDD) or a date-time (YYYYMMDDTHHMMSSZ)", val)
+}
+
+func parseIntList(key, val string, min, max int, allowNegative bool) ([]int, error) {
+	var result []int
+	for _, item := range strings.Split(val, ",") {
+		i, err := strconv.Atoi(strings.TrimPrefix(item, "+"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", key, item)
+		}
+		abs := i
+		if abs < 0 {
+			if !allowNegative {
+				return nil, fmt.Errorf("invalid %s value %q: must be between %d and %d", key, item, min, max)
+			}
+			abs = -abs
+		}
+		if abs < min || abs > max {
+			if allowNegative {
+				return nil, fmt.Errorf("invalid %s value %q: must be between %d and %d or between -%d and -%d", key, item, min, max, max, min)
+			}
+			return nil, fmt.Errorf("invalid %s value %q: must be between %d and %d", key, item, min, max)
+		}
+		result = append(result, i)
+	}
+	return result, nil
+}
+
+func parseByDay(val string) ([]Weekday, error) {
+	var result []Weekday
+	for _, item := range strings.Split(val, ",") {
+		item = strings.ToUpper(item)
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+		wd := Weekday{Day: day}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+			if err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, fmt.Errorf("invalid BYDAY value %q", item)
+			}
+			wd.N = n
+		}
+		result = append(result, wd)
+	}
+	return result, nil
+}
+
+// maxEmptyPeriods bounds the expansion of rules which never (or very rarely) match, such as BYMONTHDAY=31;BYMONTH=2.
+const maxEmptyPeriods = 5000
+
+// Occurrences returns up to n occurrence start times of the rule anchored at dtstart that are not before `after`.
+// Occurrences are computed in the location of dtstart, so wall-clock times are preserved across DST changes.
+func (r *Rule) Occurrences(dtstart, after time.Time, n int) []time.Time {
+	var result []time.Time
+	if n <= 0 {
+		return result
+	}
+	until := r.Until
+	if !until.IsZero() && r.untilFormat != untilFormats[0] {
+		until = time.Date(until.Year(), until.Month(), until.Day(), until.Hour(), until.Minute(), until.Second(), 0, dtstart.Location())
+		if r.untilFormat == untilFormats[2] {
+			// A date-only UNTIL includes the whole day
+			until = until.AddDate(0, 0, 1).Add(-time.Second)
+		}
+	}
+
+	emitted := 0
+	empty := 0
+	for period := r.firstPeriod(dtstart, after); empty < maxEmptyPeriods; period++ {
+		candidates := r.expandPeriod(dtstart, period)
+		if len(candidates) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+		for _, c := range candidates {
+			if c.Before(dtstart) {
+				continue
+			}
+			if !until.IsZero() && c.After(until) {
+				return result
+			}
+			emitted++
+			if r.Count > 0 && emitted > r.Count {
+				return result
+			}
+			if !c.Before(after) {
+				result = append(result, c)
+				if len(result) >= n {
+					return result
+				}
+			}
+		}
+	}
+	return result
+}
+
+// firstPeriod returns the index of a period at or before the first one with occurrences not before `after`, so the
+// periods which only have earlier occurrences aren't expanded. Rules with COUNT are expanded from dtstart, as every
+// occurrence is counted.
+func (r *Rule) firstPeriod(dtstart, after time.Time) int {
+	if r.Count > 0 || !after.After(dtstart) {
+		return 0
+	}
+	after = after.In(dtstart.Location())
+
+	var units int
+	switch r.Freq {
+	case Yearly:
+		units = after.Year() - dtstart.Year()
+	case Monthly:
+		units = (after.Year()-dtstart.Year())*12 + int(after.Month()) - int(dtstart.Month())
+	case Weekly:
+		units = daysBetween(dtstart, after) / 7
+	case Daily:
+		units = daysBetween(dtstart, after)
+	default:
+		// Sub-daily periods follow the wall clock, which can be an hour off the elapsed time across DST changes
+		elapsed := after.Sub(dtstart) - 2*time.Hour
+		if elapsed <= 0 {
+			return 0
+		}
+		switch r.Freq {
+		case Hourly:
+			units = int(elapsed / time.Hour)
+		case Minutely:
+			units = int(elapsed / time.Minute)
+		default:
+			units = int(elapsed / time.Second)
+		}
+	}
+
+	// The period before the estimated one is used, as the weeks of weekly rules aren't aligned on dtstart
+	if period := units/r.Interval - 1; period > 0 {
+		return period
+	}
+	return 0
+}
+
+// daysBetween returns the number of calendar days from the day of a to the day of b.
+func daysBetween(a, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(dayB.Sub(dayA) / (24 * time.Hour))
+}
+
+// Window is a single occurrence of a recurring schedule.
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Windows returns up to n windows of the given duration which end after `after`, so a window in progress is included.
+func (r *Rule) Windows(dtstart time.Time, duration time.Duration, after time.Time, n int) []Window {
+	var result []Window
+	for _, start := range r.Occurrences(dtstart, after.Add(-duration).Add(time.Second), n) {
+		result = append(result, Window{Start: start, End: start.Add(duration)})
+	}
+	return result
+}
+
+// ParseDuration parses a downtime recurrence duration such as `30m`, `2h`, `1d` or `1w`.
+func ParseDuration(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid duration %q: must begin with an integer and end with one of 'm', 'h', 'd', or 'w'", value)
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid duration %q: must begin with an integer and end with one of 'm', 'h', 'd', or 'w'", value)
+	}
+	switch value[len(value)-1] {
+	case 'm':
+		return time.Duration(n) * time.Minute, nil
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid duration %q: must begin with an integer and end with one of 'm', 'h', 'd', or 'w'", value)
+}
+
+// expandPeriod returns the sorted occurrences of the given period index, after BYSETPOS is applied.
+func (r *Rule) expandPeriod(dtstart time.Time, period int) []time.Time {
+	loc := dtstart.Location()
+	step := period * r.Interval
+
+	var days []time.Time
+	switch r.Freq {
+	case Yearly:
+		year := dtstart.Year() + step
+		for d := time.Date(year, 1, 1, 0, 0, 0, 0, loc); d.Year() == year; d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+		for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case Weekly:
+		start := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day()+7*step, 0, 0, 0, 0, loc)
+		offset := (int(start.Weekday()) - int(r.Wkst) + 7) % 7
+		start = start.AddDate(0, 0, -offset)
+		for i := 0; i < 7; i++ {
+			days = append(days, start.AddDate(0, 0, i))
+		}
+	case Daily:
+		days = append(days, time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day()+step, 0, 0, 0, 0, loc))
+	default:
+		return r.expandSubDaily(dtstart, step)
+	}
+
+	var filtered []time.Time
+	for _, d := range days {
+		if r.matchDay(d, dtstart) {
+			filtered = append(filtered, d)
+		}
+	}
+
+	var result []time.Time
+	for _, d := range filtered {
+		for _, h := range orDefault(r.ByHour, dtstart.Hour()) {
+			for _, m := range orDefault(r.ByMinute, dtstart.Minute()) {
+				for _, s := range orDefault(r.BySecond, dtstart.Second()) {
+					result = append(result, time.Date(d.Year(), d.Month(), d.Day(), h, m, s, 0, loc))
+				}
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return r.applySetPos(result)
+}
+
+func (r *Rule) expandSubDaily(dtstart time.Time, step int) []time.Time {
+	loc := dtstart.Location()
+	var base time.Time
+	switch r.Freq {
+	case Hourly:
+		base = time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), dtstart.Hour()+step, 0, 0, 0, loc)
+	case Minutely:
+		base = time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), dtstart.Hour(), dtstart.Minute()+step, 0, 0, loc)
+	default:
+		base = time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), dtstart.Hour(), dtstart.Minute(), dtstart.Second()+step, 0, loc)
+	}
+	day := time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, loc)
+	if !r.matchDay(day, dtstart) || (len(r.ByHour) > 0 && !containsInt(r.ByHour, base.Hour())) {
+		return nil
+	}
+
+	minutes := []int{base.Minute()}
+	if r.Freq == Hourly {
+		minutes = orDefault(r.ByMinute, dtstart.Minute())
+	} else if len(r.ByMinute) > 0 && !containsInt(r.ByMinute, base.Minute()) {
+		return nil
+	}
+	seconds := []int{base.Second()}
+	if r.Freq != Secondly {
+		seconds = orDefault(r.BySecond, dtstart.Second())
+	} else if len(r.BySecond) > 0 && !containsInt(r.BySecond, base.Second()) {
+		return nil
+	}
+
+	var result []time.Time
+	for _, m := range minutes {
+		for _, s := range seconds {
+			result = append(result, time.Date(base.Year(), base.Month(), base.Day(), base.Hour(), m, s, 0, loc))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return r.applySetPos(result)
+}
+
+// matchDay reports whether a calendar day is selected by the day-level BYxxx parts of the rule.
+func (r *Rule) matchDay(d, dtstart time.Time) bool {
+	if len(r.ByMonth) > 0 && !containsInt(r.ByMonth, int(d.Month())) {
+		return false
+	}
+	if len(r.ByWeekNo) > 0 {
+		week, weeks := weekNumber(d, r.Wkst)
+		if !matchSigned(r.ByWeekNo, week, weeks) {
+			return false
+		}
+	}
+	if len(r.ByYearDay) > 0 && !matchSigned(r.ByYearDay, d.YearDay(), daysIn(d.Year())) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !matchSigned(r.ByMonthDay, d.Day(), daysInMonth(d)) {
+		return false
+	}
+	if len(r.ByDay) > 0 && !r.matchByDay(d) {
+		return false
+	}
+
+	// Implicit expansion from dtstart when no explicit day selector was provided
+	switch r.Freq {
+	case Yearly:
+		if len(r.ByMonth) == 0 && len(r.ByWeekNo) == 0 && len(r.ByYearDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			return d.Month() == dtstart.Month() && d.Day() == dtstart.Day()
+		}
+		if len(r.ByMonth) > 0 && len(r.ByWeekNo) == 0 && len(r.ByYearDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			return d.Day() == dtstart.Day()
+		}
+		if len(r.ByWeekNo) > 0 && len(r.ByYearDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			return d.Weekday() == dtstart.Weekday()
+		}
+	case Monthly:
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			return d.Day() == dtstart.Day()
+		}
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			return d.Weekday() == dtstart.Weekday()
+		}
+	}
+	return true
+}
+
+func (r *Rule) matchByDay(d time.Time) bool {
+	for _, wd := range r.ByDay {
+		if wd.Day != d.Weekday() {
+			continue
+		}
+		if wd.N == 0 {
+			return true
+		}
+		// Ordinals are relative to the month for MONTHLY rules and for YEARLY rules restricted with BYMONTH
+		var index, total int
+		if r.Freq == Monthly || len(r.ByMonth) > 0 {
+			index = (d.Day()-1)/7 + 1
+			total = (daysInMonth(d)-d.Day())/7 + index
+		} else {
+			index = (d.YearDay()-1)/7 + 1
+			total = (daysIn(d.Year())-d.YearDay())/7 + index
+		}
+		if (wd.N > 0 && wd.N == index) || (wd.N < 0 && total+wd.N+1 == index) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) applySetPos(candidates []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return candidates
+	}
+	var result []time.Time
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if i >= 0 && i < len(candidates) {
+			result = append(result, candidates[i])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+// weekNumber returns the RFC 5545 week number of d, where week 1 is the first week with at least four days
+// in the year, along with the number of weeks in that year.
+func weekNumber(d time.Time, wkst time.Weekday) (int, int) {
+	firstWeekStart := func(year int) time.Time {
+		jan1 := time.Date(year, 1, 1, 0, 0, 0, 0, d.Location())
+		offset := (int(jan1.Weekday()) - int(wkst) + 7) % 7
+		start := jan1.AddDate(0, 0, -offset)
+		if offset >= 4 {
+			start = start.AddDate(0, 0, 7)
+		}
+		return start
+	}
+	year := d.Year()
+	start := firstWeekStart(year)
+	if d.Before(start) {
+		year--
+		start = firstWeekStart(year)
+	} else if next := firstWeekStart(year + 1); !d.Before(next) {
+		year++
+		start = next
+	}
+	weeks := int(firstWeekStart(year+1).Sub(start).Hours()/24+0.5) / 7
+	week := int(d.Sub(start).Hours()/24+0.5)/7 + 1
+	return week, weeks
+}
+
+func matchSigned(values []int, value, total int) bool {
+	for _, v := range values {
+		if v == value || (v < 0 && total+v+1 == value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func orDefault(values []int, def int) []int {
+	if len(values) == 0 {
+		return []int{def}
+	}
+	return values
+}
+
+func daysIn(year int) int {
+	return time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+func daysInMonth(d time.Time) int {
+	return time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,228 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseValidation(t *testing.T) {
+	cases := map[string]struct {
+		rule  string
+		valid bool
+	}{
+		"daily":                      {"FREQ=DAILY", true},
+		"prefix":                     {"RRULE:FREQ=WEEKLY;BYDAY=MO,FR", true},
+		"lowercase":                  {"freq=monthly;bymonthday=1", true},
+		"ordinal byday":              {"FREQ=MONTHLY;BYDAY=-1FR", true},
+		"setpos":                     {"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", true},
+		"until date":                 {"FREQ=DAILY;UNTIL=20240101", true},
+		"until utc":                  {"FREQ=DAILY;UNTIL=20240101T100000Z", true},
+		"empty":                      {"", false},
+		"missing freq":               {"INTERVAL=2", false},
+		"invalid freq":               {"FREQ=FORTNIGHTLY", false},
+		"count and until":            {"FREQ=DAILY;COUNT=2;UNTIL=20240101", false},
+		"zero interval":              {"FREQ=DAILY;INTERVAL=0", false},
+		"dtstart":                    {"FREQ=DAILY;DTSTART=20240101T000000Z", false},
+		"duplicate":                  {"FREQ=DAILY;FREQ=WEEKLY", false},
+		"unknown part":               {"FREQ=DAILY;FOO=BAR", false},
+		"bad byday":                  {"FREQ=WEEKLY;BYDAY=XX", false},
+		"ordinal byday weekly":       {"FREQ=WEEKLY;BYDAY=1MO", false},
+		"byweekno monthly":           {"FREQ=MONTHLY;BYWEEKNO=1", false},
+		"bymonthday weekly":          {"FREQ=WEEKLY;BYMONTHDAY=1", false},
+		"bymonth out of range":       {"FREQ=YEARLY;BYMONTH=13", false},
+		"negative byhour":            {"FREQ=DAILY;BYHOUR=-1", false},
+		"setpos without other rules": {"FREQ=MONTHLY;BYSETPOS=1", false},
+		"missing value":              {"FREQ=", false},
+	}
+	for name, tc := range cases {
+		_, err := Parse(tc.rule)
+		if tc.valid && err != nil {
+			t.Errorf("%s: expected %q to be valid, got %s", name, tc.rule, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected %q to be invalid", name, tc.rule)
+		}
+	}
+}
+
+func TestCheckParts(t *testing.T) {
+	r, err := Parse("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CheckParts("FREQ", "INTERVAL", "BYDAY"); err != nil {
+		t.Errorf("expected parts to be allowed, got %s", err)
+	}
+	if err := r.CheckParts("FREQ", "INTERVAL", "COUNT", "UNTIL"); err == nil {
+		t.Errorf("expected BYDAY to be rejected")
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone database unavailable")
+	}
+	cases := map[string]struct {
+		rule     string
+		dtstart  time.Time
+		after    time.Time
+		n        int
+		expected []string
+	}{
+		"daily with count": {
+			rule:     "FREQ=DAILY;COUNT=3",
+			dtstart:  time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC),
+			n:        5,
+			expected: []string{"2024-01-30T09:00:00Z", "2024-01-31T09:00:00Z", "2024-02-01T09:00:00Z"},
+		},
+		"weekly by day with interval": {
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			dtstart:  time.Date(2024, 1, 2, 22, 0, 0, 0, time.UTC),
+			n:        4,
+			expected: []string{"2024-01-02T22:00:00Z", "2024-01-04T22:00:00Z", "2024-01-16T22:00:00Z", "2024-01-18T22:00:00Z"},
+		},
+		"monthly last friday": {
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			n:        3,
+			expected: []string{"2024-01-26T12:00:00Z", "2024-02-23T12:00:00Z", "2024-03-29T12:00:00Z"},
+		},
+		"monthly skips short months": {
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=31",
+			dtstart:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			n:        3,
+			expected: []string{"2024-01-31T00:00:00Z", "2024-03-31T00:00:00Z", "2024-05-31T00:00:00Z"},
+		},
+		"last weekday of month": {
+			rule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart:  time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+			n:        2,
+			expected: []string{"2024-03-29T08:00:00Z", "2024-04-30T08:00:00Z"},
+		},
+		"yearly": {
+			rule:     "FREQ=YEARLY",
+			dtstart:  time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			n:        2,
+			expected: []string{"2024-02-29T00:00:00Z", "2028-02-29T00:00:00Z"},
+		},
+		"until date is inclusive": {
+			rule:     "FREQ=DAILY;UNTIL=20240103",
+			dtstart:  time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+			n:        5,
+			expected: []string{"2024-01-01T23:00:00Z", "2024-01-02T23:00:00Z", "2024-01-03T23:00:00Z"},
+		},
+		"after skips past occurrences": {
+			rule:     "FREQ=HOURLY;INTERVAL=6",
+			dtstart:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			after:    time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+			n:        2,
+			expected: []string{"2024-01-02T06:00:00Z", "2024-01-02T12:00:00Z"},
+		},
+		"after far from start": {
+			rule:     "FREQ=MINUTELY;INTERVAL=15",
+			dtstart:  time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			after:    time.Date(2030, 6, 15, 10, 7, 0, 0, time.UTC),
+			n:        2,
+			expected: []string{"2030-06-15T10:15:00Z", "2030-06-15T10:30:00Z"},
+		},
+		"after far from start with weekly interval": {
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			dtstart:  time.Date(2024, 1, 2, 22, 0, 0, 0, time.UTC),
+			after:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			n:        2,
+			expected: []string{"2025-01-02T22:00:00Z", "2025-01-14T22:00:00Z"},
+		},
+		"after across dst": {
+			rule:     "FREQ=HOURLY",
+			dtstart:  time.Date(2024, 3, 9, 1, 30, 0, 0, ny),
+			after:    time.Date(2024, 3, 10, 4, 0, 0, 0, ny),
+			n:        2,
+			expected: []string{"2024-03-10T04:30:00-04:00", "2024-03-10T05:30:00-04:00"},
+		},
+		"count applies before after": {
+			rule:     "FREQ=DAILY;COUNT=2",
+			dtstart:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			after:    time.Date(2024, 1, 2, 0, 0, 1, 0, time.UTC),
+			n:        2,
+			expected: nil,
+		},
+		"wall clock is kept across dst": {
+			rule:     "FREQ=DAILY",
+			dtstart:  time.Date(2024, 3, 9, 2, 30, 0, 0, ny).Add(-time.Hour),
+			n:        2,
+			expected: []string{"2024-03-09T01:30:00-05:00", "2024-03-10T01:30:00-05:00"},
+		},
+		"wall clock after dst": {
+			rule:     "FREQ=WEEKLY",
+			dtstart:  time.Date(2024, 3, 4, 9, 0, 0, 0, ny),
+			n:        2,
+			expected: []string{"2024-03-04T09:00:00-05:00", "2024-03-11T09:00:00-04:00"},
+		},
+	}
+	for name, tc := range cases {
+		r, err := Parse(tc.rule)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		after := tc.after
+		if after.IsZero() {
+			after = tc.dtstart
+		}
+		var actual []string
+		for _, o := range r.Occurrences(tc.dtstart, after, tc.n) {
+			actual = append(actual, o.Format(time.RFC3339))
+		}
+		if len(actual) != len(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != tc.expected[i] {
+				t.Errorf("%s: expected %v, got %v", name, tc.expected, actual)
+				break
+			}
+		}
+	}
+}
+
+func TestWindows(t *testing.T) {
+	r, err := Parse("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dtstart := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)
+	after := time.Date(2024, 1, 3, 23, 0, 0, 0, time.UTC)
+	windows := r.Windows(dtstart, 2*time.Hour, after, 2)
+	if len(windows) != 2 {
+		t.Fatalf("expected 2 windows, got %d", len(windows))
+	}
+	if !windows[0].Start.Equal(time.Date(2024, 1, 3, 22, 0, 0, 0, time.UTC)) || !windows[0].End.Equal(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the window in progress to be returned first, got %v", windows[0])
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]struct {
+		value    string
+		expected time.Duration
+		err      bool
+	}{
+		"minutes": {"30m", 30 * time.Minute, false},
+		"hours":   {"2h", 2 * time.Hour, false},
+		"days":    {"1d", 24 * time.Hour, false},
+		"weeks":   {"1w", 7 * 24 * time.Hour, false},
+		"no unit": {"10", 0, true},
+		"seconds": {"10s", 0, true},
+		"empty":   {"", 0, true},
+	}
+	for name, tc := range cases {
+		d, err := ParseDuration(tc.value)
+		if tc.err != (err != nil) {
+			t.Errorf("%s: unexpected error state %v", name, err)
+		}
+		if d != tc.expected {
+			t.Errorf("%s: expected %s, got %s", name, tc.expected, d)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+// Package sdsrule implements the evaluation of Sensitive Data Scanner rules against sample data: compilation of their
+// pattern under the regex dialect of the scanner, keyword proximity and text replacement.
+package sdsrule
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+// Text replacement types
+const (
+	ReplacementNone             = "none"
+	ReplacementHash             = "hash"
+	ReplacementString           = "replacement_string"
+	ReplacementPartialFromStart = "partial_replacement_from_beginning"
+	ReplacementPartialFromEnd   = "partial_replacement_from_end"
+)
+
+// partialReplacementCharacter replaces each character of partially replaced matches
+const partialReplacementCharacter = "*"
+
+var boundedQuantifierRegex = regexp.MustCompile(`^\{\d+(,\d*)?\}`)
+
+// malformedPatternCodes are the errors of the Go regex parser that are errors under the PCRE syntax as well. The other
+// errors are limits of the Go regex engine, such as free-spacing mode or repetitions above 1000, which the scanner
+// supports.
+var malformedPatternCodes = map[syntax.ErrorCode]bool{
+	syntax.ErrInvalidCharRange:      true,
+	syntax.ErrMissingBracket:        true,
+	syntax.ErrMissingParen:          true,
+	syntax.ErrMissingRepeatArgument: true,
+	syntax.ErrTrailingBackslash:     true,
+	syntax.ErrUnexpectedParen:       true,
+}
+
+// Check checks a pattern of the scanner. The scanner supports the PCRE syntax except backreferences, lookarounds,
+// atomic groups, possessive quantifiers, subroutines, recursion, conditionals, backtracking control verbs, `\C`, `\R`
+// and `\K`, which are rejected along with malformed patterns. Patterns only beyond the limits of the Go regex engine
+// are accepted, even though Compile rejects them.
+func Check(pattern string) error {
+	translated, err := translate(pattern)
+	if err != nil {
+		return err
+	}
+	if _, err := syntax.Parse(translated, syntax.Perl); err != nil {
+		var syntaxErr *syntax.Error
+		if errors.As(err, &syntaxErr) && malformedPatternCodes[syntaxErr.Code] {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compile compiles a pattern of the scanner with the Go regex engine, rejecting the same constructs as Check as well
+// as the patterns beyond the limits of the Go regex engine.
+func Compile(pattern string) (*regexp.Regexp, error) {
+	translated, err := translate(pattern)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(translated)
+	if err != nil {
+		return nil, fmt.Errorf("pattern can't be evaluated by the provider: %w", err)
+	}
+	return re, nil
+}
+
+// translate returns the Go syntax of a pattern of the scanner
+func translate(pattern string) (string, error) {
+	var out strings.Builder
+	inClass := false
+	quantified := false
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		wasQuantified := quantified
+		quantified = false
+		switch {
+		case c == '\\':
+			if i+1 >= len(pattern) {
+				return "", fmt.Errorf("trailing backslash at end of pattern")
+			}
+			next, size := utf8.DecodeRuneInString(pattern[i+1:])
+			translated, err := translateEscape(next, inClass)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(translated)
+			i += 1 + size
+			continue
+		case inClass:
+			if c == '[' && strings.HasPrefix(pattern[i:], "[:") {
+				if end := strings.Index(pattern[i:], ":]"); end > 0 {
+					out.WriteString(pattern[i : i+end+2])
+					i += end + 2
+					continue
+				}
+			}
+			if c == ']' {
+				inClass = false
+			}
+		case c == '[':
+			inClass = true
+			out.WriteByte(c)
+			i++
+			if strings.HasPrefix(pattern[i:], "^") {
+				out.WriteByte('^')
+				i++
+			}
+			if strings.HasPrefix(pattern[i:], "]") {
+				// A closing bracket right after the opening one is literal
+				out.WriteString(`\]`)
+				i++
+			}
+			continue
+		case c == '(':
+			if strings.HasPrefix(pattern[i:], "(*") {
+				return "", fmt.Errorf("backtracking control verbs are not supported by the scanner")
+			}
+			if strings.HasPrefix(pattern[i:], "(?#") {
+				end := strings.IndexByte(pattern[i:], ')')
+				if end < 0 {
+					return "", fmt.Errorf("missing closing ) of comment")
+				}
+				i += end + 1
+				continue
+			}
+			if err := checkGroup(pattern[i:]); err != nil {
+				return "", err
+			}
+		case c == '{':
+			if quantifier := boundedQuantifierRegex.FindString(pattern[i:]); quantifier != "" {
+				out.WriteString(quantifier)
+				i += len(quantifier)
+				quantified = true
+				continue
+			}
+		case c == '*', c == '+', c == '?':
+			if wasQuantified && c == '+' {
+				return "", fmt.Errorf("possessive quantifiers are not supported by the scanner")
+			}
+			// A lazy modifier ends the quantifier
+			quantified = !(wasQuantified && c == '?')
+		}
+		out.WriteByte(c)
+		i++
+	}
+	return out.String(), nil
+}
+
+// translateEscape returns the Go syntax of an escaped character
+func translateEscape(c rune, inClass bool) (string, error) {
+	switch {
+	case c >= '1' && c <= '9' && !inClass:
+		return "", fmt.Errorf("backreferences are not supported by the scanner")
+	case (c == 'k' || c == 'g') && !inClass:
+		return "", fmt.Errorf("backreferences and subroutines are not supported by the scanner")
+	case c == 'C' || c == 'R' || c == 'K':
+		return "", fmt.Errorf(`\%c is not supported by the scanner`, c)
+	case c == 'e':
+		return `\x1B`, nil
+	case c == 'h' && inClass:
+		return `\t \x{A0}`, nil
+	case c == 'h':
+		return `[\t \x{A0}]`, nil
+	case c == 'H' && !inClass:
+		return `[^\t \x{A0}]`, nil
+	case c == 'Z' && !inClass:
+		return `(?:\n?\z)`, nil
+	}
+	return `\` + string(c), nil
+}
+
+// checkGroup rejects the groups of the PCRE syntax not supported by the scanner
+func checkGroup(group string) error {
+	switch {
+	case strings.HasPrefix(group, "(?="), strings.HasPrefix(group, "(?!"), strings.HasPrefix(group, "(?<="), strings.HasPrefix(group, "(?<!"):
+		return fmt.Errorf("lookarounds are not supported by the scanner")
+	case strings.HasPrefix(group, "(?>"):
+		return fmt.Errorf("atomic groups are not supported by the scanner")
+	case strings.HasPrefix(group, "(?("):
+		return fmt.Errorf("conditional patterns are not supported by the scanner")
+	case strings.HasPrefix(group, "(?|"):
+		return fmt.Errorf("branch reset groups are not supported by the scanner")
+	case strings.HasPrefix(group, "(?P="), strings.HasPrefix(group, "(?P>"), strings.HasPrefix(group, "(?&"), strings.HasPrefix(group, "(?R)"):
+		return fmt.Errorf("backreferences, subroutines and recursion are not supported by the scanner")
+	case len(group) > 2 && strings.HasPrefix(group, "(?") && (group[2] >= '0' && group[2] <= '9' || group[2] == '+' || group[2] == '-' && len(group) > 3 && group[3] >= '0' && group[3] <= '9'):
+		return fmt.Errorf("subroutines and recursion are not supported by the scanner")
+	}
+	return nil
+}
+
+// TextReplacement describes how the matches of a rule are replaced.
+type TextReplacement struct {
+	Type              string
+	ReplacementString string
+	NumberOfChars     int
+}
+
+// Rule is a scanning rule.
+type Rule struct {
+	Pattern *regexp.Regexp
+	// Keywords, if any, must be found in the CharacterCount characters before a match to keep it
+	Keywords        []string
+	CharacterCount  int
+	TextReplacement TextReplacement
+}
+
+// Matches returns the byte offsets of the matches of the rule in a sample, as pairs of start and end.
+func (r *Rule) Matches(sample string) [][]int {
+	var matches [][]int
+	for _, match := range r.Pattern.FindAllStringIndex(sample, -1) {
+		if match[0] == match[1] {
+			// Empty matches redact nothing
+			continue
+		}
+		if len(r.Keywords) > 0 && !r.hasKeywordBefore(sample, match[0]) {
+			continue
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+func (r *Rule) hasKeywordBefore(sample string, offset int) bool {
+	start := offset
+	for n := 0; n < r.CharacterCount && start > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(sample[:start])
+		start -= size
+	}
+	window := strings.ToLower(sample[start:offset])
+	for _, keyword := range r.Keywords {
+		if keyword != "" && strings.Contains(window, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a sample with the matches of the rule replaced. Hashes can't be computed locally.
+func (r *Rule) Redact(sample string) (string, error) {
+	var out strings.Builder
+	last := 0
+	for _, match := range r.Matches(sample) {
+		out.WriteString(sample[last:match[0]])
+		replaced, err := r.replace(sample[match[0]:match[1]])
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(replaced)
+		last = match[1]
+	}
+	out.WriteString(sample[last:])
+	return out.String(), nil
+}
+
+func (r *Rule) replace(match string) (string, error) {
+	replacement := r.TextReplacement
+	runes := []rune(match)
+	n := replacement.NumberOfChars
+	if n > len(runes) {
+		n = len(runes)
+	}
+	switch replacement.Type {
+	case "", ReplacementNone:
+		return match, nil
+	case ReplacementString:
+		return replacement.ReplacementString, nil
+	case ReplacementPartialFromStart:
+		return strings.Repeat(partialReplacementCharacter, n) + string(runes[n:]), nil
+	case ReplacementPartialFromEnd:
+		return string(runes[:len(runes)-n]) + strings.Repeat(partialReplacementCharacter, n), nil
+	case ReplacementHash:
+		return "", fmt.Errorf("hashed replacements can't be computed locally")
+	}
+	return "", fmt.Errorf("unknown text replacement type %q", replacement.Type)
+}
+
+// CheckSample checks whether a rule matches a sample as expected and, when an output is expected, that the redacted
+// sample is the expected output.
+func (r *Rule) CheckSample(sample string, shouldMatch bool, expectedOutput *string) error {
+	matches := r.Matches(sample)
+	if shouldMatch && len(matches) == 0 {
+		return fmt.Errorf("sample %q should match but doesn't", sample)
+	}
+	if !shouldMatch && len(matches) > 0 {
+		return fmt.Errorf("sample %q shouldn't match but %q matches", sample, sample[matches[0][0]:matches[0][1]])
+	}
+	if expectedOutput == nil {
+		return nil
+	}
+	output, err := r.Redact(sample)
+	if err != nil {
+		return fmt.Errorf("sample %q: %w", sample, err)
+	}
+	if output != *expectedOutput {
+		return fmt.Errorf("sample %q is redacted as %q, expected %q", sample, output, *expectedOutput)
+	}
+	return nil
+}
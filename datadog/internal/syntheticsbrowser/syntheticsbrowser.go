@@ -0,0 +1,205 @@
+// Package syntheticsbrowser converts the steps of Synthetic browser tests returned by the API to their Terraform
+// representation. It is shared by the `datadog_synthetics_test` resource and the `datadog_synthetics_browser_test_hcl`
+// data source.
+package syntheticsbrowser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+// ParamsKeysForStepType returns the keys of the `params` block used by a step type.
+func ParamsKeysForStepType(stepType datadogV1.SyntheticsStepType) []string {
+	switch stepType {
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_CURRENT_URL:
+		return []string{"check", "value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_ATTRIBUTE:
+		return []string{"attribute", "check", "element", "value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_CONTENT:
+		return []string{"check", "element", "value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_PRESENT:
+		return []string{"element"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_EMAIL:
+		return []string{"email"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_FILE_DOWNLOAD:
+		return []string{"file"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_FROM_JAVASCRIPT:
+		return []string{"code", "element"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_PAGE_CONTAINS:
+		return []string{"value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_PAGE_LACKS:
+		return []string{"value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_CLICK:
+		return []string{"click_type", "element"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_EXTRACT_FROM_JAVASCRIPT:
+		return []string{"code", "element", "variable"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_EXTRACT_VARIABLE:
+		return []string{"element", "variable"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_GO_TO_EMAIL_LINK:
+		return []string{"value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_GO_TO_URL:
+		return []string{"value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_HOVER:
+		return []string{"element"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_PLAY_SUB_TEST:
+		return []string{"playing_tab_id", "subtest_public_id"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_PRESS_KEY:
+		return []string{"modifiers", "value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_REFRESH:
+		return []string{}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_RUN_API_TEST:
+		return []string{"request"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_SCROLL:
+		return []string{"element", "x", "y"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_SELECT_OPTION:
+		return []string{"element", "value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_TYPE_TEXT:
+		return []string{"delay", "element", "value"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_UPLOAD_FILES:
+		return []string{"element", "files", "with_click"}
+
+	case datadogV1.SYNTHETICSSTEPTYPE_WAIT:
+		return []string{"value"}
+	}
+
+	return []string{}
+}
+
+// ConvertStepParamsKey converts a key of the `params` block to the key of the API payload, and the other way around.
+func ConvertStepParamsKey(key string) string {
+	switch key {
+	case "click_type":
+		return "clickType"
+
+	case "clickType":
+		return "click_type"
+
+	case "playing_tab_id":
+		return "playingTabId"
+
+	case "playingTabId":
+		return "playing_tab_id"
+
+	case "subtest_public_id":
+		return "subtestPublicId"
+
+	case "subtestPublicId":
+		return "subtest_public_id"
+
+	case "with_click":
+		return "withClick"
+
+	case "withClick":
+		return "with_click"
+	}
+
+	return key
+}
+
+// ConvertStepParamsValueForState converts a value of the API params to the value of the `params` block.
+func ConvertStepParamsValueForState(key string, value interface{}) interface{} {
+	switch key {
+	case "element", "email", "file", "files", "request":
+		result, _ := json.Marshal(value)
+		return string(result)
+
+	case "playing_tab_id", "value":
+		return utils.ConvertToString(value)
+
+	case "variable":
+		return []interface{}{value}
+	}
+
+	return value
+}
+
+// BuildTerraformStep converts a browser test step to its local state representation
+func BuildTerraformStep(step datadogV1.SyntheticsStep) map[string]interface{} {
+	localStep := make(map[string]interface{})
+	localStep["name"] = step.GetName()
+	localStep["type"] = string(step.GetType())
+	localStep["timeout"] = step.GetTimeout()
+
+	if allowFailure, ok := step.GetAllowFailureOk(); ok {
+		localStep["allow_failure"] = allowFailure
+	}
+
+	if isCritical, ok := step.GetIsCriticalOk(); ok {
+		localStep["is_critical"] = isCritical
+	}
+	if hasNoScreenshot, ok := step.GetNoScreenshotOk(); ok {
+		localStep["no_screenshot"] = hasNoScreenshot
+	}
+
+	localParams := make(map[string]interface{})
+
+	params := step.GetParams()
+	paramsMap, _ := params.(map[string]interface{})
+
+	for key, value := range paramsMap {
+		localParams[ConvertStepParamsKey(key)] = ConvertStepParamsValueForState(ConvertStepParamsKey(key), value)
+	}
+
+	// If received an element from the backend, extract the user locator part to update the local state
+	if elementParams, ok := paramsMap["element"]; ok {
+		serializedElementParams := ConvertStepParamsValueForState("element", elementParams)
+		var stepElement interface{}
+		utils.GetMetadataFromJSON([]byte(serializedElementParams.(string)), &stepElement)
+		if elementUserLocator, ok := stepElement.(map[string]interface{})["userLocator"]; ok {
+			userLocator := elementUserLocator.(map[string]interface{})
+			values := userLocator["values"]
+			value := values.([]interface{})[0]
+
+			localElementUserLocator := map[string]interface{}{
+				"fail_test_on_cannot_locate": userLocator["failTestOnCannotLocate"],
+				"value": []map[string]interface{}{
+					value.(map[string]interface{}),
+				},
+			}
+
+			localParams["element_user_locator"] = []map[string]interface{}{localElementUserLocator}
+		}
+	}
+
+	localStep["params"] = []interface{}{localParams}
+
+	return localStep
+}
+
+// DecodeStepElement decodes an element locator which is either JSON encoded, or compressed and base64 encoded
+func DecodeStepElement(element string) (interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(element), &decoded); err == nil {
+		return decoded, nil
+	}
+	if err := json.Unmarshal([]byte(utils.DecompressAndDecodeValue(element)), &decoded); err != nil {
+		return nil, fmt.Errorf("element is neither JSON nor a compressed JSON value")
+	}
+	return decoded, nil
+}
@@ -0,0 +1,338 @@
+// Package syntheticsmobile converts the steps of Synthetic mobile application tests between their Terraform
+// representation and the JSON payload of the mobile tests API, which is not modeled by the API client.
+package syntheticsmobile
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// StepTypes are the types of mobile test steps
+var StepTypes = []string{
+	"assertElementContent",
+	"assertScreenContains",
+	"assertScreenDoesNotContain",
+	"doubleTap",
+	"extractVariable",
+	"flick",
+	"openDeeplink",
+	"playSubTest",
+	"pressBack",
+	"restartApplication",
+	"rotate",
+	"scroll",
+	"scrollToElement",
+	"tap",
+	"toggleWiFi",
+	"typeText",
+	"wait",
+}
+
+// paramsKeys are the keys of the `params` block of mobile steps
+var paramsKeys = map[string]bool{
+	"check":             true,
+	"delay":             true,
+	"direction":         true,
+	"element":           true,
+	"enable":            true,
+	"max_scrolls":       true,
+	"subtest_public_id": true,
+	"value":             true,
+	"variable":          true,
+	"with_enter":        true,
+	"x":                 true,
+	"y":                 true,
+}
+
+// ParamsKeysForStepType returns the keys of the `params` block used by a step type.
+func ParamsKeysForStepType(stepType string) []string {
+	switch stepType {
+	case "assertElementContent":
+		return []string{"check", "element", "value"}
+
+	case "assertScreenContains", "assertScreenDoesNotContain", "openDeeplink", "wait":
+		return []string{"value"}
+
+	case "doubleTap", "tap":
+		return []string{"element"}
+
+	case "extractVariable":
+		return []string{"element", "variable"}
+
+	case "flick", "scroll":
+		return []string{"x", "y"}
+
+	case "playSubTest":
+		return []string{"subtest_public_id"}
+
+	case "rotate":
+		return []string{"direction"}
+
+	case "scrollToElement":
+		return []string{"direction", "element", "max_scrolls"}
+
+	case "toggleWiFi":
+		return []string{"enable"}
+
+	case "typeText":
+		return []string{"delay", "element", "value", "with_enter"}
+	}
+
+	return []string{}
+}
+
+// BuildStep converts a `mobile_step` block to its API payload.
+func BuildStep(step map[string]interface{}) map[string]interface{} {
+	stepType, _ := step["type"].(string)
+	result := map[string]interface{}{
+		"name":              step["name"],
+		"type":              stepType,
+		"allowFailure":      step["allow_failure"],
+		"isCritical":        step["is_critical"],
+		"hasNewStepElement": step["has_new_step_element"],
+		"noScreenshot":      step["no_screenshot"],
+	}
+	if timeout, ok := step["timeout"].(int); ok && timeout != 0 {
+		result["timeout"] = timeout
+	}
+	if publicID, ok := step["public_id"].(string); ok && publicID != "" {
+		result["publicId"] = publicID
+	}
+
+	stepParams := make(map[string]interface{})
+	if params, ok := step["params"].([]interface{}); ok && len(params) > 0 && params[0] != nil {
+		stepParams = params[0].(map[string]interface{})
+	}
+	result["params"] = BuildStepParams(stepType, stepParams)
+
+	return result
+}
+
+// BuildTerraformStep converts a step of the API response to a `mobile_step` block.
+func BuildTerraformStep(step map[string]interface{}) map[string]interface{} {
+	localStep := make(map[string]interface{})
+	localStep["name"] = step["name"]
+	localStep["type"] = step["type"]
+	localStep["public_id"] = step["publicId"]
+	if v, ok := step["allowFailure"]; ok {
+		localStep["allow_failure"] = v
+	}
+	if v, ok := step["isCritical"]; ok {
+		localStep["is_critical"] = v
+	}
+	if v, ok := step["timeout"].(float64); ok {
+		localStep["timeout"] = int(v)
+	}
+	if v, ok := step["hasNewStepElement"]; ok {
+		localStep["has_new_step_element"] = v
+	}
+	if v, ok := step["noScreenshot"]; ok {
+		localStep["no_screenshot"] = v
+	}
+
+	params, _ := step["params"].(map[string]interface{})
+	localStep["params"] = []interface{}{BuildTerraformStepParams(params)}
+
+	return localStep
+}
+
+// BuildStepParams converts the `params` block of a step to its API payload, keeping only the keys used by the step
+// type.
+func BuildStepParams(stepType string, stepParams map[string]interface{}) map[string]interface{} {
+	params := make(map[string]interface{})
+
+	for _, key := range ParamsKeysForStepType(stepType) {
+		value := stepParams[key]
+		// params may be omitted or empty, leaving keys unset
+		if value == nil || value == "" {
+			continue
+		}
+		switch key {
+		case "element":
+			if elements, ok := value.([]interface{}); ok && len(elements) > 0 && elements[0] != nil {
+				params["element"] = buildStepElement(elements[0].(map[string]interface{}))
+			}
+		case "variable":
+			if variables, ok := value.([]interface{}); ok && len(variables) > 0 && variables[0] != nil {
+				variable := variables[0].(map[string]interface{})
+				params["variable"] = map[string]interface{}{
+					"name":    variable["name"],
+					"example": variable["example"],
+				}
+			}
+		case "value":
+			// the duration of `wait` steps is a number of seconds
+			s, _ := value.(string)
+			if duration, err := strconv.Atoi(s); err == nil && stepType == "wait" {
+				params["value"] = duration
+			} else {
+				params["value"] = value
+			}
+		default:
+			params[convertParamsKey(key)] = value
+		}
+	}
+
+	return params
+}
+
+// BuildTerraformStepParams converts the params of a step of the API response to a `params` block. Keys unknown to the
+// schema are dropped.
+func BuildTerraformStepParams(params map[string]interface{}) map[string]interface{} {
+	localParams := make(map[string]interface{})
+
+	for key, value := range params {
+		localKey := convertParamsKeyForState(key)
+		switch localKey {
+		case "element":
+			if element, ok := value.(map[string]interface{}); ok {
+				localParams["element"] = []interface{}{buildTerraformStepElement(element)}
+			}
+		case "variable":
+			if variable, ok := value.(map[string]interface{}); ok {
+				localParams["variable"] = []interface{}{map[string]interface{}{
+					"name":    variable["name"],
+					"example": variable["example"],
+				}}
+			}
+		case "delay", "max_scrolls":
+			if v, ok := value.(float64); ok {
+				localParams[localKey] = int(v)
+			}
+		case "value":
+			localParams[localKey] = valueToString(value)
+		default:
+			// keys unknown to the schema can't be stored in state
+			if paramsKeys[localKey] {
+				localParams[localKey] = value
+			}
+		}
+	}
+
+	return localParams
+}
+
+func buildStepElement(element map[string]interface{}) map[string]interface{} {
+	stepElement := make(map[string]interface{})
+
+	for key, jsonKey := range map[string]string{
+		"context":             "context",
+		"context_type":        "contextType",
+		"element_description": "elementDescription",
+		"text_content":        "textContent",
+		"view_name":           "viewName",
+	} {
+		if v, ok := element[key].(string); ok && v != "" {
+			stepElement[jsonKey] = v
+		}
+	}
+	if v, ok := element["multi_locator"].(map[string]interface{}); ok && len(v) > 0 {
+		stepElement["multiLocator"] = v
+	}
+	if positions, ok := element["relative_position"].([]interface{}); ok && len(positions) > 0 && positions[0] != nil {
+		position := positions[0].(map[string]interface{})
+		stepElement["relativePosition"] = map[string]interface{}{
+			"x": position["x"],
+			"y": position["y"],
+		}
+	}
+	if userLocators, ok := element["user_locator"].([]interface{}); ok && len(userLocators) > 0 && userLocators[0] != nil {
+		userLocator := userLocators[0].(map[string]interface{})
+		values := []map[string]interface{}{}
+		rawValues, _ := userLocator["values"].([]interface{})
+		for _, v := range rawValues {
+			value := v.(map[string]interface{})
+			values = append(values, map[string]interface{}{
+				"type":  value["type"],
+				"value": value["value"],
+			})
+		}
+		stepElement["userLocator"] = map[string]interface{}{
+			"failTestOnCannotLocate": userLocator["fail_test_on_cannot_locate"],
+			"values":                 values,
+		}
+	}
+
+	return stepElement
+}
+
+func buildTerraformStepElement(element map[string]interface{}) map[string]interface{} {
+	localElement := make(map[string]interface{})
+
+	for jsonKey, key := range map[string]string{
+		"context":            "context",
+		"contextType":        "context_type",
+		"elementDescription": "element_description",
+		"textContent":        "text_content",
+		"viewName":           "view_name",
+		"multiLocator":       "multi_locator",
+	} {
+		if v, ok := element[jsonKey]; ok {
+			localElement[key] = v
+		}
+	}
+	if position, ok := element["relativePosition"].(map[string]interface{}); ok {
+		localElement["relative_position"] = []interface{}{map[string]interface{}{
+			"x": position["x"],
+			"y": position["y"],
+		}}
+	}
+	if userLocator, ok := element["userLocator"].(map[string]interface{}); ok {
+		values := []interface{}{}
+		rawValues, _ := userLocator["values"].([]interface{})
+		for _, v := range rawValues {
+			value := v.(map[string]interface{})
+			values = append(values, map[string]interface{}{
+				"type":  value["type"],
+				"value": value["value"],
+			})
+		}
+		localElement["user_locator"] = []interface{}{map[string]interface{}{
+			"fail_test_on_cannot_locate": userLocator["failTestOnCannotLocate"],
+			"values":                     values,
+		}}
+	}
+
+	return localElement
+}
+
+func convertParamsKey(key string) string {
+	switch key {
+	case "max_scrolls":
+		return "maxScrolls"
+	case "subtest_public_id":
+		return "subtestPublicId"
+	case "with_enter":
+		return "withEnter"
+	}
+	return key
+}
+
+func convertParamsKeyForState(key string) string {
+	switch key {
+	case "maxScrolls":
+		return "max_scrolls"
+	case "subtestPublicId":
+		return "subtest_public_id"
+	case "withEnter":
+		return "with_enter"
+	}
+	return key
+}
+
+func valueToString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
@@ -0,0 +1,285 @@
+package syntheticsmobile
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// stepParams returns a `params` block as read from the configuration, where unset attributes have their zero value.
+func stepParams(values map[string]interface{}) []interface{} {
+	params := map[string]interface{}{
+		"check":             "",
+		"delay":             0,
+		"direction":         "",
+		"element":           []interface{}{},
+		"enable":            false,
+		"max_scrolls":       0,
+		"subtest_public_id": "",
+		"value":             "",
+		"variable":          []interface{}{},
+		"with_enter":        false,
+		"x":                 0.0,
+		"y":                 0.0,
+	}
+	for k, v := range values {
+		params[k] = v
+	}
+	return []interface{}{params}
+}
+
+var testElement = []interface{}{map[string]interface{}{
+	"context":             "NATIVE_APP",
+	"context_type":        "native",
+	"element_description": `<XCUIElementTypeButton name="Login">`,
+	"multi_locator":       map[string]interface{}{"ab": "//XCUIElementTypeButton[@name=\"Login\"]"},
+	"relative_position":   []interface{}{map[string]interface{}{"x": 0.5, "y": 0.25}},
+	"text_content":        "Login",
+	"user_locator": []interface{}{map[string]interface{}{
+		"fail_test_on_cannot_locate": true,
+		"values":                     []interface{}{map[string]interface{}{"type": "id", "value": "login"}},
+	}},
+	"view_name": "XCUIElementTypeButton",
+}}
+
+const testElementPayload = `{
+	"context": "NATIVE_APP",
+	"contextType": "native",
+	"elementDescription": "<XCUIElementTypeButton name=\"Login\">",
+	"multiLocator": {"ab": "//XCUIElementTypeButton[@name=\"Login\"]"},
+	"relativePosition": {"x": 0.5, "y": 0.25},
+	"textContent": "Login",
+	"userLocator": {"failTestOnCannotLocate": true, "values": [{"type": "id", "value": "login"}]},
+	"viewName": "XCUIElementTypeButton"
+}`
+
+// testSparseElement only sets some attributes of the element
+var testSparseElement = []interface{}{map[string]interface{}{
+	"context":             "",
+	"context_type":        "",
+	"element_description": "",
+	"multi_locator":       map[string]interface{}{},
+	"relative_position":   []interface{}{},
+	"text_content":        "",
+	"user_locator":        []interface{}{},
+	"view_name":           "XCUIElementTypeButton",
+}}
+
+func TestBuildStepParams(t *testing.T) {
+	cases := map[string]struct {
+		params map[string]interface{}
+		// payload is the JSON of the params sent to the API
+		payload string
+		// state is the JSON of the `params` block read back from the API
+		state string
+	}{
+		"assertElementContent": {
+			params:  map[string]interface{}{"check": "contains", "element": testElement, "value": "Welcome", "x": 3.0},
+			payload: `{"check": "contains", "element": ` + testElementPayload + `, "value": "Welcome"}`,
+			state:   `{"check": "contains", "element": [{"context": "NATIVE_APP", "context_type": "native", "element_description": "<XCUIElementTypeButton name=\"Login\">", "multi_locator": {"ab": "//XCUIElementTypeButton[@name=\"Login\"]"}, "relative_position": [{"x": 0.5, "y": 0.25}], "text_content": "Login", "user_locator": [{"fail_test_on_cannot_locate": true, "values": [{"type": "id", "value": "login"}]}], "view_name": "XCUIElementTypeButton"}], "value": "Welcome"}`,
+		},
+		"assertScreenContains": {
+			params:  map[string]interface{}{"value": "Welcome"},
+			payload: `{"value": "Welcome"}`,
+			state:   `{"value": "Welcome"}`,
+		},
+		"assertScreenDoesNotContain": {
+			params:  map[string]interface{}{"value": "Error"},
+			payload: `{"value": "Error"}`,
+			state:   `{"value": "Error"}`,
+		},
+		"doubleTap": {
+			params:  map[string]interface{}{"element": testSparseElement},
+			payload: `{"element": {"viewName": "XCUIElementTypeButton"}}`,
+			state:   `{"element": [{"view_name": "XCUIElementTypeButton"}]}`,
+		},
+		"extractVariable": {
+			params: map[string]interface{}{
+				"element":  testSparseElement,
+				"variable": []interface{}{map[string]interface{}{"name": "USERNAME", "example": "john"}},
+			},
+			payload: `{"element": {"viewName": "XCUIElementTypeButton"}, "variable": {"name": "USERNAME", "example": "john"}}`,
+			state:   `{"element": [{"view_name": "XCUIElementTypeButton"}], "variable": [{"name": "USERNAME", "example": "john"}]}`,
+		},
+		"flick": {
+			params:  map[string]interface{}{"x": 10.0, "y": -200.5},
+			payload: `{"x": 10, "y": -200.5}`,
+			state:   `{"x": 10, "y": -200.5}`,
+		},
+		"openDeeplink": {
+			params:  map[string]interface{}{"value": "app://home"},
+			payload: `{"value": "app://home"}`,
+			state:   `{"value": "app://home"}`,
+		},
+		"playSubTest": {
+			params:  map[string]interface{}{"subtest_public_id": "abc-def-ghi"},
+			payload: `{"subtestPublicId": "abc-def-ghi"}`,
+			state:   `{"subtest_public_id": "abc-def-ghi"}`,
+		},
+		"pressBack": {
+			params:  map[string]interface{}{"value": "ignored"},
+			payload: `{}`,
+			state:   `{}`,
+		},
+		"restartApplication": {
+			params:  map[string]interface{}{},
+			payload: `{}`,
+			state:   `{}`,
+		},
+		"rotate": {
+			params:  map[string]interface{}{"direction": "landscape"},
+			payload: `{"direction": "landscape"}`,
+			state:   `{"direction": "landscape"}`,
+		},
+		"scroll": {
+			params:  map[string]interface{}{"y": 300.0},
+			payload: `{"x": 0, "y": 300}`,
+			state:   `{"x": 0, "y": 300}`,
+		},
+		"scrollToElement": {
+			params:  map[string]interface{}{"direction": "down", "element": testSparseElement, "max_scrolls": 5},
+			payload: `{"direction": "down", "element": {"viewName": "XCUIElementTypeButton"}, "maxScrolls": 5}`,
+			state:   `{"direction": "down", "element": [{"view_name": "XCUIElementTypeButton"}], "max_scrolls": 5}`,
+		},
+		"tap": {
+			params:  map[string]interface{}{"element": testElement},
+			payload: `{"element": ` + testElementPayload + `}`,
+			state:   `{"element": [{"context": "NATIVE_APP", "context_type": "native", "element_description": "<XCUIElementTypeButton name=\"Login\">", "multi_locator": {"ab": "//XCUIElementTypeButton[@name=\"Login\"]"}, "relative_position": [{"x": 0.5, "y": 0.25}], "text_content": "Login", "user_locator": [{"fail_test_on_cannot_locate": true, "values": [{"type": "id", "value": "login"}]}], "view_name": "XCUIElementTypeButton"}]}`,
+		},
+		"toggleWiFi": {
+			params:  map[string]interface{}{},
+			payload: `{"enable": false}`,
+			state:   `{"enable": false}`,
+		},
+		"typeText": {
+			params:  map[string]interface{}{"delay": 100, "element": testSparseElement, "value": "john", "with_enter": true},
+			payload: `{"delay": 100, "element": {"viewName": "XCUIElementTypeButton"}, "value": "john", "withEnter": true}`,
+			state:   `{"delay": 100, "element": [{"view_name": "XCUIElementTypeButton"}], "value": "john", "with_enter": true}`,
+		},
+		"wait": {
+			params:  map[string]interface{}{"value": "5"},
+			payload: `{"value": 5}`,
+			state:   `{"value": "5"}`,
+		},
+	}
+
+	for _, stepType := range StepTypes {
+		if _, ok := cases[stepType]; !ok {
+			t.Errorf("missing test case for step type %s", stepType)
+		}
+	}
+
+	for stepType, tc := range cases {
+		params := BuildStepParams(stepType, stepParams(tc.params)[0].(map[string]interface{}))
+		assertJSONEqual(t, stepType+" payload", params, tc.payload)
+
+		// the API returns the params as JSON
+		var response map[string]interface{}
+		b, _ := json.Marshal(params)
+		if err := json.Unmarshal(b, &response); err != nil {
+			t.Fatalf("%s: %v", stepType, err)
+		}
+		assertJSONEqual(t, stepType+" state", BuildTerraformStepParams(response), tc.state)
+	}
+}
+
+func TestBuildStep(t *testing.T) {
+	step := map[string]interface{}{
+		"name":                 "Tap on login",
+		"type":                 "tap",
+		"allow_failure":        true,
+		"is_critical":          false,
+		"timeout":              30,
+		"has_new_step_element": false,
+		"no_screenshot":        true,
+		"public_id":            "pub-lic-id0",
+		"params":               stepParams(map[string]interface{}{"element": testSparseElement}),
+	}
+	payload := BuildStep(step)
+	assertJSONEqual(t, "payload", payload, `{
+		"name": "Tap on login",
+		"type": "tap",
+		"allowFailure": true,
+		"isCritical": false,
+		"timeout": 30,
+		"hasNewStepElement": false,
+		"noScreenshot": true,
+		"publicId": "pub-lic-id0",
+		"params": {"element": {"viewName": "XCUIElementTypeButton"}}
+	}`)
+
+	var response map[string]interface{}
+	b, _ := json.Marshal(payload)
+	if err := json.Unmarshal(b, &response); err != nil {
+		t.Fatal(err)
+	}
+	assertJSONEqual(t, "state", BuildTerraformStep(response), `{
+		"name": "Tap on login",
+		"type": "tap",
+		"allow_failure": true,
+		"is_critical": false,
+		"timeout": 30,
+		"has_new_step_element": false,
+		"no_screenshot": true,
+		"public_id": "pub-lic-id0",
+		"params": [{"element": [{"view_name": "XCUIElementTypeButton"}]}]
+	}`)
+}
+
+func TestBuildStepWithoutParams(t *testing.T) {
+	for name, params := range map[string]interface{}{
+		"missing": nil,
+		"empty":   []interface{}{},
+		"nil":     []interface{}{nil},
+	} {
+		step := map[string]interface{}{"name": "Go back", "type": "pressBack", "timeout": 0}
+		if params != nil {
+			step["params"] = params
+		}
+		assertJSONEqual(t, name, BuildStep(step), `{
+			"name": "Go back",
+			"type": "pressBack",
+			"allowFailure": null,
+			"isCritical": null,
+			"hasNewStepElement": null,
+			"noScreenshot": null,
+			"params": {}
+		}`)
+	}
+
+	// steps created outside of Terraform may have no params
+	assertJSONEqual(t, "response", BuildTerraformStep(map[string]interface{}{"name": "Go back", "type": "pressBack", "publicId": "pub-lic-id0"}), `{
+		"name": "Go back",
+		"type": "pressBack",
+		"public_id": "pub-lic-id0",
+		"params": [{}]
+	}`)
+}
+
+func TestBuildTerraformStepParamsUnknownKeys(t *testing.T) {
+	params := BuildTerraformStepParams(map[string]interface{}{
+		"value":        3.0,
+		"maxScrolls":   2.0,
+		"withEnter":    false,
+		"newParameter": "unknown to the schema",
+	})
+	assertJSONEqual(t, "state", params, `{"value": "3", "max_scrolls": 2, "with_enter": false}`)
+}
+
+func assertJSONEqual(t *testing.T, name string, actual interface{}, expected string) {
+	t.Helper()
+	var expectedValue, actualValue interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("%s: invalid expected JSON: %v", name, err)
+	}
+	b, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if err := json.Unmarshal(b, &actualValue); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if !reflect.DeepEqual(expectedValue, actualValue) {
+		t.Errorf("%s: expected %s, got %s", name, expected, b)
+	}
+}
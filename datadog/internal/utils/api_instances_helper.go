@@ -77,6 +77,7 @@ type ApiInstances struct {
 	securityMonitoringApiV2     *datadogV2.SecurityMonitoringApi
 	sensitiveDataScannerApiV2   *datadogV2.SensitiveDataScannerApi
 	serviceAccountsApiV2        *datadogV2.ServiceAccountsApi
+	spansApiV2                  *datadogV2.SpansApi
 	spansMetricsApiV2           *datadogV2.SpansMetricsApi
 	syntheticsApiV2             *datadogV2.SyntheticsApi
 	teamsApiV2                  *datadogV2.TeamsApi
@@ -604,6 +605,14 @@ func (i *ApiInstances) GetTeamsApiV2() *datadogV2.TeamsApi {
 	return i.teamsApiV2
 }
 
+// GetSpansApiV2 get instance of SpansApi
+func (i *ApiInstances) GetSpansApiV2() *datadogV2.SpansApi {
+	if i.spansApiV2 == nil {
+		i.spansApiV2 = datadogV2.NewSpansApi(i.HttpClient)
+	}
+	return i.spansApiV2
+}
+
 // GetSpansMetricsApiV2 get instance of SpansMetricsApi
 func (i *ApiInstances) GetSpansMetricsApiV2() *datadogV2.SpansMetricsApi {
 	if i.spansMetricsApiV2 == nil {
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+)
+
+// LogsRetentionMonthDays is the number of days of the months used to project the monthly volume of logs indexes.
+const LogsRetentionMonthDays = 30
+
+// LogsIndexUsage is the volume of logs indexed by an index over a number of days.
+type LogsIndexUsage struct {
+	Events          int64
+	PeakDailyEvents int64
+	Days            int
+}
+
+// AverageDailyEvents returns the average number of events indexed per day.
+func (u *LogsIndexUsage) AverageDailyEvents() float64 {
+	if u.Days == 0 {
+		return 0
+	}
+	return float64(u.Events) / float64(u.Days)
+}
+
+// GetLogsIndexUsage returns the volume of logs indexed by each index during the last complete days, by index name.
+// Indexes without usage are not part of the result.
+func GetLogsIndexUsage(ctx context.Context, api *datadogV1.UsageMeteringApi, indexNames []string, now time.Time, days int) (map[string]*LogsIndexUsage, *http.Response, error) {
+	end := now.UTC().Truncate(24 * time.Hour)
+	start := end.AddDate(0, 0, -days)
+	optionalParams := datadogV1.NewGetUsageLogsByIndexOptionalParameters().WithEndHr(end)
+	if len(indexNames) > 0 {
+		optionalParams = optionalParams.WithIndexName(indexNames)
+	}
+	usage, httpResp, err := api.GetUsageLogsByIndex(ctx, start, *optionalParams)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	return AggregateLogsIndexUsage(usage.GetUsage(), days), httpResp, nil
+}
+
+// AggregateLogsIndexUsage aggregates the hourly usage of logs indexes over a number of days, by index name.
+func AggregateLogsIndexUsage(hours []datadogV1.UsageLogsByIndexHour, days int) map[string]*LogsIndexUsage {
+	usages := make(map[string]*LogsIndexUsage)
+	dailyEvents := make(map[string]map[string]int64)
+	for _, hour := range hours {
+		name := hour.GetIndexName()
+		if _, ok := usages[name]; !ok {
+			usages[name] = &LogsIndexUsage{Days: days}
+			dailyEvents[name] = make(map[string]int64)
+		}
+		usages[name].Events += hour.GetEventCount()
+		dailyEvents[name][hour.GetHour().UTC().Format("2006-01-02")] += hour.GetEventCount()
+	}
+	for name, events := range dailyEvents {
+		for _, dayEvents := range events {
+			if dayEvents > usages[name].PeakDailyEvents {
+				usages[name].PeakDailyEvents = dayEvents
+			}
+		}
+	}
+	return usages
+}
+
+// ProjectLogsIndexMonthlyEvents projects the number of events indexed per month from the average daily volume,
+// capped by the daily limit of the index when set.
+func ProjectLogsIndexMonthlyEvents(averageDailyEvents float64, dailyLimit *int64) float64 {
+	if dailyLimit != nil && float64(*dailyLimit) < averageDailyEvents {
+		averageDailyEvents = float64(*dailyLimit)
+	}
+	return averageDailyEvents * LogsRetentionMonthDays
+}
+
+// LogsRetentionMonthlyCost returns the cost of indexing events for a month, with prices per million events keyed
+// by number of retention days.
+func LogsRetentionMonthlyCost(monthlyEvents float64, retentionDays int64, pricesPerMillionEvents map[string]float64) (float64, error) {
+	price, ok := pricesPerMillionEvents[strconv.FormatInt(retentionDays, 10)]
+	if !ok {
+		return 0, fmt.Errorf("no price per million events for a retention of %d days", retentionDays)
+	}
+	return monthlyEvents / 1e6 * price, nil
+}
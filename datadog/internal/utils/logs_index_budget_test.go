@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+)
+
+func TestAggregateLogsIndexUsage(t *testing.T) {
+	hour := func(name string, at string, events int64) datadogV1.UsageLogsByIndexHour {
+		date, _ := time.Parse(time.RFC3339, at)
+		return datadogV1.UsageLogsByIndexHour{IndexName: &name, Hour: &date, EventCount: &events}
+	}
+	usages := AggregateLogsIndexUsage([]datadogV1.UsageLogsByIndexHour{
+		hour("main", "2024-01-01T00:00:00Z", 100),
+		hour("main", "2024-01-01T23:00:00Z", 200),
+		hour("main", "2024-01-02T10:00:00Z", 250),
+		hour("debug", "2024-01-02T10:00:00Z", 10),
+	}, 7)
+
+	main := usages["main"]
+	if main == nil || main.Events != 550 || main.PeakDailyEvents != 300 || main.Days != 7 {
+		t.Fatalf("unexpected usage of main index: %+v", main)
+	}
+	if average := main.AverageDailyEvents(); average != 550.0/7 {
+		t.Errorf("expected an average of %f daily events, got %f", 550.0/7, average)
+	}
+	if debug := usages["debug"]; debug == nil || debug.Events != 10 || debug.PeakDailyEvents != 10 {
+		t.Errorf("unexpected usage of debug index: %+v", debug)
+	}
+}
+
+func TestLogsRetentionMonthlyCost(t *testing.T) {
+	limit := int64(1000000)
+	prices := map[string]float64{"15": 1.7, "30": 2.5}
+	cases := map[string]struct {
+		averageDailyEvents float64
+		dailyLimit         *int64
+		retentionDays      int64
+		cost               float64
+		valid              bool
+	}{
+		"no limit":           {2000000, nil, 15, 102, true},
+		"under limit":        {500000, &limit, 30, 37.5, true},
+		"capped by limit":    {2000000, &limit, 30, 75, true},
+		"unknown retention":  {2000000, nil, 7, 0, false},
+		"no events":          {0, nil, 15, 0, true},
+		"limit without data": {0, &limit, 15, 0, true},
+	}
+	for name, tc := range cases {
+		cost, err := LogsRetentionMonthlyCost(ProjectLogsIndexMonthlyEvents(tc.averageDailyEvents, tc.dailyLimit), tc.retentionDays, prices)
+		if tc.valid != (err == nil) {
+			t.Errorf("%s: expected valid %t, got error %v", name, tc.valid, err)
+			continue
+		}
+		if diff := cost - tc.cost; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s: expected a cost of %f, got %f", name, tc.cost, cost)
+		}
+	}
+}
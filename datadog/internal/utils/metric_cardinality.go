@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+)
+
+// GetLogsDistinctValues returns the number of distinct values of each path in the logs matching a query during the
+// last hours. Only indexed logs are searched.
+func GetLogsDistinctValues(ctx context.Context, api *datadogV2.LogsApi, query string, paths []string, hours int) (map[string]int64, *http.Response, error) {
+	body := datadogV2.LogsAggregateRequest{
+		Filter: &datadogV2.LogsQueryFilter{
+			Query: datadog.PtrString(query),
+			From:  datadog.PtrString(fmt.Sprintf("now-%dh", hours)),
+			To:    datadog.PtrString("now"),
+		},
+	}
+	for _, path := range paths {
+		body.Compute = append(body.Compute, datadogV2.LogsCompute{
+			Aggregation: datadogV2.LOGSAGGREGATIONFUNCTION_CARDINALITY,
+			Metric:      datadog.PtrString(path),
+			Type:        datadogV2.LOGSCOMPUTETYPE_TOTAL.Ptr(),
+		})
+	}
+	resp, httpResp, err := api.AggregateLogs(ctx, body)
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	values := make(map[string]*float64)
+	data := resp.GetData()
+	if buckets := data.GetBuckets(); len(buckets) > 0 {
+		for key, value := range buckets[0].GetComputes() {
+			values[key] = value.Float64
+		}
+	}
+	return distinctValuesFromComputes(paths, values), httpResp, nil
+}
+
+// GetSpansDistinctValues returns the number of distinct values of each path in the spans matching a query during the
+// last hours. Only indexed spans are searched.
+func GetSpansDistinctValues(ctx context.Context, api *datadogV2.SpansApi, query string, paths []string, hours int) (map[string]int64, *http.Response, error) {
+	attributes := datadogV2.SpansAggregateRequestAttributes{
+		Filter: &datadogV2.SpansQueryFilter{
+			Query: datadog.PtrString(query),
+			From:  datadog.PtrString(fmt.Sprintf("now-%dh", hours)),
+			To:    datadog.PtrString("now"),
+		},
+	}
+	for _, path := range paths {
+		attributes.Compute = append(attributes.Compute, datadogV2.SpansCompute{
+			Aggregation: datadogV2.SPANSAGGREGATIONFUNCTION_CARDINALITY,
+			Metric:      datadog.PtrString(path),
+			Type:        datadogV2.SPANSCOMPUTETYPE_TOTAL.Ptr(),
+		})
+	}
+	body := datadogV2.SpansAggregateRequest{
+		Data: &datadogV2.SpansAggregateData{
+			Attributes: &attributes,
+			Type:       datadogV2.SPANSAGGREGATEREQUESTTYPE_AGGREGATE_REQUEST.Ptr(),
+		},
+	}
+	resp, httpResp, err := api.AggregateSpans(ctx, body)
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	values := make(map[string]*float64)
+	if buckets := resp.GetData(); len(buckets) > 0 {
+		bucketAttributes := buckets[0].GetAttributes()
+		for key, value := range bucketAttributes.GetComputes() {
+			values[key] = value.Float64
+		}
+	}
+	return distinctValuesFromComputes(paths, values), httpResp, nil
+}
+
+// distinctValuesFromComputes maps the values of the computes of an aggregation, keyed `c0`, `c1`, ... in the order of
+// the paths, to the paths. Paths without value have no distinct value.
+func distinctValuesFromComputes(paths []string, values map[string]*float64) map[string]int64 {
+	distinctValues := make(map[string]int64, len(paths))
+	for i, path := range paths {
+		distinctValues[path] = 0
+		if value := values[fmt.Sprintf("c%d", i)]; value != nil {
+			distinctValues[path] = int64(*value)
+		}
+	}
+	return distinctValues
+}
+
+// ProjectMetricCardinality returns the maximum number of series of a metric grouped by paths with the given numbers of
+// distinct values, which is the product of the numbers of distinct values. Paths without value are tagged `N/A` and
+// count as one value.
+func ProjectMetricCardinality(distinctValues map[string]int64) int64 {
+	cardinality := int64(1)
+	for _, values := range distinctValues {
+		if values < 1 {
+			values = 1
+		}
+		if cardinality > math.MaxInt64/values {
+			return math.MaxInt64
+		}
+		cardinality *= values
+	}
+	return cardinality
+}
+
+// CheckMetricCardinality returns an error describing the numbers of distinct values per path when the projected
+// cardinality of a metric exceeds the limit.
+func CheckMetricCardinality(distinctValues map[string]int64, limit int64) error {
+	cardinality := ProjectMetricCardinality(distinctValues)
+	if cardinality <= limit {
+		return nil
+	}
+	paths := make([]string, 0, len(distinctValues))
+	for path := range distinctValues {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	details := make([]string, 0, len(paths))
+	for _, path := range paths {
+		details = append(details, fmt.Sprintf("%s: %d", path, distinctValues[path]))
+	}
+	return fmt.Errorf("projected cardinality of %d series exceeds the limit of %d (distinct values by path: %s)", cardinality, limit, strings.Join(details, ", "))
+}
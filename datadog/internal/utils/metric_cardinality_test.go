@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistinctValuesFromComputes(t *testing.T) {
+	first, second := 12.0, 3.0
+	distinctValues := distinctValuesFromComputes([]string{"@usr.id", "service", "@missing"}, map[string]*float64{"c0": &first, "c1": &second})
+	expected := map[string]int64{"@usr.id": 12, "service": 3, "@missing": 0}
+	for path, values := range expected {
+		if distinctValues[path] != values {
+			t.Errorf("expected %d distinct values for %s, got %d", values, path, distinctValues[path])
+		}
+	}
+}
+
+func TestProjectMetricCardinality(t *testing.T) {
+	cases := map[string]struct {
+		distinctValues map[string]int64
+		cardinality    int64
+	}{
+		"no group by":       {map[string]int64{}, 1},
+		"single path":       {map[string]int64{"service": 12}, 12},
+		"product of paths":  {map[string]int64{"service": 12, "@http.status_code": 5}, 60},
+		"path without data": {map[string]int64{"service": 12, "@missing": 0}, 12},
+		"overflow":          {map[string]int64{"a": math.MaxInt64 / 2, "b": 3}, math.MaxInt64},
+	}
+	for name, tc := range cases {
+		if cardinality := ProjectMetricCardinality(tc.distinctValues); cardinality != tc.cardinality {
+			t.Errorf("%s: expected a cardinality of %d, got %d", name, tc.cardinality, cardinality)
+		}
+	}
+}
+
+func TestCheckMetricCardinality(t *testing.T) {
+	distinctValues := map[string]int64{"service": 12, "@usr.id": 50000}
+	if err := CheckMetricCardinality(distinctValues, 1000000); err != nil {
+		t.Errorf("expected no error under the limit, got %v", err)
+	}
+	err := CheckMetricCardinality(distinctValues, 1000)
+	if err == nil {
+		t.Fatal("expected an error above the limit")
+	}
+	expected := "projected cardinality of 600000 series exceeds the limit of 1000 (distinct values by path: @usr.id: 50000, service: 12)"
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+}
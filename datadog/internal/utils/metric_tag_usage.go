@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+)
+
+// MetricTagKeys returns the sorted distinct keys of `key:value` tags. Tags without value are their own key.
+func MetricTagKeys(tags []string) []string {
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		key, _, _ := strings.Cut(tag, ":")
+		if key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// WithoutMetricTagKey returns the tag keys except one.
+func WithoutMetricTagKey(keys []string, key string) []string {
+	return slices.DeleteFunc(slices.Clone(keys), func(k string) bool {
+		return k == key
+	})
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMetricTagKeys(t *testing.T) {
+	cases := map[string]struct {
+		tags []string
+		keys []string
+	}{
+		"no tags":           {nil, []string{}},
+		"key value tags":    {[]string{"env:prod", "service:web", "env:staging"}, []string{"env", "service"}},
+		"tags without key":  {[]string{"canary", "env:prod", ":orphan"}, []string{"canary", "env"}},
+		"values with colon": {[]string{"url:http://example.com", "url:https://example.com"}, []string{"url"}},
+	}
+	for name, tc := range cases {
+		if keys := MetricTagKeys(tc.tags); !slices.Equal(keys, tc.keys) {
+			t.Errorf("%s: expected keys %v, got %v", name, tc.keys, keys)
+		}
+	}
+}
+
+func TestWithoutMetricTagKey(t *testing.T) {
+	keys := []string{"env", "host", "service"}
+	if without := WithoutMetricTagKey(keys, "host"); !slices.Equal(without, []string{"env", "service"}) {
+		t.Errorf("unexpected keys without host: %v", without)
+	}
+	if !slices.Equal(keys, []string{"env", "host", "service"}) {
+		t.Errorf("the keys were modified: %v", keys)
+	}
+}
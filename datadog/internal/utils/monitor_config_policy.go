@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MonitorTagPolicy is a monitor config policy of type `tag`.
+type MonitorTagPolicy struct {
+	ID             string
+	TagKey         string
+	TagKeyRequired bool
+	ValidTagValues []string
+}
+
+// CheckMonitorTagPolicies returns a description of every violation of the given tag policies by a monitor's tags.
+// Tags are `key:value` pairs; a tag whose key is governed by a policy must have one of the policy's valid values,
+// and the key must be present when the policy requires it.
+func CheckMonitorTagPolicies(tags []string, policies []MonitorTagPolicy) []string {
+	var violations []string
+	for _, policy := range policies {
+		found := false
+		for _, tag := range tags {
+			key, value, hasValue := strings.Cut(tag, ":")
+			if key != policy.TagKey {
+				continue
+			}
+			found = true
+			if !hasValue || !slices.Contains(policy.ValidTagValues, value) {
+				violations = append(violations, fmt.Sprintf("tag %q is not allowed by monitor config policy %s, valid values for %q are: %s",
+					tag, policy.ID, policy.TagKey, strings.Join(policy.ValidTagValues, ", ")))
+			}
+		}
+		if !found && policy.TagKeyRequired {
+			violations = append(violations, fmt.Sprintf("tag key %q is required by monitor config policy %s, valid values are: %s",
+				policy.TagKey, policy.ID, strings.Join(policy.ValidTagValues, ", ")))
+		}
+	}
+	return violations
+}
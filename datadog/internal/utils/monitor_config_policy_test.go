@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestCheckMonitorTagPolicies(t *testing.T) {
+	policies := []MonitorTagPolicy{
+		{ID: "env-policy", TagKey: "env", TagKeyRequired: true, ValidTagValues: []string{"prod", "staging"}},
+		{ID: "team-policy", TagKey: "team", TagKeyRequired: false, ValidTagValues: []string{"sre"}},
+	}
+	cases := map[string]struct {
+		tags       []string
+		violations int
+	}{
+		"valid":                  {[]string{"env:prod", "team:sre", "foo:bar"}, 0},
+		"optional key missing":   {[]string{"env:staging"}, 0},
+		"required key missing":   {[]string{"team:sre"}, 1},
+		"invalid value":          {[]string{"env:dev"}, 1},
+		"key without value":      {[]string{"env", "team"}, 2},
+		"one valid, one invalid": {[]string{"env:prod", "env:dev"}, 1},
+		"no tags":                {nil, 1},
+	}
+	for name, tc := range cases {
+		violations := CheckMonitorTagPolicies(tc.tags, policies)
+		if len(violations) != tc.violations {
+			t.Errorf("%s: expected %d violations, got %v", name, tc.violations, violations)
+		}
+	}
+}
@@ -2,13 +2,17 @@ package utils
 
 import (
 	"bytes"
+	"compress/zlib"
 	"crypto/sha256"
+	b64 "encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -195,6 +199,50 @@ func ConvertToSha256(content string) string {
 	return fmt.Sprintf("%x", hash[:])
 }
 
+// ConvertToString converts a decoded JSON value to a string, encoding objects and arrays as JSON
+func ConvertToString(i interface{}) string {
+	switch v := i.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		// TODO: manage target for JSON body assertions
+		valStrr, err := json.Marshal(v)
+		if err == nil {
+			return string(valStrr)
+		}
+		return ""
+	}
+}
+
+// CompressAndEncodeValue compresses a value with zlib and encodes it in base64
+func CompressAndEncodeValue(value string) string {
+	var compressedValue bytes.Buffer
+	zl := zlib.NewWriter(&compressedValue)
+	zl.Write([]byte(value))
+	zl.Close()
+	encodedCompressedValue := b64.StdEncoding.EncodeToString(compressedValue.Bytes())
+	return encodedCompressedValue
+}
+
+// DecompressAndDecodeValue decodes a base64 value and decompresses it with zlib, returning an empty string on error
+func DecompressAndDecodeValue(value string) string {
+	decodedValue, _ := b64.StdEncoding.DecodeString(value)
+	decodedBytes := bytes.NewReader(decodedValue)
+	zl, err := zlib.NewReader(decodedBytes)
+	if err != nil {
+		return ""
+	}
+	defer zl.Close()
+	compressedProtoFile, _ := io.ReadAll(zl)
+	return string(compressedProtoFile)
+}
+
 // AccountAndNamespaceFromID returns account and namespace from an ID
 func AccountAndNamespaceFromID(id string) (string, string, error) {
 	result := strings.SplitN(id, ":", 2)
@@ -0,0 +1,61 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/logsquery"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ValidateLogsQuery ensures a string is a valid log search query.
+func ValidateLogsQuery() schema.SchemaValidateDiagFunc {
+	return func(val interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := val.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid value type",
+				Detail:        "Field value must be of type string",
+				AttributePath: path,
+			}}
+		}
+		if _, err := logsquery.Parse(value); err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid logs query",
+				Detail:        err.Error(),
+				AttributePath: path,
+			}}
+		}
+		return nil
+	}
+}
+
+type logsQueryValidator struct{}
+
+func (v logsQueryValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v logsQueryValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a valid log search query"
+}
+
+func (v logsQueryValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := logsquery.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, fmt.Sprintf("invalid logs query for \"%s\"", req.Path.String()), err.Error())
+	}
+}
+
+// LogsQueryValidator is the framework counterpart of ValidateLogsQuery.
+func LogsQueryValidator() validator.String {
+	return logsQueryValidator{}
+}
@@ -0,0 +1,79 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/rrule"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ValidateRRule ensures a string is a valid RFC 5545 recurrence rule. When allowedParts is not empty, only
+// those rule parts are accepted.
+func ValidateRRule(allowedParts ...string) schema.SchemaValidateDiagFunc {
+	return func(val interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := val.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid value type",
+				Detail:        "Field value must be of type string",
+				AttributePath: path,
+			}}
+		}
+		if err := checkRRule(value, allowedParts); err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid rrule",
+				Detail:        err.Error(),
+				AttributePath: path,
+			}}
+		}
+		return nil
+	}
+}
+
+type rruleValidator struct {
+	allowedParts []string
+}
+
+func (v rruleValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v rruleValidator) MarkdownDescription(_ context.Context) string {
+	if len(v.allowedParts) > 0 {
+		return fmt.Sprintf("value must be a valid RFC 5545 recurrence rule using only %s", strings.Join(v.allowedParts, ", "))
+	}
+	return "value must be a valid RFC 5545 recurrence rule"
+}
+
+func (v rruleValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := checkRRule(req.ConfigValue.ValueString(), v.allowedParts); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, fmt.Sprintf("invalid rrule for \"%s\"", req.Path.String()), err.Error())
+	}
+}
+
+// RRuleValidator is the framework counterpart of ValidateRRule.
+func RRuleValidator(allowedParts ...string) validator.String {
+	return rruleValidator{allowedParts}
+}
+
+func checkRRule(value string, allowedParts []string) error {
+	r, err := rrule.Parse(value)
+	if err != nil {
+		return err
+	}
+	if len(allowedParts) > 0 {
+		return r.CheckParts(allowedParts...)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package validators
+
+import (
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/sdsrule"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ValidateSensitiveDataScannerPattern ensures a string is a regex supported by the Sensitive Data Scanner.
+func ValidateSensitiveDataScannerPattern() schema.SchemaValidateDiagFunc {
+	return func(val interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := val.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid value type",
+				Detail:        "Field value must be of type string",
+				AttributePath: path,
+			}}
+		}
+		if err := sdsrule.Check(value); err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid Sensitive Data Scanner pattern",
+				Detail:        err.Error(),
+				AttributePath: path,
+			}}
+		}
+		return nil
+	}
+}
@@ -265,3 +265,119 @@ func TestValidateHttpRequestHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRRule(t *testing.T) {
+	cases := []struct {
+		InputValue    string
+		AllowedParts  []string
+		ExpectedError bool
+	}{
+		{
+			InputValue:    "FREQ=WEEKLY;BYDAY=MO,FR",
+			ExpectedError: false,
+		},
+		{
+			InputValue:    "FREQ=WEEKLY;BYDAY=XX",
+			ExpectedError: true,
+		},
+		{
+			InputValue:    "FREQ=DAILY;DTSTART=20240101T000000Z",
+			ExpectedError: true,
+		},
+		{
+			InputValue:    "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			AllowedParts:  []string{"FREQ", "INTERVAL", "COUNT", "UNTIL"},
+			ExpectedError: false,
+		},
+		{
+			InputValue:    "FREQ=WEEKLY;BYDAY=MO",
+			AllowedParts:  []string{"FREQ", "INTERVAL", "COUNT", "UNTIL"},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		diags := ValidateRRule(tc.AllowedParts...)(tc.InputValue, cty.Path{})
+		if tc.ExpectedError != diags.HasError() {
+			t.Fatalf("Expected error %v for input %v, found %v instead", tc.ExpectedError, tc.InputValue, diags)
+		}
+
+		validationResult := validator.StringResponse{}
+		RRuleValidator(tc.AllowedParts...).ValidateString(nil, validator.StringRequest{ConfigValue: basetypes.NewStringValue(tc.InputValue)}, &validationResult)
+		if tc.ExpectedError != validationResult.Diagnostics.HasError() {
+			t.Fatalf("Expected error %v for input %v, found %v instead", tc.ExpectedError, tc.InputValue, validationResult.Diagnostics)
+		}
+	}
+}
+
+func TestValidateLogsQuery(t *testing.T) {
+	cases := []struct {
+		InputValue    string
+		ExpectedError bool
+	}{
+		{
+			InputValue:    "source:nginx AND (status:error OR @http.status_code:[500 TO 599])",
+			ExpectedError: false,
+		},
+		{
+			InputValue:    "",
+			ExpectedError: false,
+		},
+		{
+			InputValue:    "(source:nginx",
+			ExpectedError: true,
+		},
+		{
+			InputValue:    "service:",
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		diags := ValidateLogsQuery()(tc.InputValue, cty.Path{})
+		if tc.ExpectedError != diags.HasError() {
+			t.Fatalf("Expected error %v for input %v, found %v instead", tc.ExpectedError, tc.InputValue, diags)
+		}
+
+		validationResult := validator.StringResponse{}
+		LogsQueryValidator().ValidateString(nil, validator.StringRequest{ConfigValue: basetypes.NewStringValue(tc.InputValue)}, &validationResult)
+		if tc.ExpectedError != validationResult.Diagnostics.HasError() {
+			t.Fatalf("Expected error %v for input %v, found %v instead", tc.ExpectedError, tc.InputValue, validationResult.Diagnostics)
+		}
+	}
+}
+
+func TestValidateSensitiveDataScannerPattern(t *testing.T) {
+	cases := []struct {
+		InputValue    string
+		ExpectedError bool
+	}{
+		{
+			InputValue:    `\b(?:4\d{3}[- ]?){3}\d{4}\b`,
+			ExpectedError: false,
+		},
+		{
+			InputValue:    `\d+(?=px)`,
+			ExpectedError: true,
+		},
+		{
+			InputValue:    `[a-`,
+			ExpectedError: true,
+		},
+		{
+			InputValue:    `(?x) a b # comment`,
+			ExpectedError: false,
+		},
+		{
+			InputValue:    `\d{1,2000}`,
+			ExpectedError: false,
+		},
+	}
+
+	for _, tc := range cases {
+		diags := ValidateSensitiveDataScannerPattern()(tc.InputValue, cty.Path{})
+		if tc.ExpectedError != diags.HasError() {
+			t.Fatalf("Expected error %v for input %v, found %v instead", tc.ExpectedError, tc.InputValue, diags)
+		}
+	}
+}
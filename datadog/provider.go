@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
@@ -171,6 +172,66 @@ func Provider() *schema.Provider {
 					},
 				},
 			},
+			"synthetics_defaults": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration block containing default settings inherited by `datadog_synthetics_test` resources which don't set them.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"locations": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Array of locations used to run tests which don't set `locations`.",
+						},
+						"request_headers": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Header name and value map used by HTTP and browser tests which don't set `request_headers`.",
+						},
+						"retry": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Retry options used by tests whose `options_list` doesn't set `retry`.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"count": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     0,
+										Description: "Number of retries needed to consider a location as failed before sending a notification alert. Defaults to `0`.",
+									},
+									"interval": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     300,
+										Description: "Interval between a failed test and the next retry in milliseconds. Defaults to `300`.",
+									},
+								},
+							},
+						},
+						"monitor_options": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Monitor options used by tests whose `options_list` doesn't set `monitor_options`.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"renotify_interval": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     0,
+										Description: "Specify a renotification frequency in minutes. Defaults to `0`.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 
 		// NEW RESOURCES ARE NOT ALLOWED TO BE ADDED HERE
@@ -261,8 +322,14 @@ type ProviderConfiguration struct {
 	DatadogApiInstances *utils.ApiInstances
 	Auth                context.Context
 	DefaultTags         map[string]interface{}
+	SyntheticsDefaults  map[string]interface{}
 
 	Now func() time.Time
+
+	// monitor config policies are fetched at most once per provider run
+	monitorTagPoliciesOnce sync.Once
+	monitorTagPolicies     []utils.MonitorTagPolicy
+	monitorTagPoliciesErr  error
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -450,6 +517,9 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 			providerConfig.DefaultTags = tags.(map[string]interface{})
 		}
 	}
+	if v, ok := d.GetOk("synthetics_defaults"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		providerConfig.SyntheticsDefaults = v.([]interface{})[0].(map[string]interface{})
+	}
 
 	return &providerConfig, nil
 }
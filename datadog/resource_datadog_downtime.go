@@ -108,8 +108,8 @@ func (d *downtimeChild) GetMuteFirstRecoveryNotification() bool {
 
 func resourceDatadogDowntime() *schema.Resource {
 	return &schema.Resource{
-		Description:        "This resource is deprecated — use the `datadog_downtime_schedule resource` instead. Provides a Datadog downtime resource. This can be used to create and manage Datadog downtimes.",
-		DeprecationMessage: "This resource is deprecated — use the datadog_downtime_schedule resource instead.",
+		Description:        "This resource is deprecated — use the `datadog_downtime_schedule resource` instead. Existing downtimes can be migrated without being canceled by importing `legacy:<downtime ID>` into a `datadog_downtime_schedule`. Provides a Datadog downtime resource. This can be used to create and manage Datadog downtimes.",
+		DeprecationMessage: "This resource is deprecated — use the datadog_downtime_schedule resource instead. Existing downtimes can be migrated by importing legacy:<downtime ID> into a datadog_downtime_schedule.",
 		CreateContext:      resourceDatadogDowntimeCreate,
 		ReadContext:        resourceDatadogDowntimeRead,
 		UpdateContext:      resourceDatadogDowntimeUpdate,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/logsarchive"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
@@ -21,6 +22,7 @@ func resourceDatadogLogsArchive() *schema.Resource {
 		UpdateContext: resourceDatadogLogsArchiveUpdate,
 		ReadContext:   resourceDatadogLogsArchiveRead,
 		DeleteContext: resourceDatadogLogsArchiveDelete,
+		CustomizeDiff: resourceDatadogLogsArchiveDestinationCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -95,11 +97,63 @@ func resourceDatadogLogsArchive() *schema.Resource {
 					Type:        schema.TypeInt,
 					Optional:    true,
 				},
+				"check_destination": {
+					Description: "If set to `true`, the naming rules of the bucket or container, the syntax of the path and the format of the role, service account or storage account of the destination are checked during plan.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+						// This is never sent to the backend, so it should never generate a diff
+						return true
+					},
+				},
 			}
 		},
 	}
 }
 
+// logsArchiveDestinationVerifier checks the destinations when `check_destination` is set. Tests can point it at local
+// storage emulators with a logsarchive.EndpointVerifier.
+var logsArchiveDestinationVerifier logsarchive.Verifier = logsarchive.FormatVerifier{}
+
+// Check the destination of the archive before it is created or updated
+func resourceDatadogLogsArchiveDestinationCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if check, ok := diff.GetOk("check_destination"); !ok || !check.(bool) {
+		return nil
+	}
+	for _, archiveType := range []string{"s3_archive", "gcs_archive", "azure_archive"} {
+		blocks, _ := diff.Get(archiveType).([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+		block := blocks[0].(map[string]interface{})
+		for key := range block {
+			if !diff.NewValueKnown(fmt.Sprintf("%s.0.%s", archiveType, key)) {
+				// Destinations depending on other resources can't be checked yet
+				return nil
+			}
+		}
+		if err := logsArchiveDestinationVerifier.Verify(ctx, buildLogsArchiveDestination(archiveType, block)); err != nil {
+			return fmt.Errorf("%s: %w", archiveType, err)
+		}
+	}
+	return nil
+}
+
+func buildLogsArchiveDestination(archiveType string, block map[string]interface{}) logsarchive.Destination {
+	get := func(key string) string {
+		value, _ := block[key].(string)
+		return value
+	}
+	switch archiveType {
+	case "s3_archive":
+		return logsarchive.Destination{Type: logsarchive.S3, Bucket: get("bucket"), Path: get("path"), AccountID: get("account_id"), RoleName: get("role_name")}
+	case "gcs_archive":
+		return logsarchive.Destination{Type: logsarchive.GCS, Bucket: get("bucket"), Path: get("path"), ClientEmail: get("client_email"), ProjectID: get("project_id")}
+	default:
+		return logsarchive.Destination{Type: logsarchive.Azure, Bucket: get("container"), Path: get("path"), StorageAccount: get("storage_account"), ClientID: get("client_id"), TenantID: get("tenant_id")}
+	}
+}
+
 func resourceDatadogLogsArchiveCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
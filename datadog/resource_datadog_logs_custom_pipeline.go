@@ -2,10 +2,13 @@ package datadog
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
 
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/grok"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/logspipeline"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
@@ -35,7 +38,7 @@ const (
 	tfURLParserProcessor            = "url_parser"
 	tfUserAgentParserProcessor      = "user_agent_parser"
 	// This type string is used to differentiate between LookupProcessor and ReferenceTableLookupProcessor, due to them sharing a `type` in the API.
-	ddReferenceTableLookupProcessor = "reference-table-" + string(datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR)
+	ddReferenceTableLookupProcessor = logspipeline.ReferenceTableLookupProcessorType
 )
 
 var tfProcessorTypes = map[string]string{
@@ -75,25 +78,6 @@ var tfProcessors = map[string]*schema.Schema{
 	tfUserAgentParserProcessor:      userAgentParser,
 }
 
-var ddProcessorTypes = map[string]string{
-	string(datadogV1.LOGSARITHMETICPROCESSORTYPE_ARITHMETIC_PROCESSOR):        tfArithmeticProcessor,
-	string(datadogV1.LOGSATTRIBUTEREMAPPERTYPE_ATTRIBUTE_REMAPPER):            tfAttributeRemapperProcessor,
-	string(datadogV1.LOGSCATEGORYPROCESSORTYPE_CATEGORY_PROCESSOR):            tfCategoryProcessor,
-	string(datadogV1.LOGSDATEREMAPPERTYPE_DATE_REMAPPER):                      tfDateRemapperProcessor,
-	string(datadogV1.LOGSGEOIPPARSERTYPE_GEO_IP_PARSER):                       tfGeoIPParserProcessor,
-	string(datadogV1.LOGSGROKPARSERTYPE_GROK_PARSER):                          tfGrokParserProcessor,
-	string(datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR):                tfLookupProcessor,
-	ddReferenceTableLookupProcessor:                                           tfReferenceTableLookupProcessor,
-	string(datadogV1.LOGSMESSAGEREMAPPERTYPE_MESSAGE_REMAPPER):                tfMessageRemapperProcessor,
-	string(datadogV1.LOGSPIPELINEPROCESSORTYPE_PIPELINE):                      tfNestedPipelineProcessor,
-	string(datadogV1.LOGSSERVICEREMAPPERTYPE_SERVICE_REMAPPER):                tfServiceRemapperProcessor,
-	string(datadogV1.LOGSSTATUSREMAPPERTYPE_STATUS_REMAPPER):                  tfStatusRemapperProcessor,
-	string(datadogV1.LOGSSTRINGBUILDERPROCESSORTYPE_STRING_BUILDER_PROCESSOR): tfStringBuilderProcessor,
-	string(datadogV1.LOGSTRACEREMAPPERTYPE_TRACE_ID_REMAPPER):                 tfTraceIDRemapperProcessor,
-	string(datadogV1.LOGSURLPARSERTYPE_URL_PARSER):                            tfURLParserProcessor,
-	string(datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER):               tfUserAgentParserProcessor,
-}
-
 var arithmeticProcessor = &schema.Schema{
 	Type:        schema.TypeList,
 	MaxItems:    1,
@@ -380,13 +364,104 @@ func resourceDatadogLogsCustomPipeline() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Description: "Provides a Datadog [Logs Pipeline API](https://docs.datadoghq.com/api/v1/logs-pipelines/) resource, which is used to create and manage Datadog logs custom pipelines. Each `datadog_logs_custom_pipeline` resource defines a complete pipeline. The order of the pipelines is maintained in a different resource: `datadog_logs_pipeline_order`. When creating a new pipeline, you need to **explicitly** add this pipeline to the `datadog_logs_pipeline_order` resource to track the pipeline. Similarly, when a pipeline needs to be destroyed, remove its references from the `datadog_logs_pipeline_order` resource.",
+		Description:   "Provides a Datadog [Logs Pipeline API](https://docs.datadoghq.com/api/v1/logs-pipelines/) resource, which is used to create and manage Datadog logs custom pipelines. Each `datadog_logs_custom_pipeline` resource defines a complete pipeline. The order of the pipelines is maintained in a different resource: `datadog_logs_pipeline_order`. When creating a new pipeline, you need to **explicitly** add this pipeline to the `datadog_logs_pipeline_order` resource to track the pipeline. Similarly, when a pipeline needs to be destroyed, remove its references from the `datadog_logs_pipeline_order` resource.",
+		CustomizeDiff: logsCustomPipelineGrokDiff,
 		SchemaFunc: func() map[string]*schema.Schema {
-			return getPipelineSchema(false)
+			pipelineSchema := getPipelineSchema(false)
+			pipelineSchema["validate_grok_parsers"] = &schema.Schema{
+				Description: "Whether to parse the `samples` of the grok parsers with their rules at plan time, failing the plan when a rule is invalid or a sample matches no rule. The parser runs offline: the `url`, `useragent`, `xml`, `csv` and `rubyhash` filters are not evaluated, and `regex` matchers do not support lookarounds and backreferences.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			}
+			pipelineSchema["grok_parser_results"] = &schema.Schema{
+				Description: "Result of parsing the `samples` of the grok parsers, when `validate_grok_parsers` is `true`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"processor":  {Description: "Path of the grok parser in the pipeline.", Type: schema.TypeString, Computed: true},
+						"sample":     {Description: "The sample log.", Type: schema.TypeString, Computed: true},
+						"rule":       {Description: "Name of the match rule parsing the sample.", Type: schema.TypeString, Computed: true},
+						"attributes": {Description: "JSON encoded attributes extracted from the sample.", Type: schema.TypeString, Computed: true},
+					},
+				},
+			}
+			return pipelineSchema
 		},
 	}
 }
 
+// custom diff function that parses the samples of the grok parsers with their rules
+func logsCustomPipelineGrokDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("validate_grok_parsers").(bool) {
+		if len(d.Get("grok_parser_results").([]interface{})) > 0 {
+			return d.SetNew("grok_parser_results", []interface{}{})
+		}
+		return nil
+	}
+	if config := d.GetRawConfig(); config.IsNull() || !config.GetAttr("processor").IsWhollyKnown() {
+		return d.SetNewComputed("grok_parser_results")
+	}
+
+	var results []map[string]interface{}
+	var errs []string
+	var parseProcessors func(path string, processors []interface{})
+	parseProcessors = func(path string, processors []interface{}) {
+		for i, p := range processors {
+			processor, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if nested, ok := processor[tfNestedPipelineProcessor].([]interface{}); ok && len(nested) > 0 && nested[0] != nil {
+				parseProcessors(fmt.Sprintf("%sprocessor.%d.%s.0.", path, i, tfNestedPipelineProcessor), nested[0].(map[string]interface{})["processor"].([]interface{}))
+			}
+			grokParsers, ok := processor[tfGrokParserProcessor].([]interface{})
+			if !ok || len(grokParsers) == 0 || grokParsers[0] == nil {
+				continue
+			}
+			processorPath := fmt.Sprintf("%sprocessor.%d.%s.0", path, i, tfGrokParserProcessor)
+			grokParser := grokParsers[0].(map[string]interface{})
+
+			var matchRules, supportRules string
+			if tfGrok, ok := grokParser["grok"].([]interface{}); ok && len(tfGrok) > 0 && tfGrok[0] != nil {
+				matchRules = tfGrok[0].(map[string]interface{})["match_rules"].(string)
+				supportRules = tfGrok[0].(map[string]interface{})["support_rules"].(string)
+			}
+			parser, err := grok.NewParser(matchRules, supportRules)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", processorPath, err))
+				continue
+			}
+
+			for j, s := range grokParser["samples"].([]interface{}) {
+				sample, _ := s.(string)
+				rule, attributes, ok := parser.Parse(sample)
+				if !ok {
+					errs = append(errs, fmt.Sprintf("%s: sample %d %q does not match any of the rules %s", processorPath, j, sample, strings.Join(parser.RuleNames(), ", ")))
+					continue
+				}
+				attributesJSON, _ := json.Marshal(attributes)
+				results = append(results, map[string]interface{}{
+					"processor":  processorPath,
+					"sample":     sample,
+					"rule":       rule,
+					"attributes": string(attributesJSON),
+				})
+			}
+		}
+	}
+	parseProcessors("", d.Get("processor").([]interface{}))
+
+	if len(errs) > 0 {
+		return fmt.Errorf("grok parser validation failed:\n%s", strings.Join(errs, "\n"))
+	}
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+	return d.SetNew("grok_parser_results", results)
+}
+
 func resourceDatadogLogsPipelineCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -417,10 +492,10 @@ func updateLogsCustomPipelineState(d *schema.ResourceData, pipeline *datadogV1.L
 	if err := d.Set("is_enabled", pipeline.GetIsEnabled()); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("filter", buildTerraformFilter(pipeline.Filter)); err != nil {
+	if err := d.Set("filter", logspipeline.BuildTerraformFilter(pipeline.Filter)); err != nil {
 		return diag.FromErr(err)
 	}
-	tfProcessors, err := buildTerraformProcessors(pipeline.GetProcessors())
+	tfProcessors, err := logspipeline.BuildTerraformProcessors(pipeline.GetProcessors())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -489,270 +564,6 @@ func resourceDatadogLogsPipelineDelete(ctx context.Context, d *schema.ResourceDa
 	return nil
 }
 
-func buildTerraformProcessors(ddProcessors []datadogV1.LogsProcessor) ([]map[string]interface{}, error) {
-	tfProcessors := make([]map[string]interface{}, len(ddProcessors))
-	for i, ddProcessor := range ddProcessors {
-		tfProcessor, err := buildTerraformProcessor(ddProcessor)
-		if err != nil {
-			return nil, err
-		}
-		tfProcessors[i] = tfProcessor
-	}
-
-	return tfProcessors, nil
-}
-
-func buildTerraformProcessor(ddProcessor datadogV1.LogsProcessor) (map[string]interface{}, error) {
-	tfProcessor := make(map[string]interface{})
-	var processorType string
-	var err error
-	if ddProcessor.LogsArithmeticProcessor != nil {
-		tfProcessor = buildTerraformArithmeticProcessor(ddProcessor.LogsArithmeticProcessor)
-		processorType = string(datadogV1.LOGSARITHMETICPROCESSORTYPE_ARITHMETIC_PROCESSOR)
-	} else if ddProcessor.LogsAttributeRemapper != nil {
-		tfProcessor = buildTerraformAttributeRemapper(ddProcessor.LogsAttributeRemapper)
-		processorType = string(datadogV1.LOGSATTRIBUTEREMAPPERTYPE_ATTRIBUTE_REMAPPER)
-	} else if ddProcessor.LogsCategoryProcessor != nil {
-		tfProcessor = buildTerraformCategoryProcessor(ddProcessor.LogsCategoryProcessor)
-		processorType = string(datadogV1.LOGSCATEGORYPROCESSORTYPE_CATEGORY_PROCESSOR)
-	} else if ddProcessor.LogsDateRemapper != nil {
-		tfProcessor = buildTerraformDateRemapper(ddProcessor.LogsDateRemapper)
-		processorType = string(datadogV1.LOGSDATEREMAPPERTYPE_DATE_REMAPPER)
-	} else if ddProcessor.LogsMessageRemapper != nil {
-		tfProcessor = buildTerraformMessageRemapper(ddProcessor.LogsMessageRemapper)
-		processorType = string(datadogV1.LOGSMESSAGEREMAPPERTYPE_MESSAGE_REMAPPER)
-	} else if ddProcessor.LogsServiceRemapper != nil {
-		tfProcessor = buildTerraformServiceRemapper(ddProcessor.LogsServiceRemapper)
-		processorType = string(datadogV1.LOGSSERVICEREMAPPERTYPE_SERVICE_REMAPPER)
-	} else if ddProcessor.LogsStatusRemapper != nil {
-		tfProcessor = buildTerraformStatusRemapper(ddProcessor.LogsStatusRemapper)
-		processorType = string(datadogV1.LOGSSTATUSREMAPPERTYPE_STATUS_REMAPPER)
-	} else if ddProcessor.LogsTraceRemapper != nil {
-		tfProcessor = buildTerraformTraceRemapper(ddProcessor.LogsTraceRemapper)
-		processorType = string(datadogV1.LOGSTRACEREMAPPERTYPE_TRACE_ID_REMAPPER)
-	} else if ddProcessor.LogsGeoIPParser != nil {
-		tfProcessor = buildTerraformGeoIPParser(ddProcessor.LogsGeoIPParser)
-		processorType = string(datadogV1.LOGSGEOIPPARSERTYPE_GEO_IP_PARSER)
-	} else if ddProcessor.LogsGrokParser != nil {
-		tfProcessor = buildTerraformGrokParser(ddProcessor.LogsGrokParser)
-		processorType = string(datadogV1.LOGSGROKPARSERTYPE_GROK_PARSER)
-	} else if ddProcessor.LogsLookupProcessor != nil {
-		tfProcessor = buildTerraformLookupProcessor(ddProcessor.LogsLookupProcessor)
-		processorType = string(datadogV1.LOGSLOOKUPPROCESSORTYPE_LOOKUP_PROCESSOR)
-	} else if ddProcessor.ReferenceTableLogsLookupProcessor != nil {
-		tfProcessor = buildTerraformReferenceTableLookupProcessor(ddProcessor.ReferenceTableLogsLookupProcessor)
-		processorType = ddReferenceTableLookupProcessor
-	} else if ddProcessor.LogsPipelineProcessor != nil {
-		tfProcessor, err = buildTerraformNestedPipeline(ddProcessor.LogsPipelineProcessor)
-		processorType = string(datadogV1.LOGSPIPELINEPROCESSORTYPE_PIPELINE)
-	} else if ddProcessor.LogsStringBuilderProcessor != nil {
-		tfProcessor = buildTerraformStringBuilderProcessor(ddProcessor.LogsStringBuilderProcessor)
-		processorType = string(datadogV1.LOGSSTRINGBUILDERPROCESSORTYPE_STRING_BUILDER_PROCESSOR)
-	} else if ddProcessor.LogsURLParser != nil {
-		tfProcessor = buildTerraformURLParser(ddProcessor.LogsURLParser)
-		processorType = string(datadogV1.LOGSURLPARSERTYPE_URL_PARSER)
-	} else if ddProcessor.LogsUserAgentParser != nil {
-		tfProcessor = buildTerraformUserAgentParser(ddProcessor.LogsUserAgentParser)
-		processorType = string(datadogV1.LOGSUSERAGENTPARSERTYPE_USER_AGENT_PARSER)
-	} else {
-		err = fmt.Errorf("failed to support datadogV1 processor type, %s", ddProcessor.GetActualInstance())
-	}
-	if err != nil {
-		return nil, err
-	}
-	return map[string]interface{}{
-		ddProcessorTypes[processorType]: []map[string]interface{}{tfProcessor},
-	}, nil
-}
-
-func buildTerraformUserAgentParser(ddUserAgent *datadogV1.LogsUserAgentParser) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":    ddUserAgent.Sources,
-		"target":     ddUserAgent.GetTarget(),
-		"is_encoded": ddUserAgent.GetIsEncoded(),
-		"name":       ddUserAgent.GetName(),
-		"is_enabled": ddUserAgent.GetIsEnabled(),
-	}
-}
-
-func buildTerraformURLParser(ddURL *datadogV1.LogsURLParser) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":                  ddURL.Sources,
-		"target":                   ddURL.GetTarget(),
-		"normalize_ending_slashes": ddURL.GetNormalizeEndingSlashes(),
-		"name":                     ddURL.GetName(),
-		"is_enabled":               ddURL.GetIsEnabled(),
-	}
-}
-
-func buildTerraformLookupProcessor(ddLookup *datadogV1.LogsLookupProcessor) map[string]interface{} {
-	tfProcessor := map[string]interface{}{
-		"source":       ddLookup.GetSource(),
-		"target":       ddLookup.GetTarget(),
-		"lookup_table": ddLookup.GetLookupTable(),
-		"name":         ddLookup.GetName(),
-		"is_enabled":   ddLookup.GetIsEnabled(),
-	}
-
-	if ddLookup.HasDefaultLookup() {
-		tfProcessor["default_lookup"] = ddLookup.GetDefaultLookup()
-	}
-
-	return tfProcessor
-}
-
-func buildTerraformReferenceTableLookupProcessor(ddLookup *datadogV1.ReferenceTableLogsLookupProcessor) map[string]interface{} {
-	return map[string]interface{}{
-		"source":                  ddLookup.GetSource(),
-		"target":                  ddLookup.GetTarget(),
-		"lookup_enrichment_table": ddLookup.GetLookupEnrichmentTable(),
-		"name":                    ddLookup.GetName(),
-		"is_enabled":              ddLookup.GetIsEnabled(),
-	}
-}
-
-func buildTerraformNestedPipeline(ddNested *datadogV1.LogsPipelineProcessor) (map[string]interface{}, error) {
-	tfProcessors, err := buildTerraformProcessors(ddNested.GetProcessors())
-	if err != nil {
-		return nil, err
-	}
-	return map[string]interface{}{
-		"filter":     buildTerraformFilter(ddNested.Filter),
-		"processor":  tfProcessors,
-		"name":       ddNested.GetName(),
-		"is_enabled": ddNested.GetIsEnabled(),
-	}, nil
-}
-
-func buildTerraformStringBuilderProcessor(ddStringBuilder *datadogV1.LogsStringBuilderProcessor) map[string]interface{} {
-	return map[string]interface{}{
-		"template":           ddStringBuilder.GetTemplate(),
-		"target":             ddStringBuilder.GetTarget(),
-		"is_replace_missing": ddStringBuilder.GetIsReplaceMissing(),
-		"name":               ddStringBuilder.GetName(),
-		"is_enabled":         ddStringBuilder.GetIsEnabled(),
-	}
-}
-
-func buildTerraformGeoIPParser(ddGeoIPParser *datadogV1.LogsGeoIPParser) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":    ddGeoIPParser.GetSources(),
-		"target":     ddGeoIPParser.GetTarget(),
-		"name":       ddGeoIPParser.GetName(),
-		"is_enabled": ddGeoIPParser.GetIsEnabled(),
-	}
-}
-
-func buildTerraformGrokParser(ddGrok *datadogV1.LogsGrokParser) map[string]interface{} {
-	return map[string]interface{}{
-		"samples":    ddGrok.GetSamples(),
-		"source":     ddGrok.GetSource(),
-		"grok":       buildTerraformGrokRule(&ddGrok.Grok),
-		"name":       ddGrok.GetName(),
-		"is_enabled": ddGrok.GetIsEnabled(),
-	}
-}
-
-func buildTerraformGrokRule(ddGrokRule *datadogV1.LogsGrokParserRules) []map[string]interface{} {
-	tfGrokRule := map[string]interface{}{
-		"support_rules": ddGrokRule.GetSupportRules(),
-		"match_rules":   ddGrokRule.GetMatchRules(),
-	}
-	return []map[string]interface{}{tfGrokRule}
-}
-
-func buildTerraformMessageRemapper(remapper *datadogV1.LogsMessageRemapper) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":    remapper.GetSources(),
-		"name":       remapper.GetName(),
-		"is_enabled": remapper.GetIsEnabled(),
-	}
-}
-
-func buildTerraformDateRemapper(remapper *datadogV1.LogsDateRemapper) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":    remapper.GetSources(),
-		"name":       remapper.GetName(),
-		"is_enabled": remapper.GetIsEnabled(),
-	}
-}
-
-func buildTerraformServiceRemapper(remapper *datadogV1.LogsServiceRemapper) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":    remapper.GetSources(),
-		"name":       remapper.GetName(),
-		"is_enabled": remapper.GetIsEnabled(),
-	}
-}
-
-func buildTerraformStatusRemapper(remapper *datadogV1.LogsStatusRemapper) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":    remapper.GetSources(),
-		"name":       remapper.GetName(),
-		"is_enabled": remapper.GetIsEnabled(),
-	}
-}
-
-func buildTerraformTraceRemapper(remapper *datadogV1.LogsTraceRemapper) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":    remapper.GetSources(),
-		"name":       remapper.GetName(),
-		"is_enabled": remapper.GetIsEnabled(),
-	}
-}
-
-func buildTerraformCategoryProcessor(ddCategory *datadogV1.LogsCategoryProcessor) map[string]interface{} {
-	return map[string]interface{}{
-		"target":     ddCategory.GetTarget(),
-		"category":   buildTerraformCategories(ddCategory.Categories),
-		"name":       ddCategory.GetName(),
-		"is_enabled": ddCategory.GetIsEnabled(),
-	}
-}
-
-func buildTerraformCategories(ddCategories []datadogV1.LogsCategoryProcessorCategory) []map[string]interface{} {
-	tfCategories := make([]map[string]interface{}, len(ddCategories))
-	for i, ddCategory := range ddCategories {
-		tfCategories[i] = map[string]interface{}{
-			"name":   ddCategory.GetName(),
-			"filter": buildTerraformFilter(ddCategory.Filter),
-		}
-	}
-	return tfCategories
-}
-
-func buildTerraformAttributeRemapper(ddAttribute *datadogV1.LogsAttributeRemapper) map[string]interface{} {
-	return map[string]interface{}{
-		"sources":              ddAttribute.Sources,
-		"source_type":          ddAttribute.GetSourceType(),
-		"target":               ddAttribute.GetTarget(),
-		"target_type":          ddAttribute.GetTargetType(),
-		"target_format":        ddAttribute.GetTargetFormat(),
-		"preserve_source":      ddAttribute.GetPreserveSource(),
-		"override_on_conflict": ddAttribute.GetOverrideOnConflict(),
-		"name":                 ddAttribute.GetName(),
-		"is_enabled":           ddAttribute.GetIsEnabled(),
-	}
-}
-
-func buildTerraformArithmeticProcessor(ddArithmetic *datadogV1.LogsArithmeticProcessor) map[string]interface{} {
-
-	return map[string]interface{}{
-		"target":             ddArithmetic.GetTarget(),
-		"is_replace_missing": ddArithmetic.GetIsReplaceMissing(),
-		"expression":         ddArithmetic.GetExpression(),
-		"name":               ddArithmetic.GetName(),
-		"is_enabled":         ddArithmetic.GetIsEnabled(),
-	}
-}
-
-func buildTerraformFilter(ddFilter *datadogV1.LogsFilter) []map[string]interface{} {
-	tfFilter := map[string]interface{}{
-		"query": ddFilter.GetQuery(),
-	}
-	return []map[string]interface{}{tfFilter}
-}
-
 func buildDatadogPipeline(d *schema.ResourceData) (*datadogV1.LogsPipeline, error) {
 	var ddPipeline datadogV1.LogsPipeline
 	ddPipeline.SetName(d.Get("name").(string))
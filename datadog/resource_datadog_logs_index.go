@@ -2,11 +2,14 @@ package datadog
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"math"
 	"regexp"
 	"sync"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -88,9 +91,10 @@ var indexSchema = map[string]*schema.Schema{
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"query": {
-					Description: "Logs filter criteria. Only logs matching this filter criteria are considered for this index.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "Logs filter criteria. Only logs matching this filter criteria are considered for this index.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validators.ValidateLogsQuery(),
 				},
 			},
 		},
@@ -103,8 +107,33 @@ var indexSchema = map[string]*schema.Schema{
 			Schema: exclusionFilterSchema,
 		},
 	},
+	"retention_cost_budget": {
+		Description: "Check during plan that changes of `daily_limit`, `disable_daily_limit` or `retention_days` don't raise the projected monthly retention cost of the index above a threshold. The plan fails, rather than showing a warning, when the projected cost exceeds the threshold. The monthly volume is projected from the usage of the index over the last 7 days, capped by its daily limit. Only the Standard Tier retention is projected: the cost of `flex_retention_days` is excluded. This is never sent to Datadog.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"monthly_threshold": {
+					Description:  "Projected monthly retention cost above which the plan fails.",
+					Type:         schema.TypeFloat,
+					Required:     true,
+					ValidateFunc: validation.FloatAtLeast(0),
+				},
+				"price_per_million_events": {
+					Description: "Price of a million indexed events, keyed by number of retention days, for example `{ \"15\" = 1.70, \"30\" = 2.50 }`.",
+					Type:        schema.TypeMap,
+					Required:    true,
+					Elem:        &schema.Schema{Type: schema.TypeFloat},
+				},
+			},
+		},
+	},
 }
 
+// logsIndexUsageDays is the number of days of usage the monthly volume of an index is projected from
+const logsIndexUsageDays = 7
+
 var exclusionFilterSchema = map[string]*schema.Schema{
 	"name": {
 		Description: "The name of the exclusion filter.",
@@ -122,9 +151,10 @@ var exclusionFilterSchema = map[string]*schema.Schema{
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"query": {
-					Description: "Only logs matching the filter criteria and the query of the parent index will be considered for this exclusion filter.",
-					Type:        schema.TypeString,
-					Optional:    true,
+					Description:      "Only logs matching the filter criteria and the query of the parent index will be considered for this exclusion filter.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validators.ValidateLogsQuery(),
 				},
 				"sample_rate": {
 					Description: "The fraction of logs excluded by the exclusion filter, when active.",
@@ -152,6 +182,7 @@ func resourceDatadogLogsIndex() *schema.Resource {
 		UpdateContext: resourceDatadogLogsIndexUpdate,
 		ReadContext:   resourceDatadogLogsIndexRead,
 		DeleteContext: resourceDatadogLogsIndexDelete,
+		CustomizeDiff: logsIndexRetentionCostDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -162,6 +193,75 @@ func resourceDatadogLogsIndex() *schema.Resource {
 	}
 }
 
+// logsIndexRetentionCostDiff checks that a change doesn't raise the projected monthly retention cost of the index
+// above the threshold of its retention cost budget. SDKv2 CustomizeDiff functions can't return warnings, so going
+// over budget fails the plan. The Flex Tier retention isn't part of the projection.
+func logsIndexRetentionCostDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	budgets := diff.Get("retention_cost_budget").([]interface{})
+	if len(budgets) == 0 || budgets[0] == nil || !diff.HasChanges("daily_limit", "disable_daily_limit", "retention_days") {
+		return nil
+	}
+	for _, key := range []string{"daily_limit", "disable_daily_limit", "retention_days", "retention_cost_budget"} {
+		if !diff.NewValueKnown(key) {
+			// Values depending on other resources can't be checked yet
+			return nil
+		}
+	}
+	budget := budgets[0].(map[string]interface{})
+	threshold := budget["monthly_threshold"].(float64)
+	prices := make(map[string]float64)
+	for retention, price := range budget["price_per_million_events"].(map[string]interface{}) {
+		prices[retention] = price.(float64)
+	}
+
+	// New indexes have no usage yet, so their volume is only projected from their daily limit
+	averageDailyEvents := math.Inf(1)
+	name := diff.Get("name").(string)
+	if diff.Id() != "" {
+		providerConf := meta.(*ProviderConfiguration)
+		usages, httpResp, err := utils.GetLogsIndexUsage(providerConf.Auth, providerConf.DatadogApiInstances.GetUsageMeteringApiV1(), []string{name}, providerConf.Now(), logsIndexUsageDays)
+		if err != nil {
+			return utils.TranslateClientError(err, httpResp, "error getting logs index usage")
+		}
+		averageDailyEvents = 0
+		if usage, ok := usages[name]; ok {
+			averageDailyEvents = usage.AverageDailyEvents()
+		}
+	}
+
+	projectedCost := func(values func(string) interface{}) (float64, error) {
+		var dailyLimit *int64
+		if limit := int64(values("daily_limit").(int)); !values("disable_daily_limit").(bool) && limit > 0 {
+			dailyLimit = &limit
+		}
+		monthlyEvents := utils.ProjectLogsIndexMonthlyEvents(averageDailyEvents, dailyLimit)
+		if math.IsInf(monthlyEvents, 1) {
+			return monthlyEvents, nil
+		}
+		return utils.LogsRetentionMonthlyCost(monthlyEvents, int64(values("retention_days").(int)), prices)
+	}
+	newCost, err := projectedCost(func(key string) interface{} {
+		return diff.Get(key)
+	})
+	if err != nil {
+		return fmt.Errorf("retention_cost_budget: %s", err)
+	}
+	if math.IsInf(newCost, 1) || newCost <= threshold {
+		// Without daily limit nor usage, the volume can't be projected
+		return nil
+	}
+	if diff.Id() != "" {
+		oldCost, err := projectedCost(func(key string) interface{} {
+			old, _ := diff.GetChange(key)
+			return old
+		})
+		if err == nil && newCost <= oldCost {
+			return nil
+		}
+	}
+	return fmt.Errorf("the projected monthly retention cost of logs index %s would be %.2f, above the threshold of %.2f of its retention_cost_budget", name, newCost, threshold)
+}
+
 func resourceDatadogLogsIndexCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -10,6 +10,7 @@ import (
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDatadogLogsMetric() *schema.Resource {
@@ -19,6 +20,7 @@ func resourceDatadogLogsMetric() *schema.Resource {
 		ReadContext:   resourceDatadogLogsMetricRead,
 		UpdateContext: resourceDatadogLogsMetricUpdate,
 		DeleteContext: resourceDatadogLogsMetricDelete,
+		CustomizeDiff: logsMetricCardinalityDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -96,6 +98,30 @@ func resourceDatadogLogsMetric() *schema.Resource {
 					},
 				},
 
+				"cardinality_check": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Check during plan that the `group_by` paths don't project more series than a limit. The number of distinct values of each path is searched in the indexed logs matching the filter, and the plan fails when their product exceeds the limit, as this resource can't report warnings during plan, unlike `datadog_spans_metric`. The check runs when the metric is created or its `filter` or `group_by` change. This is never sent to Datadog.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"limit": {
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+								Description:  "Maximum number of series of the metric.",
+							},
+							"hours": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      24,
+								ValidateFunc: validation.IntBetween(1, 720),
+								Description:  "Number of hours of logs searched for distinct values.",
+							},
+						},
+					},
+				},
+
 				"name": {
 					Type:        schema.TypeString,
 					Required:    true,
@@ -207,6 +233,46 @@ func getGroupBys(d *schema.ResourceData) ([]datadogV2.LogsMetricGroupBy, error)
 	return groupBys, nil
 }
 
+// Estimate the cardinality of the metric from the distinct values of its group by paths in the recent logs.
+// SDKv2 CustomizeDiff functions can't return warnings, so exceeding the limit is an error.
+func logsMetricCardinalityDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	checks := diff.Get("cardinality_check").([]interface{})
+	if len(checks) == 0 || checks[0] == nil || (diff.Id() != "" && !diff.HasChanges("filter", "group_by")) {
+		return nil
+	}
+	for _, key := range []string{"filter", "group_by", "cardinality_check"} {
+		if !diff.NewValueKnown(key) {
+			// Values depending on other resources can't be checked yet
+			return nil
+		}
+	}
+	check := checks[0].(map[string]interface{})
+
+	var paths []string
+	for _, v := range diff.Get("group_by").(*schema.Set).List() {
+		if groupBy, ok := v.(map[string]interface{}); ok {
+			paths = append(paths, groupBy["path"].(string))
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	var query string
+	if filters := diff.Get("filter").([]interface{}); len(filters) > 0 && filters[0] != nil {
+		query = filters[0].(map[string]interface{})["query"].(string)
+	}
+
+	providerConf := meta.(*ProviderConfiguration)
+	distinctValues, httpResp, err := utils.GetLogsDistinctValues(providerConf.Auth, providerConf.DatadogApiInstances.GetLogsApiV2(), query, paths, check["hours"].(int))
+	if err != nil {
+		return utils.TranslateClientError(err, httpResp, "error estimating the cardinality of the logs metric")
+	}
+	if err := utils.CheckMetricCardinality(distinctValues, int64(check["limit"].(int))); err != nil {
+		return fmt.Errorf("cardinality_check: %s", err)
+	}
+	return nil
+}
+
 func resourceDatadogLogsMetricCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
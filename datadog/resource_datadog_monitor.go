@@ -32,7 +32,7 @@ func resourceDatadogMonitor() *schema.Resource {
 		ReadContext:   resourceDatadogMonitorRead,
 		UpdateContext: resourceDatadogMonitorUpdate,
 		DeleteContext: resourceDatadogMonitorDelete,
-		CustomizeDiff: customdiff.All(resourceDatadogMonitorCustomizeDiff, tagDiff),
+		CustomizeDiff: customdiff.All(resourceDatadogMonitorCustomizeDiff, tagDiff, resourceDatadogMonitorConfigPolicyCustomizeDiff),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -348,6 +348,15 @@ func resourceDatadogMonitor() *schema.Resource {
 						return true
 					},
 				},
+				"check_config_policies": {
+					Description: "If set to `true`, the monitor tags are checked during plan against the monitor config policies of the organization.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+						// This is never sent to the backend, so it should never generate a diff
+						return true
+					},
+				},
 				"variables": getMonitorFormulaQuerySchema(),
 				"scheduling_options": {
 					Description: "Configuration options for scheduling.",
@@ -917,6 +926,22 @@ func resourceDatadogMonitorCustomizeDiff(ctx context.Context, diff *schema.Resou
 	})
 }
 
+// Check the monitor tags, including default tags, against the monitor config policies
+func resourceDatadogMonitorConfigPolicyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if check, ok := diff.GetOk("check_config_policies"); !ok || !check.(bool) {
+		return nil
+	}
+	if !diff.NewValueKnown("tags") {
+		// Tags depending on other resources can't be checked yet
+		return nil
+	}
+	var tags []string
+	for _, s := range diff.Get("tags").(*schema.Set).List() {
+		tags = append(tags, s.(string))
+	}
+	return checkMonitorConfigPolicies(meta, "tags", tags)
+}
+
 func resourceDatadogMonitorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -2,7 +2,9 @@ package datadog
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -225,3 +227,48 @@ func checkPolicyConsistency(d *schema.ResourceData) error {
 	}
 	return nil
 }
+
+// MonitorTagPolicies returns the tag policies of the organization. They are fetched on first use and cached for the
+// rest of the provider run.
+func (p *ProviderConfiguration) MonitorTagPolicies() ([]utils.MonitorTagPolicy, error) {
+	p.monitorTagPoliciesOnce.Do(func() {
+		resp, httpresp, err := p.DatadogApiInstances.GetMonitorsApiV2().ListMonitorConfigPolicies(p.Auth)
+		if err != nil {
+			p.monitorTagPoliciesErr = utils.TranslateClientError(err, httpresp, "error querying monitor config policies")
+			return
+		}
+		for _, mcp := range resp.GetData() {
+			attributes := mcp.GetAttributes()
+			policy := attributes.GetPolicy()
+			if attributes.GetPolicyType() != datadogV2.MONITORCONFIGPOLICYTYPE_TAG || policy.MonitorConfigPolicyTagPolicy == nil {
+				continue
+			}
+			p.monitorTagPolicies = append(p.monitorTagPolicies, utils.MonitorTagPolicy{
+				ID:             mcp.GetId(),
+				TagKey:         policy.MonitorConfigPolicyTagPolicy.GetTagKey(),
+				TagKeyRequired: policy.MonitorConfigPolicyTagPolicy.GetTagKeyRequired(),
+				ValidTagValues: policy.MonitorConfigPolicyTagPolicy.GetValidTagValues(),
+			})
+		}
+	})
+	return p.monitorTagPolicies, p.monitorTagPoliciesErr
+}
+
+// checkMonitorConfigPolicies reports the violations of the organization's monitor config policies by the tags set
+// on the given attribute.
+func checkMonitorConfigPolicies(meta interface{}, attribute string, tags []string) error {
+	policies, err := meta.(*ProviderConfiguration).MonitorTagPolicies()
+	if err != nil {
+		return err
+	}
+	violations := utils.CheckMonitorTagPolicies(tags, policies)
+	if len(violations) == 0 {
+		return nil
+	}
+	var msg strings.Builder
+	msg.WriteString("monitor does not comply with the monitor config policies:")
+	for _, violation := range violations {
+		fmt.Fprintf(&msg, "\n  %s: %s", attribute, violation)
+	}
+	return errors.New(msg.String())
+}
@@ -47,7 +47,7 @@ func resourceDatadogMonitorJSON() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: customdiff.ForceNewIfChange("monitor", func(ctx context.Context, old, new, meta interface{}) bool {
+		CustomizeDiff: customdiff.All(customdiff.ForceNewIfChange("monitor", func(ctx context.Context, old, new, meta interface{}) bool {
 			oldAttrMap, _ := structure.ExpandJsonFromString(old.(string))
 			newAttrMap, _ := structure.ExpandJsonFromString(new.(string))
 
@@ -62,7 +62,7 @@ func resourceDatadogMonitorJSON() *schema.Resource {
 			}
 
 			return oldType != newType
-		}),
+		}), resourceDatadogMonitorJSONConfigPolicyCustomizeDiff),
 		SchemaFunc: func() map[string]*schema.Schema {
 			return map[string]*schema.Schema{
 				"monitor": {
@@ -100,6 +100,15 @@ func resourceDatadogMonitorJSON() *schema.Resource {
 					},
 					Description: "The JSON formatted definition of the monitor.",
 				},
+				"check_config_policies": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "If set to `true`, the tags of the monitor definition are checked during plan against the monitor config policies of the organization.",
+					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+						// This is never sent to the backend, so it should never generate a diff
+						return true
+					},
+				},
 				"url": {
 					Type:        schema.TypeString,
 					Optional:    true,
@@ -111,6 +120,28 @@ func resourceDatadogMonitorJSON() *schema.Resource {
 	}
 }
 
+func resourceDatadogMonitorJSONConfigPolicyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if check, ok := diff.GetOk("check_config_policies"); !ok || !check.(bool) {
+		return nil
+	}
+	if !diff.NewValueKnown("monitor") {
+		return nil
+	}
+	attrMap, err := structure.ExpandJsonFromString(diff.Get("monitor").(string))
+	if err != nil {
+		return err
+	}
+	var tags []string
+	if rawTags, ok := attrMap["tags"].([]interface{}); ok {
+		for _, tag := range rawTags {
+			if tag, ok := tag.(string); ok {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return checkMonitorConfigPolicies(meta, "monitor.tags", tags)
+}
+
 func resourceDatadogMonitorJSONRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -2,12 +2,15 @@ package datadog
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/sdsrule"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 )
@@ -19,6 +22,7 @@ func resourceDatadogSensitiveDataScannerRule() *schema.Resource {
 		CreateContext: resourceDatadogSensitiveDataScannerRuleCreate,
 		UpdateContext: resourceDatadogSensitiveDataScannerRuleUpdate,
 		DeleteContext: resourceDatadogSensitiveDataScannerRuleDelete,
+		CustomizeDiff: sensitiveDataScannerRuleSamplesDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -65,9 +69,10 @@ func resourceDatadogSensitiveDataScannerRule() *schema.Resource {
 					Description: "Whether or not the rule is enabled.",
 				},
 				"pattern": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Not included if there is a relationship to a standard pattern.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "Not included if there is a relationship to a standard pattern. Patterns using regex syntax not supported by the scanner, such as lookarounds or backreferences, are rejected during plan.",
+					ValidateDiagFunc: validators.ValidateSensitiveDataScannerPattern(),
 				},
 				"tags": {
 					Type:        schema.TypeList,
@@ -133,6 +138,30 @@ func resourceDatadogSensitiveDataScannerRule() *schema.Resource {
 						},
 					},
 				},
+				"test_samples": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Samples the rule is tested against during plan, applying the keyword proximity of `included_keyword_configuration` and the `text_replacement` of the rule locally. The plan fails when a sample is not matched or redacted as expected. Rules referring to a standard pattern are tested against the pattern of the standard pattern. Patterns using free-spacing mode or repetitions above 1000 can't be evaluated by the provider and fail the plan when samples are set. This is never sent to Datadog.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"sample": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Text scanned by the rule.",
+							},
+							"should_match": {
+								Type:        schema.TypeBool,
+								Required:    true,
+								Description: "Whether the rule is expected to match the sample.",
+							},
+							"expected_output": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The sample once redacted by the rule. Partial replacements replace characters with `*`. Can't be checked for the `hash` replacement type.",
+							},
+						},
+					},
+				},
 				"priority": {
 					Type:         schema.TypeInt,
 					Optional:     true,
@@ -145,6 +174,80 @@ func resourceDatadogSensitiveDataScannerRule() *schema.Resource {
 	}
 }
 
+// Test the rule against the samples of `test_samples`
+func sensitiveDataScannerRuleSamplesDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	samples := diff.Get("test_samples").([]interface{})
+	if len(samples) == 0 {
+		return nil
+	}
+	for _, key := range []string{"pattern", "standard_pattern_id", "included_keyword_configuration", "text_replacement", "test_samples"} {
+		if !diff.NewValueKnown(key) {
+			// Values depending on other resources can't be checked yet
+			return nil
+		}
+	}
+
+	pattern := diff.Get("pattern").(string)
+	if standardPatternID := diff.Get("standard_pattern_id").(string); standardPatternID != "" {
+		providerConf := meta.(*ProviderConfiguration)
+		resp, httpResp, err := providerConf.DatadogApiInstances.GetSensitiveDataScannerApiV2().ListStandardPatterns(providerConf.Auth)
+		if err != nil {
+			return utils.TranslateClientError(err, httpResp, "error listing standard patterns")
+		}
+		pattern = ""
+		for _, standardPattern := range resp.GetData() {
+			if standardPattern.GetId() == standardPatternID {
+				pattern = standardPattern.Attributes.GetPattern()
+			}
+		}
+		if pattern == "" {
+			return fmt.Errorf("test_samples: standard pattern %s not found", standardPatternID)
+		}
+	}
+	re, err := sdsrule.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("test_samples: %s", err)
+	}
+
+	rule := &sdsrule.Rule{Pattern: re}
+	if v, ok := diff.GetOk("included_keyword_configuration"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		config := v.([]interface{})[0].(map[string]interface{})
+		for _, keyword := range config["keywords"].([]interface{}) {
+			if keyword, ok := keyword.(string); ok {
+				rule.Keywords = append(rule.Keywords, keyword)
+			}
+		}
+		rule.CharacterCount = config["character_count"].(int)
+	}
+	if v, ok := diff.GetOk("text_replacement"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		replacement := v.([]interface{})[0].(map[string]interface{})
+		rule.TextReplacement = sdsrule.TextReplacement{
+			Type:              replacement["type"].(string),
+			ReplacementString: replacement["replacement_string"].(string),
+			NumberOfChars:     replacement["number_of_chars"].(int),
+		}
+	}
+
+	var errs []string
+	for _, v := range samples {
+		sample, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var expectedOutput *string
+		if output, ok := sample["expected_output"].(string); ok && output != "" {
+			expectedOutput = &output
+		}
+		if err := rule.CheckSample(sample["sample"].(string), sample["should_match"].(bool), expectedOutput); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("test_samples: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
 func resourceDatadogSensitiveDataScannerRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
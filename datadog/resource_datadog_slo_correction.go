@@ -2,15 +2,28 @@ package datadog
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/rrule"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// sloCorrectionRRuleParts are the recurrence rule parts supported by the SLO correction API
+var sloCorrectionRRuleParts = []string{"FREQ", "INTERVAL", "COUNT", "UNTIL"}
+
+// sloCorrectionScheduleFields are the attributes the `next_occurrences` preview is derived from
+var sloCorrectionScheduleFields = []string{"start", "end", "rrule", "duration", "timezone", "next_occurrences_count"}
+
+// sloCorrectionDefaultOccurrencesCount is the number of windows in `next_occurrences` when `next_occurrences_count` isn't set
+const sloCorrectionDefaultOccurrencesCount = 5
+
 func resourceDatadogSloCorrection() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Resource for interacting with the slo_correction API.",
@@ -18,6 +31,7 @@ func resourceDatadogSloCorrection() *schema.Resource {
 		ReadContext:   resourceDatadogSloCorrectionRead,
 		UpdateContext: resourceDatadogSloCorrectionUpdate,
 		DeleteContext: resourceDatadogSloCorrectionDelete,
+		CustomizeDiff: resourceDatadogSloCorrectionCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -51,25 +65,118 @@ func resourceDatadogSloCorrection() *schema.Resource {
 					Description: "Starting time of the correction in epoch seconds.",
 				},
 				"timezone": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "The timezone to display in the UI for the correction times (defaults to \"UTC\")",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validators.ValidateDatadogDowntimeTimezone,
+					Description:  "The timezone to display in the UI for the correction times (defaults to \"UTC\")",
 				},
 				"duration": {
-					Type:        schema.TypeInt,
-					Optional:    true,
-					Description: "Length of time in seconds for a specified `rrule` recurring SLO correction (required if specifying `rrule`)",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Length of time in seconds for a specified `rrule` recurring SLO correction (required if specifying `rrule`)",
 				},
 				"rrule": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Recurrence rules as defined in the iCalendar RFC 5545. Supported rules for SLO corrections are `FREQ`, `INTERVAL`, `COUNT` and `UNTIL`.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validators.ValidateRRule(sloCorrectionRRuleParts...),
+					Description:      "Recurrence rules as defined in the iCalendar RFC 5545. Supported rules for SLO corrections are `FREQ`, `INTERVAL`, `COUNT` and `UNTIL`.",
+				},
+				"next_occurrences_count": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(1, 100),
+					Description:  "Number of upcoming correction windows to compute in `next_occurrences`. 5 windows are computed when not set.",
+				},
+				"next_occurrences": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "The next correction windows, computed locally from `start`, `end`, `rrule`, `duration` and `timezone`. Windows which are already over are not listed.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"start": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Start of the correction window, as an RFC 3339 timestamp in the correction's timezone.",
+							},
+							"end": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "End of the correction window, as an RFC 3339 timestamp in the correction's timezone.",
+							},
+						},
+					},
 				},
 			}
 		},
 	}
 }
 
+func resourceDatadogSloCorrectionCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if rule, ok := diff.GetOk("rrule"); ok && rule.(string) != "" {
+		if _, ok := diff.GetOk("duration"); !ok && diff.NewValueKnown("duration") {
+			return fmt.Errorf("`duration` is required when `rrule` is set")
+		}
+	}
+
+	if diff.Id() != "" && !diff.HasChanges(sloCorrectionScheduleFields...) {
+		return nil
+	}
+	for _, field := range sloCorrectionScheduleFields {
+		if !diff.NewValueKnown(field) {
+			return diff.SetNewComputed("next_occurrences")
+		}
+	}
+	providerConf := meta.(*ProviderConfiguration)
+	occurrences, err := buildSloCorrectionOccurrences(diff, providerConf.Now())
+	if err != nil {
+		return err
+	}
+	return diff.SetNew("next_occurrences", occurrences)
+}
+
+// buildSloCorrectionOccurrences lists the correction windows which are not over at `now`
+func buildSloCorrectionOccurrences(d utils.Resource, now time.Time) ([]map[string]interface{}, error) {
+	occurrences := make([]map[string]interface{}, 0)
+	count := sloCorrectionDefaultOccurrencesCount
+	if c, ok := d.GetOk("next_occurrences_count"); ok {
+		count = c.(int)
+	}
+
+	location := time.UTC
+	if timezone, ok := d.GetOk("timezone"); ok {
+		loc, err := time.LoadLocation(timezone.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error loading timezone %q: %w", timezone, err)
+		}
+		location = loc
+	}
+	start := time.Unix(int64(d.Get("start").(int)), 0).In(location)
+
+	var windows []rrule.Window
+	if rule, ok := d.GetOk("rrule"); ok && rule.(string) != "" {
+		r, err := rrule.Parse(rule.(string))
+		if err != nil {
+			return nil, err
+		}
+		duration := time.Duration(d.Get("duration").(int)) * time.Second
+		windows = r.Windows(start, duration, now, count)
+	} else if end, ok := d.GetOk("end"); ok {
+		window := rrule.Window{Start: start, End: time.Unix(int64(end.(int)), 0).In(location)}
+		if window.End.After(now) {
+			windows = append(windows, window)
+		}
+	}
+
+	for _, window := range windows {
+		occurrences = append(occurrences, map[string]interface{}{
+			"start": window.Start.Format(time.RFC3339),
+			"end":   window.End.Format(time.RFC3339),
+		})
+	}
+	return occurrences, nil
+}
+
 func buildDatadogSloCorrection(d *schema.ResourceData) *datadogV1.SLOCorrectionCreateRequest {
 	result := datadogV1.NewSLOCorrectionCreateRequestWithDefaults()
 	// `type` is hardcoded to 'correction' in Data
@@ -151,10 +258,10 @@ func resourceDatadogSloCorrectionCreate(ctx context.Context, d *schema.ResourceD
 	sloCorrection := response.GetData()
 	d.SetId(sloCorrection.GetId())
 
-	return updateSLOCorrectionState(d, response.Data)
+	return updateSLOCorrectionState(d, providerConf.Now(), response.Data)
 }
 
-func updateSLOCorrectionState(d *schema.ResourceData, sloCorrectionData *datadogV1.SLOCorrection) diag.Diagnostics {
+func updateSLOCorrectionState(d *schema.ResourceData, now time.Time, sloCorrectionData *datadogV1.SLOCorrection) diag.Diagnostics {
 	if sloCorrectionAttributes, ok := sloCorrectionData.GetAttributesOk(); ok {
 		if category, ok := sloCorrectionAttributes.GetCategoryOk(); ok {
 			if err := d.Set("category", string(*category)); err != nil {
@@ -210,6 +317,13 @@ func updateSLOCorrectionState(d *schema.ResourceData, sloCorrectionData *datadog
 			}
 		}
 	}
+	occurrences, err := buildSloCorrectionOccurrences(d, now)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("next_occurrences", occurrences); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -233,7 +347,7 @@ func resourceDatadogSloCorrectionRead(ctx context.Context, d *schema.ResourceDat
 	if err := utils.CheckForUnparsed(sloCorrectionGetResp); err != nil {
 		return diag.FromErr(err)
 	}
-	return updateSLOCorrectionState(d, sloCorrectionGetResp.Data)
+	return updateSLOCorrectionState(d, providerConf.Now(), sloCorrectionGetResp.Data)
 }
 
 func resourceDatadogSloCorrectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -252,7 +366,7 @@ func resourceDatadogSloCorrectionUpdate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	return updateSLOCorrectionState(d, response.Data)
+	return updateSLOCorrectionState(d, providerConf.Now(), response.Data)
 }
 
 func resourceDatadogSloCorrectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -46,10 +46,28 @@ func resourceDatadogSyntheticsGlobalVariable() *schema.Resource {
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
 				"value": {
-					Description: "The value of the global variable.",
+					Description:  "The value of the global variable. Exactly one of `value` or `value_wo` must be set.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					ExactlyOneOf: []string{"value", "value_wo"},
+				},
+				"value_wo": {
+					Description: "The value of the global variable, which is never stored in the state. Changes to this value are only applied when `value_version` changes.",
 					Type:        schema.TypeString,
-					Required:    true,
+					Optional:    true,
 					Sensitive:   true,
+					StateFunc: func(_ interface{}) string {
+						// the value is only read from the configuration
+						return ""
+					},
+					RequiredWith: []string{"value_version"},
+				},
+				"value_version": {
+					Description:  "Version of `value_wo`. Changing it updates the value of the global variable from `value_wo`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					RequiredWith: []string{"value_wo"},
 				},
 				"secure": {
 					Description: "If set to true, the value of the global variable is hidden.",
@@ -263,7 +281,9 @@ func buildSyntheticsGlobalVariableRequestStruct(d *schema.ResourceData) *datadog
 
 	syntheticsGlobalVariableValue := datadogV1.SyntheticsGlobalVariableValue{}
 
-	syntheticsGlobalVariableValue.SetValue(d.Get("value").(string))
+	if value, ok := getSyntheticsGlobalVariableValue(d); ok {
+		syntheticsGlobalVariableValue.SetValue(value)
+	}
 	syntheticsGlobalVariableValue.SetSecure(d.Get("secure").(bool))
 
 	if _, ok := d.GetOk("options.0"); ok {
@@ -322,13 +342,32 @@ func buildSyntheticsGlobalVariableRequestStruct(d *schema.ResourceData) *datadog
 	return syntheticsGlobalVariableRequest
 }
 
+// getSyntheticsGlobalVariableValue returns the value of the variable and whether it must be sent. `value_wo` is read
+// from the configuration since it is never stored in the state, and only sent when `value_version` changes.
+func getSyntheticsGlobalVariableValue(d *schema.ResourceData) (string, bool) {
+	if _, ok := d.GetOk("value_version"); ok {
+		if !d.HasChange("value_version") {
+			return "", false
+		}
+		if config := d.GetRawConfig(); !config.IsNull() {
+			if valueWO := config.GetAttr("value_wo"); !valueWO.IsNull() && valueWO.IsKnown() {
+				return valueWO.AsString(), true
+			}
+		}
+	}
+	return d.Get("value").(string), true
+}
+
 func updateSyntheticsGlobalVariableLocalState(d *schema.ResourceData, syntheticsGlobalVariable *datadogV1.SyntheticsGlobalVariable) diag.Diagnostics {
 	d.Set("name", syntheticsGlobalVariable.GetName())
 	d.Set("description", syntheticsGlobalVariable.GetDescription())
 
 	syntheticsGlobalVariableValue := syntheticsGlobalVariable.GetValue()
 
-	if syntheticsGlobalVariableValue.GetSecure() {
+	if _, ok := d.GetOk("value_version"); ok {
+		// the value is write-only and must not be stored in the state
+		d.Set("value", "")
+	} else if syntheticsGlobalVariableValue.GetSecure() {
 		// if the global variable is secure we need to get the value
 		// from the config since it will not be returned by the api
 		d.Set("value", d.Get("value").(string))
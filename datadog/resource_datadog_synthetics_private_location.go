@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 
@@ -48,6 +51,24 @@ func resourceDatadogSyntheticsPrivateLocation() *schema.Resource {
 					Computed:    true,
 					Sensitive:   true,
 				},
+				"helm_values": {
+					Description: "Values for the `synthetics-private-location` Helm chart containing the worker configuration. Only available for private locations created by Terraform.",
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+				},
+				"kubernetes_secret": {
+					Description: "Kubernetes Secret manifest containing the worker configuration in its `worker-config.json` key. Only available for private locations created by Terraform.",
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+				},
+				"docker_env": {
+					Description: "Docker env-file lines configuring the worker through `DATADOG_*` environment variables. Line breaks in values are escaped as `\\n`. Only available for private locations created by Terraform.",
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+				},
 				"metadata": {
 					Type:        schema.TypeList,
 					MaxItems:    1,
@@ -114,6 +135,9 @@ func resourceDatadogSyntheticsPrivateLocationCreate(ctx context.Context, d *sche
 	// set the config that is only returned when creating the private location
 	conf, _ := json.Marshal(createdSyntheticsPrivateLocationResponse.GetConfig())
 	d.Set("config", string(conf))
+	if diags := setSyntheticsPrivateLocationWorkerConfig(d, createdSyntheticsPrivateLocationResponse.GetConfig()); diags.HasError() {
+		return diags
+	}
 
 	// Return the read function to ensure the state is reflected in the terraform.state file
 	return resourceDatadogSyntheticsPrivateLocationRead(ctx, d, meta)
@@ -219,3 +243,86 @@ func updateSyntheticsPrivateLocationLocalState(d *schema.ResourceData, synthetic
 
 	return nil
 }
+
+// setSyntheticsPrivateLocationWorkerConfig renders the worker configuration, only returned when creating the private
+// location, in formats ready to be used by Helm, Kubernetes and Docker.
+func setSyntheticsPrivateLocationWorkerConfig(d *schema.ResourceData, config map[string]interface{}) diag.Diagnostics {
+	prettyConfig, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("helm_values", "configFile: |\n"+indentLines(string(prettyConfig), "  ")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	secretName := strings.Trim(regexp.MustCompile(`[^a-z0-9-]+`).ReplaceAllString(strings.ToLower(d.Get("name").(string)), "-"), "-")
+	if secretName == "" {
+		secretName = "synthetics-private-location"
+	}
+	secret := fmt.Sprintf("apiVersion: v1\nkind: Secret\nmetadata:\n  name: %s-worker-config\ntype: Opaque\nstringData:\n  worker-config.json: |\n%s",
+		secretName, indentLines(string(prettyConfig), "    "))
+	if err := d.Set("kubernetes_secret", secret); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("docker_env", buildSyntheticsPrivateLocationDockerEnv(config)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// buildSyntheticsPrivateLocationDockerEnv converts worker options to environment variables: `publicKey.pem` is
+// set through `DATADOG_PUBLIC_KEY_PEM`, `datadogApiKey` through `DATADOG_API_KEY` and the private location `id`
+// through `DATADOG_LOCATION`.
+func buildSyntheticsPrivateLocationDockerEnv(config map[string]interface{}) string {
+	env := make(map[string]string)
+	var addOption func(name string, value interface{})
+	addOption = func(name string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, nested := range v {
+				addOption(name+"_"+key, nested)
+			}
+		case string:
+			env[name] = strings.ReplaceAll(v, "\n", `\n`)
+		default:
+			encoded, _ := json.Marshal(v)
+			env[name] = string(encoded)
+		}
+	}
+	for key, value := range config {
+		if key == "id" {
+			key = "location"
+		}
+		key = strings.TrimPrefix(key, "datadog")
+		addOption(key, value)
+	}
+
+	lines := make([]string, 0, len(env))
+	for name, value := range env {
+		lines = append(lines, "DATADOG_"+toEnvVariableName(name)+"="+value)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// toEnvVariableName converts a camelCase option path to SCREAMING_SNAKE_CASE, for example `publicKey_pem` to `PUBLIC_KEY_PEM`
+func toEnvVariableName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' && i > 0 && name[i-1] != '_' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+func indentLines(s, indent string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = indent + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
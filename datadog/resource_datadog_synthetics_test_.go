@@ -3,29 +3,34 @@
 package datadog
 
 import (
-	"bytes"
-	"compress/zlib"
 	"context"
-	b64 "encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	_nethttp "net/http"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/syntheticsbrowser"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/syntheticsmobile"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
 	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/validators"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// syntheticsMobileTestPath is the endpoint of mobile tests, which are not modeled by the API client
+const syntheticsMobileTestPath = "/api/v1/synthetics/tests/mobile"
+
 /*
  * Resource
  */
@@ -37,6 +42,7 @@ func resourceDatadogSyntheticsTest() *schema.Resource {
 		ReadContext:   resourceDatadogSyntheticsTestRead,
 		UpdateContext: resourceDatadogSyntheticsTestUpdate,
 		DeleteContext: resourceDatadogSyntheticsTestDelete,
+		CustomizeDiff: customdiff.All(syntheticsTestDefaultsDiff, syntheticsTestStepTemplateDiff, syntheticsTestVariablesDiff),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -68,7 +74,7 @@ func resourceDatadogSyntheticsTest() *schema.Resource {
 					Optional:    true,
 					Elem:        syntheticsTestRequest(),
 				},
-				"request_headers":            syntheticsTestRequestHeaders(),
+				"request_headers":            syntheticsTestInheritedFromDefaults(syntheticsTestRequestHeaders()),
 				"request_query":              syntheticsTestRequestQuery(),
 				"request_basicauth":          syntheticsTestRequestBasicAuth(),
 				"request_proxy":              syntheticsTestRequestProxy(),
@@ -83,6 +89,15 @@ func resourceDatadogSyntheticsTest() *schema.Resource {
 					Type:        schema.TypeString,
 					Optional:    true,
 				},
+				"check_variables": {
+					Description: "If set to `true`, the variables referenced by the steps of multistep API tests are checked during plan against `config_variable` blocks and the variables extracted by previous steps.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+						// This is never sent to the backend, so it should never generate a diff
+						return true
+					},
+				},
 				"device_ids": {
 					Description: "Required if `type = \"browser\"`. Array with the different device IDs used to run the test.",
 					Type:        schema.TypeList,
@@ -93,14 +108,15 @@ func resourceDatadogSyntheticsTest() *schema.Resource {
 					},
 				},
 				"locations": {
-					Description: "Array of locations used to run the test. Refer to [the Datadog Synthetics location data source](https://registry.terraform.io/providers/DataDog/datadog/latest/docs/data-sources/synthetics_locations) to retrieve the list of locations.",
+					Description: "Array of locations used to run the test. Required unless set in the provider `synthetics_defaults` block. Refer to [the Datadog Synthetics location data source](https://registry.terraform.io/providers/DataDog/datadog/latest/docs/data-sources/synthetics_locations) to retrieve the list of locations.",
 					Type:        schema.TypeSet,
-					Required:    true,
+					Optional:    true,
+					Computed:    true,
 					Elem: &schema.Schema{
 						Type: schema.TypeString,
 					},
 				},
-				"options_list": syntheticsTestOptionsList(),
+				"options_list": syntheticsTestInheritedFromDefaults(syntheticsTestOptionsList()),
 				"name": {
 					Description: "Name of Datadog synthetics test.",
 					Type:        schema.TypeString,
@@ -132,8 +148,16 @@ func resourceDatadogSyntheticsTest() *schema.Resource {
 					Type:        schema.TypeInt,
 					Computed:    true,
 				},
-				"browser_step": syntheticsTestBrowserStep(),
-				"api_step":     syntheticsTestAPIStep(),
+				"browser_step":        syntheticsTestBrowserStep(),
+				"api_step":            syntheticsTestAPIStep(),
+				"mobile_step":         syntheticsTestMobileStep(),
+				"mobile_options_list": syntheticsMobileTestOptionsList(),
+				"config_initial_application_arguments": {
+					Description: "Initial arguments passed to the application of a mobile test on launch.",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
 				"set_cookie": {
 					Description: "Cookies to be used for a browser test request, using the [Set-Cookie](https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie) syntax.",
 					Type:        schema.TypeString,
@@ -311,10 +335,16 @@ func syntheticsTestRequestBasicAuth() *schema.Schema {
 					Optional:    true,
 				},
 				"password": {
-					Description: "Password for authentication.",
+					Description:      "Password for authentication. Only its hash is stored in state.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Sensitive:        true,
+					DiffSuppressFunc: syntheticsSecretDiffSuppress,
+				},
+				"password_hash": {
+					Description: "SHA256 hash of the password, stored in state instead of the password.",
 					Type:        schema.TypeString,
-					Optional:    true,
-					Sensitive:   true,
+					Computed:    true,
 				},
 				"access_key": {
 					Type:        schema.TypeString,
@@ -388,10 +418,16 @@ func syntheticsTestRequestBasicAuth() *schema.Schema {
 					Optional:    true,
 				},
 				"client_secret": {
+					Type:             schema.TypeString,
+					Description:      "Client secret for `oauth-client` or `oauth-rop` authentication. Only its hash is stored in state.",
+					Optional:         true,
+					Sensitive:        true,
+					DiffSuppressFunc: syntheticsSecretDiffSuppress,
+				},
+				"client_secret_hash": {
 					Type:        schema.TypeString,
-					Description: "Client secret for `oauth-client` or `oauth-rop` authentication.",
-					Optional:    true,
-					Sensitive:   true,
+					Description: "SHA256 hash of the client secret, stored in state instead of the client secret.",
+					Computed:    true,
 				},
 			},
 		},
@@ -924,6 +960,12 @@ func syntheticsTestAPIStep() *schema.Schema {
 					Type:        schema.TypeInt,
 					Optional:    true,
 				},
+				"step_template": {
+					Description:  "JSON definition of the step, as returned by the API, used instead of the other attributes of the step except `name`, which can't be set along with it. This allows sharing common steps, such as an authentication, across tests.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsJSON,
+				},
 			},
 		},
 	}
@@ -1210,6 +1252,324 @@ func syntheticsBrowserStepParams() schema.Schema {
 	}
 }
 
+func syntheticsMobileTestOptionsList() *schema.Schema {
+	// monitoring and scheduling options are shared with API and browser tests
+	commonOptions := syntheticsTestOptionsList().Elem.(*schema.Resource).Schema
+
+	return &schema.Schema{
+		Description: "Required if `type = \"mobile\"`. Options for the mobile application test.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tick_every": {
+					Description:  "How often the test should run (in seconds).",
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(300, 604800),
+				},
+				"scheduling":           commonOptions["scheduling"],
+				"min_failure_duration": commonOptions["min_failure_duration"],
+				"monitor_name":         commonOptions["monitor_name"],
+				"monitor_options":      commonOptions["monitor_options"],
+				"monitor_priority":     commonOptions["monitor_priority"],
+				"restricted_roles":     commonOptions["restricted_roles"],
+				"retry":                commonOptions["retry"],
+				"ci":                   commonOptions["ci"],
+				"no_screenshot":        commonOptions["no_screenshot"],
+				"device_ids": {
+					Description: "Array with the different device IDs used to run the test, for example `synthetics:mobile:device:iphone_15_ios_17`.",
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"mobile_application": {
+					Description: "Mobile application version tested.",
+					Type:        schema.TypeList,
+					Required:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"application_id": {
+								Description: "ID of the mobile application.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"reference_id": {
+								Description: "ID of the application version to test. Required if `reference_type = \"version\"`.",
+								Type:        schema.TypeString,
+								Optional:    true,
+							},
+							"reference_type": {
+								Description:  "Whether the test runs on the `latest` application version or on the version set in `reference_id`.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      "latest",
+								ValidateFunc: validation.StringInSlice([]string{"latest", "version"}, false),
+							},
+						},
+					},
+				},
+				"allow_application_crash": {
+					Description: "Whether the test passes when the application crashes.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"disable_auto_accept_alert": {
+					Description: "Whether system alerts, such as permission requests, are left for the test steps to handle instead of being accepted automatically.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"default_step_timeout": {
+					Description:  "Default timeout of the steps (in seconds).",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(1, 300),
+				},
+				"verbosity": {
+					Description:  "Verbosity level of the test results.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(0, 5),
+				},
+			},
+		},
+	}
+}
+
+func syntheticsTestMobileStep() *schema.Schema {
+	return &schema.Schema{
+		Description: "Steps for mobile application tests.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "Name of the step.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"type": {
+					Description:  "Type of the step.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(syntheticsmobile.StepTypes, false),
+				},
+				"allow_failure": {
+					Description: "Determines if the step should be allowed to fail.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"is_critical": {
+					Description: "Determines whether or not to consider the entire test as failed if this step fails. Can be used only if `allow_failure` is `true`.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"timeout": {
+					Description: "Used to override the default timeout of a step.",
+					Type:        schema.TypeInt,
+					Optional:    true,
+				},
+				"has_new_step_element": {
+					Description: "Whether the step uses an element which was not available when the test was recorded.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"no_screenshot": {
+					Description: "Prevents saving screenshots of the step.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"public_id": {
+					Description: "The identifier of the step on the backend.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"params": {
+					Description: "Parameters for the step.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Required:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"check": {
+								Description:  "Check type to use for an assertion step.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringInSlice([]string{"equals", "notEquals", "contains", "notContains", "startsWith", "notStartsWith", "greater", "lower", "greaterEquals", "lowerEquals", "matchRegex", "between", "isEmpty", "notIsEmpty"}, false),
+							},
+							"delay": {
+								Description: "Delay between each key stroke for a `typeText` step (in milliseconds).",
+								Type:        schema.TypeInt,
+								Optional:    true,
+							},
+							"direction": {
+								Description:  "Direction of a `scrollToElement` step, or orientation of a `rotate` step.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringInSlice([]string{"up", "down", "left", "right", "portrait", "landscape"}, false),
+							},
+							"element": syntheticsMobileStepElement(),
+							"enable": {
+								Description: "Whether a `toggleWiFi` step enables or disables the Wi-Fi.",
+								Type:        schema.TypeBool,
+								Optional:    true,
+							},
+							"max_scrolls": {
+								Description: "Maximum number of scrolls of a `scrollToElement` step.",
+								Type:        schema.TypeInt,
+								Optional:    true,
+							},
+							"subtest_public_id": {
+								Description: "ID of the test to play for a `playSubTest` step.",
+								Type:        schema.TypeString,
+								Optional:    true,
+							},
+							"value": {
+								Description: "Value of the step: text to type or to look for, deep link to open, or duration to wait (in seconds).",
+								Type:        schema.TypeString,
+								Optional:    true,
+							},
+							"variable": {
+								Description: "Variable extracted by an `extractVariable` step.",
+								Type:        schema.TypeList,
+								MaxItems:    1,
+								Optional:    true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"name": {
+											Description:  "Name of the extracted variable.",
+											Type:         schema.TypeString,
+											Required:     true,
+											ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z][A-Z0-9_]+[A-Z0-9]$`), "must be all uppercase with underscores"),
+										},
+										"example": {
+											Description: "Example of the extracted variable.",
+											Type:        schema.TypeString,
+											Optional:    true,
+										},
+									},
+								},
+							},
+							"with_enter": {
+								Description: "Whether a `typeText` step presses enter after typing the value.",
+								Type:        schema.TypeBool,
+								Optional:    true,
+							},
+							"x": {
+								Description: "Horizontal offset of a `flick` or `scroll` step.",
+								Type:        schema.TypeFloat,
+								Optional:    true,
+							},
+							"y": {
+								Description: "Vertical offset of a `flick` or `scroll` step.",
+								Type:        schema.TypeFloat,
+								Optional:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func syntheticsMobileStepElement() *schema.Schema {
+	return &schema.Schema{
+		Description: "Element targeted by the step.",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"context": {
+					Description: "Context of the element.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"context_type": {
+					Description:  "Type of the context of the element.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{"native", "web"}, false),
+				},
+				"element_description": {
+					Description: "Description of the element.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"multi_locator": {
+					Description: "Locators recorded for the element.",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"relative_position": {
+					Description: "Position of the interaction relative to the element.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"x": {
+								Type:     schema.TypeFloat,
+								Optional: true,
+							},
+							"y": {
+								Type:     schema.TypeFloat,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"text_content": {
+					Description: "Text content of the element.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"user_locator": {
+					Description: "Custom locator for the element.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"fail_test_on_cannot_locate": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"values": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"type": {
+											Type:         schema.TypeString,
+											Optional:     true,
+											ValidateFunc: validation.StringInSlice([]string{"accessibility-id", "id", "ios-predicate-string", "ios-class-chain", "xpath"}, false),
+										},
+										"value": {
+											Type:     schema.TypeString,
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"view_name": {
+					Description: "Name of the view of the element.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
 func syntheticsBrowserVariable() *schema.Schema {
 	return &schema.Schema{
 		Description: "Variables used for a browser test steps. Multiple `variable` blocks are allowed with the structure below.",
@@ -1268,9 +1628,15 @@ func syntheticsConfigVariable() *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"example": {
-					Description: "Example for the variable. This value is not returned by the api when `secure = true`. Avoid drift by only making updates to this value from within Terraform.",
+					Description:      "Example for the variable. This value is not returned by the api when `secure = true`. Avoid drift by only making updates to this value from within Terraform.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: syntheticsSecretDiffSuppress,
+				},
+				"example_hash": {
+					Description: "SHA256 hash of the example when `secure = true`, stored in state instead of the example.",
 					Type:        schema.TypeString,
-					Optional:    true,
+					Computed:    true,
 				},
 				"name": {
 					Description:  "Name of the variable.",
@@ -1279,9 +1645,15 @@ func syntheticsConfigVariable() *schema.Schema {
 					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z][A-Z0-9_]+[A-Z0-9]$`), "must be all uppercase with underscores"),
 				},
 				"pattern": {
-					Description: "Pattern of the variable. This value is not returned by the api when `secure = true`. Avoid drift by only making updates to this value from within Terraform.",
+					Description:      "Pattern of the variable. This value is not returned by the api when `secure = true`. Avoid drift by only making updates to this value from within Terraform.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: syntheticsSecretDiffSuppress,
+				},
+				"pattern_hash": {
+					Description: "SHA256 hash of the pattern when `secure = true`, stored in state instead of the pattern.",
 					Type:        schema.TypeString,
-					Optional:    true,
+					Computed:    true,
 				},
 				"type": {
 					Description:      "Type of test configuration variable.",
@@ -1335,6 +1707,267 @@ func syntheticsHttpVersionOption() *schema.Schema {
  * CRUD functions
  */
 
+// syntheticsTestInheritedFromDefaults marks an attribute whose planned value can come from the provider `synthetics_defaults` block
+func syntheticsTestInheritedFromDefaults(attribute *schema.Schema) *schema.Schema {
+	attribute.Computed = true
+	return attribute
+}
+
+// custom diff function that changes plan to take the provider synthetics defaults into account
+func syntheticsTestDefaultsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	defaults := meta.(*ProviderConfiguration).SyntheticsDefaults
+
+	if locationsConfig := config.GetAttr("locations"); locationsConfig.IsNull() {
+		locations, _ := defaults["locations"].(*schema.Set)
+		if locations == nil || locations.Len() == 0 {
+			return fmt.Errorf("`locations` must be set, either on the test or in the provider `synthetics_defaults` block")
+		}
+		if err := d.SetNew("locations", schema.NewSet(schema.HashString, locations.List())); err != nil {
+			return fmt.Errorf("error setting locations diff: %w", err)
+		}
+	}
+
+	// Attributes inherited from the defaults are computed, so removing them from the configuration
+	// must explicitly plan their removal
+	if headersConfig := config.GetAttr("request_headers"); headersConfig.IsNull() {
+		// Headers are sent by every test type, but only HTTP and browser tests inherit the default headers
+		testType := d.Get("type").(string)
+		subtype := d.Get("subtype").(string)
+		inheritsHeaders := testType == string(datadogV1.SYNTHETICSTESTDETAILSTYPE_BROWSER) ||
+			(testType == string(datadogV1.SYNTHETICSTESTDETAILSTYPE_API) && (subtype == "" || subtype == string(datadogV1.SYNTHETICSTESTDETAILSSUBTYPE_HTTP)))
+		headers := map[string]interface{}{}
+		if defaultHeaders, ok := defaults["request_headers"].(map[string]interface{}); ok && inheritsHeaders {
+			headers = defaultHeaders
+		}
+		if err := d.SetNew("request_headers", headers); err != nil {
+			return fmt.Errorf("error setting request_headers diff: %w", err)
+		}
+	}
+
+	optionsConfig := config.GetAttr("options_list")
+	if !optionsConfig.IsKnown() {
+		return nil
+	}
+	optionsList := d.Get("options_list").([]interface{})
+	if optionsConfig.IsNull() || optionsConfig.LengthInt() == 0 {
+		if len(optionsList) > 0 {
+			if err := d.SetNew("options_list", []interface{}{}); err != nil {
+				return fmt.Errorf("error setting options_list diff: %w", err)
+			}
+		}
+		return nil
+	}
+	if len(optionsList) == 0 || optionsList[0] == nil {
+		return nil
+	}
+
+	optionConfig := optionsConfig.AsValueSlice()[0]
+	options := optionsList[0].(map[string]interface{})
+	inherited := false
+	for _, key := range []string{"retry", "monitor_options"} {
+		keyConfig := optionConfig.GetAttr(key)
+		if !keyConfig.IsKnown() || (!keyConfig.IsNull() && keyConfig.LengthInt() > 0) {
+			continue
+		}
+		if value, ok := defaults[key].([]interface{}); ok && len(value) > 0 && value[0] != nil {
+			options[key] = value
+			inherited = true
+		}
+	}
+	if inherited {
+		if err := d.SetNew("options_list", optionsList); err != nil {
+			return fmt.Errorf("error setting options_list diff: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// syntheticsVariableReferenceRegex matches references to variables, e.g. `{{ TOKEN }}`. Built-in functions such as
+// `{{ numeric(3) }}` are not matched.
+var syntheticsVariableReferenceRegex = regexp.MustCompile(`{{\s*([A-Z][A-Z0-9_]*)\s*}}`)
+
+// custom diff function that checks the steps using `step_template` don't set the attributes it overrides, and that
+// their template is a valid API test step
+func syntheticsTestStepTemplateDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.GetAttr("api_step").IsKnown() || config.GetAttr("api_step").IsNull() {
+		return nil
+	}
+
+	overriddenKeys := make([]string, 0)
+	for key := range syntheticsTestAPIStep().Elem.(*schema.Resource).Schema {
+		if key != "name" && key != "step_template" {
+			overriddenKeys = append(overriddenKeys, key)
+		}
+	}
+	sort.Strings(overriddenKeys)
+
+	var errs []string
+	for i, stepConfig := range config.GetAttr("api_step").AsValueSlice() {
+		stepTemplate := stepConfig.GetAttr("step_template")
+		if !stepTemplate.IsKnown() || stepTemplate.IsNull() || stepTemplate.AsString() == "" {
+			continue
+		}
+		for _, key := range overriddenKeys {
+			value := stepConfig.GetAttr(key)
+			if value.IsNull() || (value.IsKnown() && value.Type().IsCollectionType() && value.LengthInt() == 0) {
+				continue
+			}
+			errs = append(errs, fmt.Sprintf("api_step %d: `%s` conflicts with `step_template`", i, key))
+		}
+		var step datadogV1.SyntheticsAPIStep
+		if err := json.Unmarshal([]byte(stepTemplate.AsString()), &step); err != nil || utils.CheckForUnparsed(step) != nil {
+			errs = append(errs, fmt.Sprintf("api_step %d: `step_template` is not a valid API test step", i))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid api steps:\n%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// custom diff function that checks the variables referenced by the steps of multistep API tests are defined, when
+// `check_variables` is set
+func syntheticsTestVariablesDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if check, ok := d.GetOk("check_variables"); !ok || !check.(bool) {
+		return nil
+	}
+	if d.Get("type").(string) != string(datadogV1.SYNTHETICSTESTDETAILSTYPE_API) || d.Get("subtype").(string) != "multi" {
+		return nil
+	}
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.GetAttr("config_variable").IsWhollyKnown() || !config.GetAttr("api_step").IsKnown() {
+		return nil
+	}
+	if stepsConfig := config.GetAttr("api_step"); !stepsConfig.IsNull() {
+		for _, stepConfig := range stepsConfig.AsValueSlice() {
+			if !stepConfig.GetAttr("extracted_value").IsWhollyKnown() || !stepConfig.GetAttr("step_template").IsKnown() {
+				return nil
+			}
+		}
+	}
+
+	steps := d.Get("api_step").([]interface{})
+	stepTemplates := make([]map[string]interface{}, len(steps))
+	for i, s := range steps {
+		stepMap, _ := s.(map[string]interface{})
+		if stepTemplate, _ := stepMap["step_template"].(string); stepTemplate != "" {
+			// Invalid templates are reported by syntheticsTestStepTemplateDiff
+			json.Unmarshal([]byte(stepTemplate), &stepTemplates[i])
+		}
+	}
+
+	// Variables defined in a script can't be known before running the test
+	if d.Get("variables_from_script").(string) != "" {
+		return nil
+	}
+
+	definedVariables := make(map[string]bool)
+	for _, v := range d.Get("config_variable").([]interface{}) {
+		if variable, ok := v.(map[string]interface{}); ok {
+			definedVariables[variable["name"].(string)] = true
+		}
+	}
+	extractingSteps := make(map[string]int)
+	for i, s := range steps {
+		stepMap, _ := s.(map[string]interface{})
+		for _, name := range syntheticsAPIStepExtractedValueNames(stepMap, stepTemplates[i]) {
+			if _, ok := extractingSteps[name]; !ok {
+				extractingSteps[name] = i
+			}
+		}
+	}
+
+	var errs []string
+	for i, s := range steps {
+		stepMap, _ := s.(map[string]interface{})
+		for _, name := range syntheticsAPIStepVariableReferences(stepMap, stepTemplates[i]) {
+			if definedVariables[name] {
+				continue
+			}
+			if extractingStep, ok := extractingSteps[name]; !ok {
+				errs = append(errs, fmt.Sprintf("api_step %d (%q) references undefined variable %s: declare it with a `config_variable` block, which is also required for global variables, or extract it in a previous step", i, stepMap["name"], name))
+			} else if extractingStep >= i {
+				errs = append(errs, fmt.Sprintf("api_step %d (%q) references variable %s, which is only extracted by api_step %d", i, stepMap["name"], name, extractingStep))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid variable references:\n%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// syntheticsAPIStepExtractedValueNames returns the names of the variables extracted by a step, from its template if it has one
+func syntheticsAPIStepExtractedValueNames(stepMap map[string]interface{}, stepTemplate map[string]interface{}) []string {
+	var extractedValues []interface{}
+	if stepTemplate != nil {
+		extractedValues, _ = stepTemplate["extractedValues"].([]interface{})
+	} else {
+		extractedValues, _ = stepMap["extracted_value"].([]interface{})
+	}
+
+	var names []string
+	for _, v := range extractedValues {
+		if extractedValue, ok := v.(map[string]interface{}); ok {
+			if name, ok := extractedValue["name"].(string); ok && name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// syntheticsAPIStepVariableReferences returns the names of the variables referenced by a step, sorted and deduplicated
+func syntheticsAPIStepVariableReferences(stepMap map[string]interface{}, stepTemplate map[string]interface{}) []string {
+	step := stepMap
+	ignoredKeys := map[string]bool{"name": true, "extracted_value": true, "step_template": true}
+	if stepTemplate != nil {
+		step = stepTemplate
+		ignoredKeys = map[string]bool{"name": true, "extractedValues": true}
+	}
+
+	references := make(map[string]bool)
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			for _, match := range syntheticsVariableReferenceRegex.FindAllStringSubmatch(v, -1) {
+				references[match[1]] = true
+			}
+		case map[string]interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		case []interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		case *schema.Set:
+			collect(v.List())
+		}
+	}
+	for key, value := range step {
+		if !ignoredKeys[key] {
+			collect(value)
+		}
+	}
+
+	names := make([]string, 0, len(references))
+	for name := range references {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func resourceDatadogSyntheticsTestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConf := meta.(*ProviderConfiguration)
 	apiInstances := providerConf.DatadogApiInstances
@@ -1409,9 +2042,46 @@ func resourceDatadogSyntheticsTestCreate(ctx context.Context, d *schema.Resource
 			return diag.FromErr(err)
 		}
 
-		d.SetId(getSyntheticsBrowserTestResponse.GetPublicId())
+		d.SetId(getSyntheticsBrowserTestResponse.GetPublicId())
+
+		return updateSyntheticsBrowserTestLocalState(d, &getSyntheticsBrowserTestResponse)
+	} else if *testType == datadogV1.SYNTHETICSTESTDETAILSTYPE_MOBILE {
+		syntheticsTest := buildDatadogSyntheticsMobileTest(d)
+		respByte, httpResponse, err := utils.SendRequest(auth, apiInstances.HttpClient, "POST", syntheticsMobileTestPath, syntheticsTest)
+		if err != nil {
+			// Note that Id won't be set, so no state will be saved.
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error creating synthetics mobile test")
+		}
+		createdSyntheticsTest, err := utils.ConvertResponseByteToMap(respByte)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		publicID, _ := createdSyntheticsTest["public_id"].(string)
+
+		var getSyntheticsMobileTestResponse map[string]interface{}
+		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+			respByte, httpResponseGet, err := utils.SendRequest(auth, apiInstances.HttpClient, "GET", syntheticsMobileTestPath+"/"+publicID, nil)
+			if err != nil {
+				if httpResponseGet != nil && httpResponseGet.StatusCode == 404 {
+					return retry.RetryableError(fmt.Errorf("synthetics mobile test not created yet"))
+				}
+
+				return retry.NonRetryableError(err)
+			}
+			getSyntheticsMobileTestResponse, err = utils.ConvertResponseByteToMap(respByte)
+			if err != nil {
+				return retry.NonRetryableError(err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(publicID)
 
-		return updateSyntheticsBrowserTestLocalState(d, &getSyntheticsBrowserTestResponse)
+		return updateSyntheticsMobileTestLocalState(d, getSyntheticsMobileTestResponse)
 	}
 
 	return diag.Errorf("unrecognized synthetics test type %v", testType)
@@ -1428,7 +2098,7 @@ func resourceDatadogSyntheticsTestRead(ctx context.Context, d *schema.ResourceDa
 	var err error
 	var httpresp *_nethttp.Response
 
-	// get the generic test to detect if it's an api or browser test
+	// get the generic test to detect if it's an api, browser or mobile test
 	syntheticsTest, httpresp, err = apiInstances.GetSyntheticsApiV1().GetTest(auth, d.Id())
 	if err != nil {
 		if httpresp != nil && httpresp.StatusCode == 404 {
@@ -1438,6 +2108,24 @@ func resourceDatadogSyntheticsTestRead(ctx context.Context, d *schema.ResourceDa
 		}
 		return utils.TranslateClientErrorDiag(err, httpresp, "error getting synthetics test")
 	}
+
+	// mobile tests options and steps are not modeled by the API client, they are read as raw JSON
+	if syntheticsTest.GetType() == datadogV1.SYNTHETICSTESTDETAILSTYPE_MOBILE {
+		respByte, httpresp, err := utils.SendRequest(auth, apiInstances.HttpClient, "GET", syntheticsMobileTestPath+"/"+d.Id(), nil)
+		if err != nil {
+			if httpresp != nil && httpresp.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+			return utils.TranslateClientErrorDiag(err, httpresp, "error getting synthetics mobile test")
+		}
+		syntheticsMobileTest, err := utils.ConvertResponseByteToMap(respByte)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return updateSyntheticsMobileTestLocalState(d, syntheticsMobileTest)
+	}
+
 	if err := utils.CheckForUnparsed(syntheticsTest); err != nil {
 		return diag.FromErr(err)
 	}
@@ -1499,6 +2187,18 @@ func resourceDatadogSyntheticsTestUpdate(ctx context.Context, d *schema.Resource
 			return diag.FromErr(err)
 		}
 		return updateSyntheticsBrowserTestLocalState(d, &updatedTest)
+	} else if *testType == datadogV1.SYNTHETICSTESTDETAILSTYPE_MOBILE {
+		syntheticsTest := buildDatadogSyntheticsMobileTest(d)
+		respByte, httpResponse, err := utils.SendRequest(auth, apiInstances.HttpClient, "PUT", syntheticsMobileTestPath+"/"+d.Id(), syntheticsTest)
+		if err != nil {
+			// If the Update callback returns with or without an error, the full state is saved.
+			return utils.TranslateClientErrorDiag(err, httpResponse, "error updating synthetics mobile test")
+		}
+		updatedTest, err := utils.ConvertResponseByteToMap(respByte)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return updateSyntheticsMobileTestLocalState(d, updatedTest)
 	}
 
 	return diag.Errorf("unrecognized synthetics test type %v", testType)
@@ -1549,6 +2249,7 @@ func updateSyntheticsBrowserTestLocalState(d *schema.ResourceData, syntheticsTes
 
 	if basicAuth, ok := actualRequest.GetBasicAuthOk(); ok && basicAuth.SyntheticsBasicAuthWeb != nil {
 		localAuth := buildTerraformBasicAuth(basicAuth)
+		buildTerraformBasicAuthHashes(localAuth, d.Get("request_basicauth").([]interface{}))
 
 		if err := d.Set("request_basicauth", []map[string]string{localAuth}); err != nil {
 			return diag.FromErr(err)
@@ -1635,66 +2336,20 @@ func updateSyntheticsBrowserTestLocalState(d *schema.ResourceData, syntheticsTes
 	var localSteps []map[string]interface{}
 
 	for stepIndex, step := range steps {
-		localStep := make(map[string]interface{})
-		localStep["name"] = step.GetName()
-		localStep["type"] = string(step.GetType())
-		localStep["timeout"] = step.GetTimeout()
-
-		if allowFailure, ok := step.GetAllowFailureOk(); ok {
-			localStep["allow_failure"] = allowFailure
-		}
-
-		if isCritical, ok := step.GetIsCriticalOk(); ok {
-			localStep["is_critical"] = isCritical
-		}
-		if hasNoScreenshot, ok := step.GetNoScreenshotOk(); ok {
-			localStep["no_screenshot"] = hasNoScreenshot
-		}
-
-		localParams := make(map[string]interface{})
+		localStep := syntheticsbrowser.BuildTerraformStep(step)
 
 		forceElementUpdate, ok := d.GetOk(fmt.Sprintf("browser_step.%d.force_element_update", stepIndex))
 		if ok {
 			localStep["force_element_update"] = forceElementUpdate
 		}
 
-		params := step.GetParams()
-		paramsMap := params.(map[string]interface{})
-
-		for key, value := range paramsMap {
-			if key == "element" && forceElementUpdate == true {
-				// prevent overriding `element` in the local state with the one received from the backend, and
-				// keep the element from the local state instead
-				element := d.Get(fmt.Sprintf("browser_step.%d.params.0.element", stepIndex))
-				localParams["element"] = element
-			} else {
-				localParams[convertStepParamsKey(key)] = convertStepParamsValueForState(convertStepParamsKey(key), value)
-			}
-		}
-
-		// If received an element from the backend, extract the user locator part to update the local state
-		if elementParams, ok := paramsMap["element"]; ok {
-			serializedElementParams := convertStepParamsValueForState("element", elementParams)
-			var stepElement interface{}
-			utils.GetMetadataFromJSON([]byte(serializedElementParams.(string)), &stepElement)
-			if elementUserLocator, ok := stepElement.(map[string]interface{})["userLocator"]; ok {
-				userLocator := elementUserLocator.(map[string]interface{})
-				values := userLocator["values"]
-				value := values.([]interface{})[0]
-
-				localElementUserLocator := map[string]interface{}{
-					"fail_test_on_cannot_locate": userLocator["failTestOnCannotLocate"],
-					"value": []map[string]interface{}{
-						value.(map[string]interface{}),
-					},
-				}
-
-				localParams["element_user_locator"] = []map[string]interface{}{localElementUserLocator}
-			}
+		localParams := localStep["params"].([]interface{})[0].(map[string]interface{})
+		if _, ok := localParams["element"]; ok && forceElementUpdate == true {
+			// prevent overriding `element` in the local state with the one received from the backend, and
+			// keep the element from the local state instead
+			localParams["element"] = d.Get(fmt.Sprintf("browser_step.%d.params.0.element", stepIndex))
 		}
 
-		localStep["params"] = []interface{}{localParams}
-
 		localSteps = append(localSteps, localStep)
 	}
 
@@ -1720,6 +2375,70 @@ func updateSyntheticsBrowserTestLocalState(d *schema.ResourceData, syntheticsTes
 	return nil
 }
 
+func updateSyntheticsMobileTestLocalState(d *schema.ResourceData, syntheticsTest map[string]interface{}) diag.Diagnostics {
+	if err := d.Set("type", syntheticsTest["type"]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	config, _ := syntheticsTest["config"].(map[string]interface{})
+
+	var configVariables []datadogV1.SyntheticsConfigVariable
+	if variables, ok := config["variables"]; ok && variables != nil {
+		serializedVariables, _ := json.Marshal(variables)
+		if err := json.Unmarshal(serializedVariables, &configVariables); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	oldConfigVariables := d.Get("config_variable").([]interface{})
+	if err := d.Set("config_variable", buildTerraformConfigVariables(configVariables, oldConfigVariables)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("config_initial_application_arguments", config["initialApplicationArguments"]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("locations", syntheticsTest["locations"]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	options, _ := syntheticsTest["options"].(map[string]interface{})
+	if err := d.Set("mobile_options_list", buildTerraformMobileTestOptions(options)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	steps, _ := syntheticsTest["steps"].([]interface{})
+	localSteps := make([]map[string]interface{}, 0, len(steps))
+	for _, s := range steps {
+		step, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		localSteps = append(localSteps, syntheticsmobile.BuildTerraformStep(step))
+	}
+	if err := d.Set("mobile_step", localSteps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", syntheticsTest["name"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("message", syntheticsTest["message"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", syntheticsTest["status"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", syntheticsTest["tags"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if monitorID, ok := syntheticsTest["monitor_id"].(float64); ok {
+		if err := d.Set("monitor_id", int(monitorID)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
 func updateSyntheticsAPITestLocalState(d *schema.ResourceData, syntheticsTest *datadogV1.SyntheticsAPITest) diag.Diagnostics {
 	if err := d.Set("type", syntheticsTest.GetType()); err != nil {
 		return diag.FromErr(err)
@@ -1751,6 +2470,7 @@ func updateSyntheticsAPITestLocalState(d *schema.ResourceData, syntheticsTest *d
 
 	if basicAuth, ok := actualRequest.GetBasicAuthOk(); ok {
 		localAuth := buildTerraformBasicAuth(basicAuth)
+		buildTerraformBasicAuthHashes(localAuth, d.Get("request_basicauth").([]interface{}))
 
 		if err := d.Set("request_basicauth", []map[string]string{localAuth}); err != nil {
 			return diag.FromErr(err)
@@ -1812,6 +2532,11 @@ func updateSyntheticsAPITestLocalState(d *schema.ResourceData, syntheticsTest *d
 		localSteps := make([]interface{}, len(*steps))
 
 		for i, step := range *steps {
+			if stepTemplate := d.Get(fmt.Sprintf("api_step.%d.step_template", i)).(string); stepTemplate != "" {
+				localSteps[i] = buildTerraformAPIStepFromTemplate(step, stepTemplate, d.Get(fmt.Sprintf("api_step.%d.subtype", i)).(string))
+				continue
+			}
+
 			localStep := make(map[string]interface{})
 
 			if step.SyntheticsAPITestStep != nil {
@@ -1841,6 +2566,7 @@ func updateSyntheticsAPITestLocalState(d *schema.ResourceData, syntheticsTest *d
 
 				if basicAuth, ok := stepRequest.GetBasicAuthOk(); ok {
 					localAuth := buildTerraformBasicAuth(basicAuth)
+					buildTerraformBasicAuthHashes(localAuth, d.Get(fmt.Sprintf("api_step.%d.request_basicauth", i)).([]interface{}))
 					localStep["request_basicauth"] = []map[string]string{localAuth}
 				}
 
@@ -2012,13 +2738,14 @@ func buildDatadogSyntheticsAPITest(d *schema.ResourceData) *datadogV1.Synthetics
 		request.SetPersistCookies(attr.(bool))
 	}
 	if attr, ok := d.GetOk("request_definition.0.proto_json_descriptor"); ok {
-		request.SetCompressedJsonDescriptor(compressAndEncodeValue(attr.(string)))
+		request.SetCompressedJsonDescriptor(utils.CompressAndEncodeValue(attr.(string)))
 	}
 	if attr, ok := d.GetOk("request_definition.0.plain_proto_file"); ok {
-		request.SetCompressedProtoFile(compressAndEncodeValue(attr.(string)))
+		request.SetCompressedProtoFile(utils.CompressAndEncodeValue(attr.(string)))
 	}
 
-	request = *completeSyntheticsTestRequest(request, d.Get("request_headers").(map[string]interface{}), d.Get("request_query").(map[string]interface{}), d.Get("request_basicauth").([]interface{}), d.Get("request_client_certificate").([]interface{}), d.Get("request_proxy").([]interface{}), d.Get("request_metadata").(map[string]interface{}))
+	requestBasicAuths := withSyntheticsBasicAuthConfigSecrets(d.Get("request_basicauth").([]interface{}), syntheticsTestRawConfigPath(d, "request_basicauth"))
+	request = *completeSyntheticsTestRequest(request, d.Get("request_headers").(map[string]interface{}), d.Get("request_query").(map[string]interface{}), requestBasicAuths, d.Get("request_client_certificate").([]interface{}), d.Get("request_proxy").([]interface{}), d.Get("request_metadata").(map[string]interface{}))
 
 	config := datadogV1.NewSyntheticsAPITestConfigWithDefaults()
 
@@ -2032,7 +2759,7 @@ func buildDatadogSyntheticsAPITest(d *schema.ResourceData) *datadogV1.Synthetics
 		config.Assertions = assertions
 	}
 
-	requestConfigVariables := d.Get("config_variable").([]interface{})
+	requestConfigVariables := withSyntheticsConfigVariableConfigSecrets(d.Get("config_variable").([]interface{}), syntheticsTestRawConfigPath(d, "config_variable"))
 	config.SetConfigVariables(buildDatadogConfigVariables(requestConfigVariables))
 
 	if attr, ok := d.GetOk("variables_from_script"); ok && attr != nil {
@@ -2042,10 +2769,15 @@ func buildDatadogSyntheticsAPITest(d *schema.ResourceData) *datadogV1.Synthetics
 	if attr, ok := d.GetOk("api_step"); ok && syntheticsTest.GetSubtype() == "multi" {
 		steps := []datadogV1.SyntheticsAPIStep{}
 
-		for _, s := range attr.([]interface{}) {
+		for i, s := range attr.([]interface{}) {
 			step := datadogV1.SyntheticsAPIStep{}
 			stepMap := s.(map[string]interface{})
 
+			if stepTemplate, ok := stepMap["step_template"].(string); ok && stepTemplate != "" {
+				steps = append(steps, buildDatadogAPIStepFromTemplate(stepMap["name"].(string), stepTemplate))
+				continue
+			}
+
 			stepSubtype := stepMap["subtype"].(string)
 
 			if stepSubtype == "" || stepSubtype == "http" || stepSubtype == "grpc" {
@@ -2079,7 +2811,7 @@ func buildDatadogSyntheticsAPITest(d *schema.ResourceData) *datadogV1.Synthetics
 							request.SetCallType(datadogV1.SyntheticsTestCallType(v))
 						}
 						if v, ok := requestMap["plain_proto_file"].(string); ok && v != "" {
-							request.SetCompressedProtoFile(compressAndEncodeValue(v))
+							request.SetCompressedProtoFile(utils.CompressAndEncodeValue(v))
 						}
 					} else if step.SyntheticsAPITestStep.GetSubtype() == "http" {
 						request.SetUrl(requestMap["url"].(string))
@@ -2109,7 +2841,8 @@ func buildDatadogSyntheticsAPITest(d *schema.ResourceData) *datadogV1.Synthetics
 					}
 				}
 
-				request = *completeSyntheticsTestRequest(request, stepMap["request_headers"].(map[string]interface{}), stepMap["request_query"].(map[string]interface{}), stepMap["request_basicauth"].([]interface{}), stepMap["request_client_certificate"].([]interface{}), stepMap["request_proxy"].([]interface{}), stepMap["request_metadata"].(map[string]interface{}))
+				requestBasicAuths := withSyntheticsBasicAuthConfigSecrets(stepMap["request_basicauth"].([]interface{}), syntheticsTestRawConfigPath(d, "api_step", i, "request_basicauth"))
+				request = *completeSyntheticsTestRequest(request, stepMap["request_headers"].(map[string]interface{}), stepMap["request_query"].(map[string]interface{}), requestBasicAuths, stepMap["request_client_certificate"].([]interface{}), stepMap["request_proxy"].([]interface{}), stepMap["request_metadata"].(map[string]interface{}))
 
 				step.SyntheticsAPITestStep.SetRequest(request)
 
@@ -2201,11 +2934,14 @@ func buildDatadogSyntheticsBrowserTest(d *schema.ResourceData) *datadogV1.Synthe
 		}
 	}
 
-	if username, ok := d.GetOk("request_basicauth.0.username"); ok {
-		if password, ok := d.GetOk("request_basicauth.0.password"); ok {
+	if requestBasicAuths := withSyntheticsBasicAuthConfigSecrets(d.Get("request_basicauth").([]interface{}), syntheticsTestRawConfigPath(d, "request_basicauth")); len(requestBasicAuths) > 0 {
+		requestBasicAuth, _ := requestBasicAuths[0].(map[string]interface{})
+		username, _ := requestBasicAuth["username"].(string)
+		password, _ := requestBasicAuth["password"].(string)
+		if username != "" && password != "" {
 			basicAuth := datadogV1.NewSyntheticsBasicAuthWebWithDefaults()
-			basicAuth.SetPassword(password.(string))
-			basicAuth.SetUsername(username.(string))
+			basicAuth.SetPassword(password)
+			basicAuth.SetUsername(username)
 			request.SetBasicAuth(datadogV1.SyntheticsBasicAuthWebAsSyntheticsBasicAuth(basicAuth))
 		}
 	}
@@ -2268,7 +3004,7 @@ func buildDatadogSyntheticsBrowserTest(d *schema.ResourceData) *datadogV1.Synthe
 		}
 	}
 
-	requestConfigVariables := d.Get("config_variable").([]interface{})
+	requestConfigVariables := withSyntheticsConfigVariableConfigSecrets(d.Get("config_variable").([]interface{}), syntheticsTestRawConfigPath(d, "config_variable"))
 	config.SetConfigVariables(buildDatadogConfigVariables(requestConfigVariables))
 
 	if attr, ok := d.GetOk("set_cookie"); ok {
@@ -2318,12 +3054,12 @@ func buildDatadogSyntheticsBrowserTest(d *schema.ResourceData) *datadogV1.Synthe
 
 			params := make(map[string]interface{})
 			stepParams := stepMap["params"].([]interface{})[0]
-			stepTypeParams := getParamsKeysForStepType(step.GetType())
+			stepTypeParams := syntheticsbrowser.ParamsKeysForStepType(step.GetType())
 
 			for _, key := range stepTypeParams {
 				if stepMap, ok := stepParams.(map[string]interface{}); ok && stepMap[key] != "" {
 					convertedValue := convertStepParamsValueForConfig(step.GetType(), key, stepMap[key])
-					params[convertStepParamsKey(key)] = convertedValue
+					params[syntheticsbrowser.ConvertStepParamsKey(key)] = convertedValue
 				}
 			}
 
@@ -2358,6 +3094,216 @@ func buildDatadogSyntheticsBrowserTest(d *schema.ResourceData) *datadogV1.Synthe
 	return syntheticsTest
 }
 
+func buildDatadogSyntheticsMobileTest(d *schema.ResourceData) map[string]interface{} {
+	config := map[string]interface{}{
+		"variables": buildDatadogConfigVariables(withSyntheticsConfigVariableConfigSecrets(d.Get("config_variable").([]interface{}), syntheticsTestRawConfigPath(d, "config_variable"))),
+	}
+	if attr, ok := d.GetOk("config_initial_application_arguments"); ok {
+		config["initialApplicationArguments"] = attr.(map[string]interface{})
+	}
+
+	locations := []string{}
+	if attr, ok := d.GetOk("locations"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			locations = append(locations, s.(string))
+		}
+	}
+
+	tags := make([]string, 0)
+	if attr, ok := d.GetOk("tags"); ok {
+		for _, s := range attr.([]interface{}) {
+			if tag, ok := s.(string); ok {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	steps := []map[string]interface{}{}
+	if attr, ok := d.GetOk("mobile_step"); ok {
+		for _, s := range attr.([]interface{}) {
+			steps = append(steps, syntheticsmobile.BuildStep(s.(map[string]interface{})))
+		}
+	}
+
+	return map[string]interface{}{
+		"type":      string(datadogV1.SYNTHETICSTESTDETAILSTYPE_MOBILE),
+		"name":      d.Get("name").(string),
+		"message":   d.Get("message").(string),
+		"status":    d.Get("status").(string),
+		"locations": locations,
+		"tags":      tags,
+		"config":    config,
+		"options":   buildDatadogMobileTestOptions(d),
+		"steps":     steps,
+	}
+}
+
+func buildDatadogMobileTestOptions(d *schema.ResourceData) map[string]interface{} {
+	options := make(map[string]interface{})
+
+	if attr, ok := d.GetOk("mobile_options_list.0.tick_every"); ok {
+		options["tick_every"] = attr.(int)
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.min_failure_duration"); ok {
+		options["min_failure_duration"] = attr.(int)
+	}
+	if rawScheduling, ok := d.GetOk("mobile_options_list.0.scheduling.0"); ok {
+		scheduling := rawScheduling.(map[string]interface{})
+		timeframes := []map[string]interface{}{}
+		for _, tf := range scheduling["timeframes"].(*schema.Set).List() {
+			timeframe := tf.(map[string]interface{})
+			timeframes = append(timeframes, map[string]interface{}{
+				"day":  timeframe["day"],
+				"from": timeframe["from"],
+				"to":   timeframe["to"],
+			})
+		}
+		options["scheduling"] = map[string]interface{}{
+			"timeframes": timeframes,
+			"timezone":   scheduling["timezone"],
+		}
+	}
+	if rawRetry, ok := d.GetOk("mobile_options_list.0.retry.0"); ok {
+		retry := rawRetry.(map[string]interface{})
+		options["retry"] = map[string]interface{}{
+			"count":    retry["count"],
+			"interval": retry["interval"],
+		}
+	}
+	if rawMonitorOptions, ok := d.GetOk("mobile_options_list.0.monitor_options.0"); ok {
+		monitorOptions := rawMonitorOptions.(map[string]interface{})
+		options["monitor_options"] = map[string]interface{}{
+			"renotify_interval": monitorOptions["renotify_interval"],
+		}
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.monitor_name"); ok {
+		options["monitor_name"] = attr.(string)
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.monitor_priority"); ok {
+		options["monitor_priority"] = attr.(int)
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.restricted_roles"); ok {
+		roles := []string{}
+		for _, role := range attr.(*schema.Set).List() {
+			roles = append(roles, role.(string))
+		}
+		options["restricted_roles"] = roles
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.ci.0.execution_rule"); ok {
+		options["ci"] = map[string]interface{}{
+			"executionRule": attr.(string),
+		}
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.device_ids"); ok {
+		options["device_ids"] = attr.([]interface{})
+	}
+	if rawApplication, ok := d.GetOk("mobile_options_list.0.mobile_application.0"); ok {
+		application := rawApplication.(map[string]interface{})
+		mobileApplication := map[string]interface{}{
+			"applicationId": application["application_id"],
+			"referenceType": application["reference_type"],
+		}
+		if referenceID, ok := application["reference_id"].(string); ok && referenceID != "" {
+			mobileApplication["referenceId"] = referenceID
+		}
+		options["mobileApplication"] = mobileApplication
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.no_screenshot"); ok {
+		options["noScreenshot"] = attr.(bool)
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.allow_application_crash"); ok {
+		options["allowApplicationCrash"] = attr.(bool)
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.disable_auto_accept_alert"); ok {
+		options["disableAutoAcceptAlert"] = attr.(bool)
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.default_step_timeout"); ok {
+		options["defaultStepTimeout"] = attr.(int)
+	}
+	if attr, ok := d.GetOk("mobile_options_list.0.verbosity"); ok {
+		options["verbosity"] = attr.(int)
+	}
+
+	return options
+}
+
+func buildTerraformMobileTestOptions(options map[string]interface{}) []map[string]interface{} {
+	localOptions := make(map[string]interface{})
+
+	for _, key := range []string{"tick_every", "min_failure_duration", "monitor_priority"} {
+		if v, ok := options[key].(float64); ok {
+			localOptions[key] = int(v)
+		}
+	}
+	if v, ok := options["monitor_name"]; ok {
+		localOptions["monitor_name"] = v
+	}
+	if v, ok := options["restricted_roles"]; ok && v != nil {
+		localOptions["restricted_roles"] = v
+	}
+	if v, ok := options["device_ids"]; ok {
+		localOptions["device_ids"] = v
+	}
+	if scheduling, ok := options["scheduling"].(map[string]interface{}); ok {
+		timeframes := []map[string]interface{}{}
+		rawTimeframes, _ := scheduling["timeframes"].([]interface{})
+		for _, tf := range rawTimeframes {
+			timeframe := tf.(map[string]interface{})
+			timeframes = append(timeframes, map[string]interface{}{
+				"day":  int(timeframe["day"].(float64)),
+				"from": timeframe["from"],
+				"to":   timeframe["to"],
+			})
+		}
+		localOptions["scheduling"] = []map[string]interface{}{{
+			"timeframes": timeframes,
+			"timezone":   scheduling["timezone"],
+		}}
+	}
+	if retry, ok := options["retry"].(map[string]interface{}); ok {
+		localRetry := make(map[string]interface{})
+		if v, ok := retry["count"].(float64); ok {
+			localRetry["count"] = int(v)
+		}
+		if v, ok := retry["interval"].(float64); ok {
+			localRetry["interval"] = int(v)
+		}
+		localOptions["retry"] = []map[string]interface{}{localRetry}
+	}
+	if monitorOptions, ok := options["monitor_options"].(map[string]interface{}); ok {
+		if v, ok := monitorOptions["renotify_interval"].(float64); ok {
+			localOptions["monitor_options"] = []map[string]interface{}{{"renotify_interval": int(v)}}
+		}
+	}
+	if ci, ok := options["ci"].(map[string]interface{}); ok {
+		localOptions["ci"] = []map[string]interface{}{{"execution_rule": ci["executionRule"]}}
+	}
+	if application, ok := options["mobileApplication"].(map[string]interface{}); ok {
+		localOptions["mobile_application"] = []map[string]interface{}{{
+			"application_id": application["applicationId"],
+			"reference_id":   application["referenceId"],
+			"reference_type": application["referenceType"],
+		}}
+	}
+	if v, ok := options["noScreenshot"]; ok {
+		localOptions["no_screenshot"] = v
+	}
+	if v, ok := options["allowApplicationCrash"]; ok {
+		localOptions["allow_application_crash"] = v
+	}
+	if v, ok := options["disableAutoAcceptAlert"]; ok {
+		localOptions["disable_auto_accept_alert"] = v
+	}
+	if v, ok := options["defaultStepTimeout"].(float64); ok {
+		localOptions["default_step_timeout"] = int(v)
+	}
+	if v, ok := options["verbosity"].(float64); ok {
+		localOptions["verbosity"] = int(v)
+	}
+
+	return []map[string]interface{}{localOptions}
+}
+
 func buildDatadogAssertions(attr []interface{}) []datadogV1.SyntheticsAssertion {
 	assertions := make([]datadogV1.SyntheticsAssertion, 0)
 
@@ -2529,7 +3475,7 @@ func buildTerraformAssertions(actualAssertions []datadogV1.SyntheticsAssertion)
 				localAssertion["property"] = assertionTarget.GetProperty()
 			}
 			if target := assertionTarget.GetTarget(); target != nil {
-				localAssertion["target"] = convertToString(target)
+				localAssertion["target"] = utils.ConvertToString(target)
 			}
 			if v, ok := assertionTarget.GetTypeOk(); ok {
 				localAssertion["type"] = string(*v)
@@ -2626,7 +3572,7 @@ func buildTerraformAssertions(actualAssertions []datadogV1.SyntheticsAssertion)
 				localAssertion["operator"] = string(*v)
 			}
 			if target := assertionTarget.GetTarget(); target != nil {
-				localAssertion["target"] = convertToString(target)
+				localAssertion["target"] = utils.ConvertToString(target)
 			}
 			if v, ok := assertionTarget.GetTypeOk(); ok {
 				localAssertion["type"] = string(*v)
@@ -2747,6 +3693,73 @@ func buildDatadogBasicAuth(requestBasicAuth map[string]interface{}) datadogV1.Sy
 	return datadogV1.SyntheticsBasicAuth{}
 }
 
+// syntheticsBasicAuthSecretKeys are the secrets of basic auth blocks, only stored in state as a hash
+var syntheticsBasicAuthSecretKeys = []string{"password", "client_secret"}
+
+// syntheticsTestRawConfigPath returns the value at a path of attribute names and list indexes in the configuration,
+// or a null value when the path is not set or not known.
+func syntheticsTestRawConfigPath(d *schema.ResourceData, path ...interface{}) cty.Value {
+	value := d.GetRawConfig()
+	for _, step := range path {
+		if value.IsNull() || !value.IsKnown() {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		switch step := step.(type) {
+		case string:
+			value = value.GetAttr(step)
+		case int:
+			if step >= value.LengthInt() {
+				return cty.NullVal(cty.DynamicPseudoType)
+			}
+			value = value.Index(cty.NumberIntVal(int64(step)))
+		}
+	}
+	return value
+}
+
+// withSyntheticsBasicAuthConfigSecrets replaces the secrets of a basic auth block with their configured values, as
+// the state may hold another value when the diff on a secret is suppressed by its hash.
+func withSyntheticsBasicAuthConfigSecrets(requestBasicAuths []interface{}, config cty.Value) []interface{} {
+	if len(requestBasicAuths) == 0 || config.IsNull() || !config.IsKnown() || config.LengthInt() == 0 {
+		return requestBasicAuths
+	}
+	requestBasicAuth, ok := requestBasicAuths[0].(map[string]interface{})
+	if !ok {
+		return requestBasicAuths
+	}
+	basicAuthConfig := config.Index(cty.NumberIntVal(0))
+	for _, key := range syntheticsBasicAuthSecretKeys {
+		if v := basicAuthConfig.GetAttr(key); v.IsKnown() && !v.IsNull() {
+			requestBasicAuth[key] = v.AsString()
+		}
+	}
+	return requestBasicAuths
+}
+
+// withSyntheticsConfigVariableConfigSecrets replaces the example and pattern of secure config variables with their
+// configured values, as the state only holds their hash.
+func withSyntheticsConfigVariableConfigSecrets(requestConfigVariables []interface{}, config cty.Value) []interface{} {
+	if config.IsNull() || !config.IsKnown() {
+		return requestConfigVariables
+	}
+	for i, v := range requestConfigVariables {
+		variableMap, ok := v.(map[string]interface{})
+		if !ok || i >= config.LengthInt() {
+			continue
+		}
+		if secure, _ := variableMap["secure"].(bool); !secure {
+			continue
+		}
+		variableConfig := config.Index(cty.NumberIntVal(int64(i)))
+		for _, key := range []string{"example", "pattern"} {
+			if v := variableConfig.GetAttr(key); v.IsKnown() && !v.IsNull() {
+				variableMap[key] = v.AsString()
+			}
+		}
+	}
+	return requestConfigVariables
+}
+
 func buildTerraformBasicAuth(basicAuth *datadogV1.SyntheticsBasicAuth) map[string]string {
 	localAuth := make(map[string]string)
 
@@ -2844,6 +3857,27 @@ func buildTerraformBasicAuth(basicAuth *datadogV1.SyntheticsBasicAuth) map[strin
 	return localAuth
 }
 
+// buildTerraformBasicAuthHashes replaces the secrets of a basic auth block with their hash, computed from the secrets
+// returned by the API or, when they are not returned, from the previous state.
+func buildTerraformBasicAuthHashes(localAuth map[string]string, oldBasicAuths []interface{}) {
+	var oldBasicAuth map[string]interface{}
+	if len(oldBasicAuths) > 0 {
+		oldBasicAuth, _ = oldBasicAuths[0].(map[string]interface{})
+	}
+	for _, key := range syntheticsBasicAuthSecretKeys {
+		secret := localAuth[key]
+		if secret == "" {
+			secret, _ = oldBasicAuth[key].(string)
+		}
+		if secret != "" {
+			localAuth[key+"_hash"] = getCertificateStateValue(secret)
+		} else if hash, ok := oldBasicAuth[key+"_hash"].(string); ok {
+			localAuth[key+"_hash"] = hash
+		}
+		delete(localAuth, key)
+	}
+}
+
 func buildDatadogBodyFiles(attr []interface{}) []datadogV1.SyntheticsTestRequestBodyFile {
 	files := []datadogV1.SyntheticsTestRequestBodyFile{}
 	for _, f := range attr {
@@ -2934,10 +3968,19 @@ func buildTerraformConfigVariables(configVariables []datadogV1.SyntheticsConfigV
 
 		if configVariable.GetType() != "global" {
 			// If the variable is secure, the example and pattern are not returned by the API,
-			// so we need to keep the values from the terraform config.
+			// so we keep the hash of the values from the terraform config, which are sent on updates.
+			// On import, there is no previous configuration and the hashes are left empty.
 			if v, ok := localVariable["secure"].(bool); ok && v {
-				localVariable["example"] = oldConfigVariables[i].(map[string]interface{})["example"].(string)
-				localVariable["pattern"] = oldConfigVariables[i].(map[string]interface{})["pattern"].(string)
+				if i < len(oldConfigVariables) && oldConfigVariables[i] != nil {
+					oldConfigVariable := oldConfigVariables[i].(map[string]interface{})
+					for _, key := range []string{"example", "pattern"} {
+						if value, _ := oldConfigVariable[key].(string); value != "" {
+							localVariable[key+"_hash"] = getCertificateStateValue(value)
+						} else {
+							localVariable[key+"_hash"], _ = oldConfigVariable[key+"_hash"].(string)
+						}
+					}
+				}
 			} else {
 				if v, ok := configVariable.GetExampleOk(); ok {
 					localVariable["example"] = *v
@@ -3417,7 +4460,7 @@ func buildTerraformTestRequest(request datadogV1.SyntheticsTestRequest) map[stri
 		localRequest["body_type"] = request.GetBodyType()
 	}
 	if request.HasMethod() {
-		localRequest["method"] = convertToString(request.GetMethod())
+		localRequest["method"] = utils.ConvertToString(request.GetMethod())
 	}
 	if request.HasTimeout() {
 		localRequest["timeout"] = request.GetTimeout()
@@ -3437,7 +4480,7 @@ func buildTerraformTestRequest(request datadogV1.SyntheticsTestRequest) map[stri
 		}
 	}
 	if request.HasDnsServer() {
-		localRequest["dns_server"] = convertToString(request.GetDnsServer())
+		localRequest["dns_server"] = utils.ConvertToString(request.GetDnsServer())
 	}
 	if request.HasDnsServerPort() {
 		localRequest["dns_server_port"] = request.GetDnsServerPort()
@@ -3473,11 +4516,11 @@ func buildTerraformTestRequest(request datadogV1.SyntheticsTestRequest) map[stri
 		localRequest["http_version"] = request.GetHttpVersion()
 	}
 	if request.HasCompressedJsonDescriptor() {
-		localRequest["proto_json_descriptor"] = decompressAndDecodeValue(request.GetCompressedJsonDescriptor())
+		localRequest["proto_json_descriptor"] = utils.DecompressAndDecodeValue(request.GetCompressedJsonDescriptor())
 	}
 
 	if request.HasCompressedProtoFile() {
-		localRequest["plain_proto_file"] = decompressAndDecodeValue(request.GetCompressedProtoFile())
+		localRequest["plain_proto_file"] = utils.DecompressAndDecodeValue(request.GetCompressedProtoFile())
 	}
 
 	return localRequest
@@ -3510,22 +4553,78 @@ func buildTerraformTestRequestProxy(proxy datadogV1.SyntheticsTestRequestProxy)
  * Utils
  */
 
-func compressAndEncodeValue(value string) string {
-	var compressedValue bytes.Buffer
-	zl := zlib.NewWriter(&compressedValue)
-	zl.Write([]byte(value))
-	zl.Close()
-	encodedCompressedValue := b64.StdEncoding.EncodeToString(compressedValue.Bytes())
-	return encodedCompressedValue
+// buildDatadogAPIStepFromTemplate builds an API step from the JSON definition of `step_template`, named after the step
+func buildDatadogAPIStepFromTemplate(name string, stepTemplate string) datadogV1.SyntheticsAPIStep {
+	step := datadogV1.SyntheticsAPIStep{}
+	if err := json.Unmarshal([]byte(stepTemplate), &step); err != nil {
+		log.Printf("[WARN] invalid step_template for api step %s: %s", name, err)
+	}
+	if step.SyntheticsAPITestStep != nil {
+		step.SyntheticsAPITestStep.SetName(name)
+	} else if step.SyntheticsAPIWaitStep != nil {
+		step.SyntheticsAPIWaitStep.SetName(name)
+	}
+	return step
+}
+
+// buildTerraformAPIStepFromTemplate keeps the `step_template` of a step as long as the step returned by the API
+// still matches it, and otherwise stores the definition returned by the API to surface the drift.
+func buildTerraformAPIStepFromTemplate(step datadogV1.SyntheticsAPIStep, oldStepTemplate string, oldSubtype string) map[string]interface{} {
+	localStep := map[string]interface{}{
+		"subtype":       oldSubtype,
+		"step_template": oldStepTemplate,
+	}
+
+	var actualStep map[string]interface{}
+	actualStepJSON, _ := json.Marshal(step)
+	if err := json.Unmarshal(actualStepJSON, &actualStep); err != nil {
+		return localStep
+	}
+	localStep["name"] = actualStep["name"]
+	delete(actualStep, "name")
+	delete(actualStep, "id")
+
+	var expectedStep map[string]interface{}
+	if err := json.Unmarshal([]byte(oldStepTemplate), &expectedStep); err == nil {
+		delete(expectedStep, "name")
+		if isJSONSubset(expectedStep, actualStep) {
+			return localStep
+		}
+	}
+
+	actualStepJSON, _ = json.Marshal(actualStep)
+	localStep["step_template"] = string(actualStepJSON)
+	return localStep
 }
 
-func decompressAndDecodeValue(value string) string {
-	decodedValue, _ := b64.StdEncoding.DecodeString(value)
-	decodedBytes := bytes.NewReader(decodedValue)
-	zl, _ := zlib.NewReader(decodedBytes)
-	defer zl.Close()
-	compressedProtoFile, _ := io.ReadAll(zl)
-	return string(compressedProtoFile)
+// isJSONSubset returns whether every value of a decoded JSON value is found in another one, ignoring
+// the additional object keys, such as default values added by the API.
+func isJSONSubset(expected interface{}, actual interface{}) bool {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range e {
+			if !isJSONSubset(value, a[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			return false
+		}
+		for i := range e {
+			if !isJSONSubset(e[i], a[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(expected, actual)
 }
 
 func convertStepParamsValueForConfig(stepType datadogV1.SyntheticsStepType, key string, value interface{}) interface{} {
@@ -3556,72 +4655,6 @@ func convertStepParamsValueForConfig(stepType datadogV1.SyntheticsStepType, key
 	return value
 }
 
-func convertStepParamsValueForState(key string, value interface{}) interface{} {
-	switch key {
-	case "element", "email", "file", "files", "request":
-		result, _ := json.Marshal(value)
-		return string(result)
-
-	case "playing_tab_id", "value":
-		return convertToString(value)
-
-	case "variable":
-		return []interface{}{value}
-	}
-
-	return value
-}
-
-func convertStepParamsKey(key string) string {
-	switch key {
-	case "click_type":
-		return "clickType"
-
-	case "clickType":
-		return "click_type"
-
-	case "playing_tab_id":
-		return "playingTabId"
-
-	case "playingTabId":
-		return "playing_tab_id"
-
-	case "subtest_public_id":
-		return "subtestPublicId"
-
-	case "subtestPublicId":
-		return "subtest_public_id"
-
-	case "with_click":
-		return "withClick"
-
-	case "withClick":
-		return "with_click"
-	}
-
-	return key
-}
-
-func convertToString(i interface{}) string {
-	switch v := i.(type) {
-	case bool:
-		return strconv.FormatBool(v)
-	case int:
-		return strconv.Itoa(v)
-	case float64:
-		return strconv.FormatFloat(v, 'f', -1, 64)
-	case string:
-		return v
-	default:
-		// TODO: manage target for JSON body assertions
-		valStrr, err := json.Marshal(v)
-		if err == nil {
-			return string(valStrr)
-		}
-		return ""
-	}
-}
-
 // get the sha256 of a client certificate content
 // in some case where Terraform compares the state value
 // we already get the hashed value so we don't need to
@@ -3634,82 +4667,11 @@ func getCertificateStateValue(content string) string {
 	return utils.ConvertToSha256(content)
 }
 
-func getParamsKeysForStepType(stepType datadogV1.SyntheticsStepType) []string {
-	switch stepType {
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_CURRENT_URL:
-		return []string{"check", "value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_ATTRIBUTE:
-		return []string{"attribute", "check", "element", "value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_CONTENT:
-		return []string{"check", "element", "value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_ELEMENT_PRESENT:
-		return []string{"element"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_EMAIL:
-		return []string{"email"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_FILE_DOWNLOAD:
-		return []string{"file"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_FROM_JAVASCRIPT:
-		return []string{"code", "element"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_PAGE_CONTAINS:
-		return []string{"value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_ASSERT_PAGE_LACKS:
-		return []string{"value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_CLICK:
-		return []string{"click_type", "element"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_EXTRACT_FROM_JAVASCRIPT:
-		return []string{"code", "element", "variable"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_EXTRACT_VARIABLE:
-		return []string{"element", "variable"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_GO_TO_EMAIL_LINK:
-		return []string{"value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_GO_TO_URL:
-		return []string{"value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_HOVER:
-		return []string{"element"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_PLAY_SUB_TEST:
-		return []string{"playing_tab_id", "subtest_public_id"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_PRESS_KEY:
-		return []string{"modifiers", "value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_REFRESH:
-		return []string{}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_RUN_API_TEST:
-		return []string{"request"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_SCROLL:
-		return []string{"element", "x", "y"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_SELECT_OPTION:
-		return []string{"element", "value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_TYPE_TEXT:
-		return []string{"delay", "element", "value"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_UPLOAD_FILES:
-		return []string{"element", "files", "with_click"}
-
-	case datadogV1.SYNTHETICSSTEPTYPE_WAIT:
-		return []string{"value"}
-	}
-
-	return []string{}
+// syntheticsSecretDiffSuppress suppresses the diff on a secret not returned by the API when the hash of the configured
+// value, stored in the `<secret>_hash` attribute, is unchanged.
+func syntheticsSecretDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	hash, _ := d.Get(k + "_hash").(string)
+	return new != "" && hash != "" && getCertificateStateValue(new) == hash
 }
 
 func getSyntheticsTestType(d *schema.ResourceData) *datadogV1.SyntheticsTestDetailsType {
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogDowntimeCoverageDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceDowntimeCoverageConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_downtime_coverage.foo", "downtimes.#", "1"),
+					resource.TestCheckResourceAttrPair("data.datadog_downtime_coverage.foo", "downtimes.0.id", "datadog_downtime_schedule.foo", "id"),
+					resource.TestCheckResourceAttr("data.datadog_downtime_coverage.foo", "downtimes.0.windows.#", "1"),
+					resource.TestCheckResourceAttr("data.datadog_downtime_coverage.foo", "downtimes.0.windows.0.start", "2050-01-02T03:04:05Z"),
+					resource.TestCheckResourceAttr("data.datadog_downtime_coverage.foo", "downtimes.0.windows.0.end", "2050-01-02T05:04:05Z"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceDowntimeCoverageConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_monitor" "foo" {
+  name    = "%s"
+  type    = "metric alert"
+  message = "some message Notify: @hipchat-channel"
+  query   = "avg(last_1h):avg:aws.ec2.cpu{environment:foo,host:foo} by {host} > 2"
+
+  monitor_thresholds {
+    critical = "2.0"
+  }
+}
+
+resource "datadog_downtime_schedule" "foo" {
+  scope = "env:(staging OR %v)"
+  monitor_identifier {
+    monitor_id = datadog_monitor.foo.id
+  }
+  one_time_schedule {
+    start = "2050-01-02T03:04:05Z"
+    end   = "2050-01-02T05:04:05Z"
+  }
+  notify_end_types = []
+}
+
+data "datadog_downtime_coverage" "foo" {
+  monitor_id = datadog_monitor.foo.id
+  from       = "2050-01-01T00:00:00Z"
+  to         = "2050-01-03T00:00:00Z"
+  depends_on = [datadog_downtime_schedule.foo]
+}
+`, uniq, uniq)
+}
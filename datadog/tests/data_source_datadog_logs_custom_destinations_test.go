@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogLogsCustomDestinationsDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	name := uniqueEntityName(ctx, t)
+
+	path := "data.datadog_logs_custom_destinations.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceLogsCustomDestinationsConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(path, "destinations.#", "2"),
+					// Destinations are sorted by name
+					resource.TestCheckResourceAttrPair(path, "destinations.0.id", "datadog_logs_custom_destination.disabled", "id"),
+					resource.TestCheckResourceAttr(path, "destinations.0.enabled", "false"),
+					resource.TestCheckResourceAttr(path, "destinations.0.status", "disabled"),
+					resource.TestCheckResourceAttrPair(path, "destinations.1.id", "datadog_logs_custom_destination.enabled", "id"),
+					resource.TestCheckResourceAttr(path, "destinations.1.name", name+"-enabled"),
+					resource.TestCheckResourceAttr(path, "destinations.1.type", "http"),
+					resource.TestCheckResourceAttr(path, "destinations.1.endpoint", "https://example.org"),
+					resource.TestCheckResourceAttr(path, "destinations.1.enabled", "true"),
+					resource.TestCheckResourceAttr(path, "destinations.1.status", "enabled"),
+					resource.TestCheckResourceAttr(path, "destinations.1.errors.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceLogsCustomDestinationsConfig(name string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_custom_destination" "enabled" {
+	name = "%[1]s-enabled"
+	http_destination {
+		endpoint = "https://example.org"
+		basic_auth {
+			username = "test-user"
+			password = "test-pass"
+		}
+	}
+}
+
+resource "datadog_logs_custom_destination" "disabled" {
+	name    = "%[1]s-disabled"
+	enabled = false
+	http_destination {
+		endpoint = "https://example.org"
+		basic_auth {
+			username = "test-user"
+			password = "test-pass"
+		}
+	}
+}
+
+data "datadog_logs_custom_destinations" "foo" {
+	ids = [
+		datadog_logs_custom_destination.enabled.id,
+		datadog_logs_custom_destination.disabled.id,
+	]
+}`, name)
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogLogsIndexBudgetDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:                    testAccDatasourceLogsIndexBudgetConfig(uniq),
+				PreventPostDestroyRefresh: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.#", "1"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.name", uniq),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.daily_limit", "200000"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.daily_limit_warning_threshold_percentage", "70"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.retention_days", "15"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.flex_retention_days", "180"),
+					// A new index has no usage yet
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.indexed_events", "0"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.peak_daily_limit_percentage", "0"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.projected_monthly_events", "0"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_budget.foo", "indexes.0.projected_monthly_retention_cost", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceLogsIndexBudgetConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_index" "foo" {
+  name                                     = "%s"
+  daily_limit                              = 200000
+  daily_limit_warning_threshold_percentage = 70
+  retention_days                           = 15
+  flex_retention_days                      = 180
+  filter {
+    query = "non-existent-query"
+  }
+}
+
+data "datadog_logs_index_budget" "foo" {
+  index_names = [datadog_logs_index.foo.name]
+  days        = 3
+  price_per_million_events = {
+    "15" = 1.70
+  }
+}
+`, uniq)
+}
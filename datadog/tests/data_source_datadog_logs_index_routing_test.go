@@ -0,0 +1,81 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogLogsIndexRoutingDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:                    testAccDatasourceLogsIndexRoutingConfig(uniq),
+				PreventPostDestroyRefresh: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_logs_index_routing.excluded", "index", uniq),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_routing.excluded", "matching_indexes.#", "1"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_routing.excluded", "exclusion_filter", "Filter coredns logs"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_routing.excluded", "exclusion_sample_rate", "0.97"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_routing.kept", "index", uniq),
+					resource.TestCheckNoResourceAttr("data.datadog_logs_index_routing.kept", "exclusion_filter"),
+					resource.TestCheckNoResourceAttr("data.datadog_logs_index_routing.unmatched", "index"),
+					resource.TestCheckResourceAttr("data.datadog_logs_index_routing.unmatched", "matching_indexes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceLogsIndexRoutingConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_index" "foo" {
+  name           = "%s"
+  daily_limit    = 200000
+  retention_days = 15
+  filter {
+    query = "service:terraform-routing"
+  }
+  exclusion_filter {
+    name       = "Filter coredns logs"
+    is_enabled = true
+    filter {
+      query       = "app:coredns"
+      sample_rate = 0.97
+    }
+  }
+}
+
+data "datadog_logs_index_routing" "excluded" {
+  index_names = [datadog_logs_index.foo.name]
+  facets = {
+    service = "terraform-routing"
+    app     = "coredns"
+  }
+}
+
+data "datadog_logs_index_routing" "kept" {
+  index_names = [datadog_logs_index.foo.name]
+  log = jsonencode({
+    message = "hello"
+    service = "terraform-routing"
+    ddtags  = "app:api"
+  })
+}
+
+data "datadog_logs_index_routing" "unmatched" {
+  index_names = [datadog_logs_index.foo.name]
+  facets = {
+    service = "another-service"
+  }
+}
+`, uniq)
+}
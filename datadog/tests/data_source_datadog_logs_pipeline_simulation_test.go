@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogLogsPipelineSimulationDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceLogsPipelineSimulationConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.existing", "matched_pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.existing", "matched_pipelines.0", uniq),
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.existing", "skipped_processors.#", "0"),
+					resource.TestMatchResourceAttr("data.datadog_logs_pipeline_simulation.existing", "result", regexp.MustCompile(`"greeting":"hello world"`)),
+					// The local definition replaces the existing pipeline
+					resource.TestCheckResourceAttr("data.datadog_logs_pipeline_simulation.local", "matched_pipelines.#", "1"),
+					resource.TestMatchResourceAttr("data.datadog_logs_pipeline_simulation.local", "result", regexp.MustCompile(`"greeting":"goodbye world"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceLogsPipelineSimulationConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_logs_custom_pipeline" "foo" {
+	name       = "%s"
+	is_enabled = true
+	filter {
+		query = "source:terraform-simulation"
+	}
+	processor {
+		string_builder_processor {
+			name       = "greeting"
+			is_enabled = true
+			template   = "hello %%%%{name}"
+			target     = "greeting"
+		}
+	}
+}
+
+locals {
+	simulation_log = jsonencode({
+		message  = "hi"
+		ddsource = "terraform-simulation"
+		name     = "world"
+	})
+}
+
+data "datadog_logs_pipeline_simulation" "existing" {
+	pipeline_ids = [datadog_logs_custom_pipeline.foo.id]
+	log          = local.simulation_log
+}
+
+data "datadog_logs_pipeline_simulation" "local" {
+	pipeline_ids = [datadog_logs_custom_pipeline.foo.id]
+	pipelines = [jsonencode({
+		id         = datadog_logs_custom_pipeline.foo.id
+		name       = datadog_logs_custom_pipeline.foo.name
+		is_enabled = true
+		filter     = [{ query = "source:terraform-simulation" }]
+		processor = [{
+			string_builder_processor = [{
+				is_enabled = true
+				template   = "goodbye %%%%{name}"
+				target     = "greeting"
+			}]
+		}]
+	})]
+	log = local.simulation_log
+}`, uniq)
+}
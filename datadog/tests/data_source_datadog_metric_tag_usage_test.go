@@ -0,0 +1,44 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogMetricTagUsageDatasource(t *testing.T) {
+	t.Parallel()
+	_, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceMetricTagUsageConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.datadog_metric_tag_usage.foo", "id"),
+					resource.TestCheckResourceAttrSet("data.datadog_metric_tag_usage.foo", "ingested_tag_keys.#"),
+					resource.TestCheckResourceAttrSet("data.datadog_metric_tag_usage.foo", "queried_tag_keys.#"),
+					resource.TestCheckResourceAttrSet("data.datadog_metric_tag_usage.foo", "estimated_series"),
+					resource.TestCheckResourceAttrSet("data.datadog_metric_tag_usage.foo", "tag_keys.0.key"),
+					resource.TestCheckNoResourceAttr("data.datadog_metric_tag_usage.foo", "tag_keys.0.estimated_series_with"),
+					resource.TestCheckResourceAttrSet("data.datadog_metric_tag_usage.estimated", "tag_keys.0.estimated_series_with"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDatasourceMetricTagUsageConfig = `
+data "datadog_metric_tag_usage" "foo" {
+	metric_name    = "system.load.1"
+	window_seconds = 86400
+}
+
+data "datadog_metric_tag_usage" "estimated" {
+	metric_name       = "system.load.1"
+	window_seconds    = 86400
+	estimate_tag_keys = true
+}`
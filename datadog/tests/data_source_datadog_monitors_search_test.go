@@ -0,0 +1,67 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogMonitorsSearchDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := strings.ToLower(strings.ReplaceAll(uniqueEntityName(ctx, t), "-", "_"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMonitorsSearchMonitorConfig(uniq),
+			},
+			{
+				Config: testAccDatasourceMonitorsSearchConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_monitors_search.foo", "monitors.#", "1"),
+					resource.TestCheckResourceAttrPair("data.datadog_monitors_search.foo", "monitors.0.id", "datadog_monitor.foo", "id"),
+					resource.TestCheckResourceAttr("data.datadog_monitors_search.foo", "monitors.0.name", uniq),
+					resource.TestCheckResourceAttr("data.datadog_monitors_search.foo", "monitors.0.type", "query alert"),
+					resource.TestCheckResourceAttr("data.datadog_monitors_search.foo", "monitors.0.priority", "2"),
+					resource.TestCheckTypeSetElemAttr("data.datadog_monitors_search.foo", "monitors.0.tags.*", fmt.Sprintf("test_datasource_monitors_search:%s", uniq)),
+					resource.TestCheckResourceAttrSet("data.datadog_monitors_search.foo", "monitors.0.overall_state"),
+					resource.TestCheckResourceAttr("data.datadog_monitors_search.foo", "monitors.0.restricted_roles.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMonitorsSearchMonitorConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_monitor" "foo" {
+  name     = "%s"
+  type     = "query alert"
+  message  = "some message Notify: @hipchat-channel"
+  query    = "avg(last_1h):avg:aws.ec2.cpu{environment:foo,host:foo} by {host} > 2"
+  priority = 2
+  tags     = ["test_datasource_monitors_search:%s"]
+
+  monitor_thresholds {
+    critical = "2.0"
+  }
+}
+`, uniq, uniq)
+}
+
+func testAccDatasourceMonitorsSearchConfig(uniq string) string {
+	return fmt.Sprintf(`
+%s
+
+data "datadog_monitors_search" "foo" {
+  query      = "tag:\"test_datasource_monitors_search:%s\""
+  depends_on = [datadog_monitor.foo]
+}
+`, testAccMonitorsSearchMonitorConfig(uniq), uniq)
+}
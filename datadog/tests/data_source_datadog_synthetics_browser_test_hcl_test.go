@@ -0,0 +1,165 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsBrowserTestHCLDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceSyntheticsBrowserTestHCLConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.datadog_synthetics_browser_test_hcl.foo", "id", "datadog_synthetics_test.foo", "id"),
+					resource.TestMatchResourceAttr("data.datadog_synthetics_browser_test_hcl.foo", "browser_step_hcl", regexp.MustCompile(`name += "Check current url"`)),
+					resource.TestMatchResourceAttr("data.datadog_synthetics_browser_test_hcl.foo", "browser_step_hcl", regexp.MustCompile(`type += "assertCurrentUrl"`)),
+					resource.TestMatchResourceAttr("data.datadog_synthetics_browser_test_hcl.foo", "browser_step_hcl", regexp.MustCompile(`check += "contains"`)),
+					resource.TestMatchResourceAttr("data.datadog_synthetics_browser_test_hcl.foo", "browser_step_hcl", regexp.MustCompile(`value += "datadoghq"`)),
+					resource.TestMatchResourceAttr("data.datadog_synthetics_browser_test_hcl.foo", "browser_variable_hcl", regexp.MustCompile(`name += "MY_VAR"`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatadogSyntheticsBrowserTestHCLDatasource_JSON(t *testing.T) {
+	t.Parallel()
+	_, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceSyntheticsBrowserTestHCLJSONConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.datadog_synthetics_browser_test_hcl.foo", "id"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_test_hcl.foo", "browser_step_hcl", `browser_step {
+  name        = "Type the name"
+  type        = "typeText"
+  is_critical = true
+  timeout     = 10
+  params {
+    value = "{{ MY_VAR }}"
+  }
+}
+
+browser_step {
+  name = "Go to \"login\""
+  type = "goToUrl"
+  params {
+    value = "https://$${HOST}/login"
+  }
+}
+`),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_browser_test_hcl.foo", "browser_variable_hcl", `browser_variable {
+  type    = "text"
+  name    = "MY_VAR"
+  example = "foo"
+  pattern = "foo"
+}
+`),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceSyntheticsBrowserTestHCLConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test" "foo" {
+	type       = "browser"
+	name       = "%s"
+	message    = "Notify @datadog.user"
+	status     = "paused"
+	device_ids = ["laptop_large"]
+	locations  = ["aws:eu-central-1"]
+
+	request_definition {
+		method = "GET"
+		url    = "https://www.datadoghq.com"
+	}
+
+	options_list {
+		tick_every = 900
+	}
+
+	browser_step {
+		name = "Check current url"
+		type = "assertCurrentUrl"
+		params {
+			check = "contains"
+			value = "datadoghq"
+		}
+	}
+
+	browser_variable {
+		type    = "text"
+		name    = "MY_VAR"
+		example = "foo"
+		pattern = "foo"
+	}
+}
+
+data "datadog_synthetics_browser_test_hcl" "foo" {
+	public_id = datadog_synthetics_test.foo.id
+}`, uniq)
+}
+
+func testAccDatasourceSyntheticsBrowserTestHCLJSONConfig() string {
+	return `
+data "datadog_synthetics_browser_test_hcl" "foo" {
+	json = jsonencode({
+		name      = "Exported browser test"
+		type      = "browser"
+		status    = "paused"
+		message   = ""
+		locations = ["aws:eu-central-1"]
+		options = {
+			tick_every = 900
+		}
+		config = {
+			assertions = []
+			request = {
+				method = "GET"
+				url    = "https://www.datadoghq.com"
+			}
+			variables = [{
+				type    = "text"
+				name    = "MY_VAR"
+				example = "foo"
+				pattern = "foo"
+			}]
+		}
+		steps = [{
+			name         = "Type the name"
+			type         = "typeText"
+			timeout      = 10
+			allowFailure = false
+			isCritical   = true
+			noScreenshot = false
+			params = {
+				delay = 0
+				value = "{{ MY_VAR }}"
+			}
+		}, {
+			name    = "Go to \"login\""
+			type    = "goToUrl"
+			timeout = 0
+			params = {
+				value = "https://$${HOST}/login"
+			}
+		}]
+	})
+}`
+}
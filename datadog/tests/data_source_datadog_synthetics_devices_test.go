@@ -0,0 +1,37 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsDevicesDatasource(t *testing.T) {
+	t.Parallel()
+	_, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "datadog_synthetics_devices" "all" {}
+
+				data "datadog_synthetics_devices" "filtered" {
+					browsers     = ["chrome", "firefox"]
+					form_factors = ["mobile_small"]
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.datadog_synthetics_devices.all", "device_ids.#", "9"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_devices.all", "device_ids.0", "chrome.laptop_large"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_devices.filtered", "device_ids.#", "2"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_devices.filtered", "device_ids.0", "chrome.mobile_small"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_devices.filtered", "device_ids.1", "firefox.mobile_small"),
+				),
+			},
+		},
+	})
+}
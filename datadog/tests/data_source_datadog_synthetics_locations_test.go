@@ -20,6 +20,7 @@ func TestAccDatadogSyntheticsLocation_existing(t *testing.T) {
 				`,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.datadog_synthetics_locations.test", "locations.%"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_locations.test", "id", "datadog-synthetics-location||||false|"),
 				),
 			},
 		},
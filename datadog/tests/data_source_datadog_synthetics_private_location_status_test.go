@@ -0,0 +1,47 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsPrivateLocationStatusDatasource(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceSyntheticsPrivateLocationStatusConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.datadog_synthetics_private_location_status.foo", "id", "datadog_synthetics_private_location.foo", "id"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_private_location_status.foo", "name", uniq),
+					// No worker runs for a new private location
+					resource.TestCheckResourceAttr("data.datadog_synthetics_private_location_status.foo", "healthy", "false"),
+					resource.TestCheckResourceAttr("data.datadog_synthetics_private_location_status.foo", "running_workers", "0"),
+					resource.TestCheckNoResourceAttr("data.datadog_synthetics_private_location_status.foo", "last_seen"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceSyntheticsPrivateLocationStatusConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_private_location" "foo" {
+	name        = "%s"
+	description = "a private location"
+	tags        = ["foo:bar", "baz"]
+}
+
+data "datadog_synthetics_private_location_status" "foo" {
+	private_location_id = datadog_synthetics_private_location.foo.id
+	lookback            = 3600
+}`, uniq)
+}
@@ -59,17 +59,24 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_csm_threats_agent_rules_test":                 "cloud-workload-security",
 	"tests/data_source_datadog_dashboard_list_test":                          "dashboard-lists",
 	"tests/data_source_datadog_dashboard_test":                               "dashboard",
+	"tests/data_source_datadog_downtime_coverage_test":                       "downtimes",
 	"tests/data_source_datadog_hosts_test":                                   "hosts",
 	"tests/data_source_datadog_integration_aws_logs_services_test":           "integration-aws",
 	"tests/data_source_datadog_integration_aws_namespace_rules_test":         "integration-aws",
 	"tests/data_source_datadog_ip_ranges_test":                               "ip-ranges",
 	"tests/data_source_datadog_logs_archives_order_test":                     "logs-archive",
+	"tests/data_source_datadog_logs_custom_destinations_test":                "logs-custom-destination",
 	"tests/data_source_datadog_logs_indexes_order_test":                      "logs-index",
 	"tests/data_source_datadog_logs_indexes_test":                            "logs-index",
+	"tests/data_source_datadog_logs_index_routing_test":                      "logs-index",
+	"tests/data_source_datadog_logs_index_budget_test":                       "logs-index",
+	"tests/data_source_datadog_logs_pipeline_simulation_test":                "logs-pipelines",
 	"tests/data_source_datadog_logs_pipelines_test":                          "logs-pipelines",
+	"tests/data_source_datadog_metric_tag_usage_test":                        "metrics",
 	"tests/data_source_datadog_monitor_config_policies_test":                 "monitor-config-policies",
 	"tests/data_source_datadog_monitor_config_policy_test":                   "monitor-config-policies",
 	"tests/data_source_datadog_monitor_test":                                 "monitors",
+	"tests/data_source_datadog_monitors_search_test":                         "monitors",
 	"tests/data_source_datadog_monitors_test":                                "monitors",
 	"tests/data_source_datadog_permissions_test":                             "permissions",
 	"tests/data_source_datadog_powerpack_test":                               "powerpacks",
@@ -86,8 +93,11 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/data_source_datadog_service_account_test":                         "users",
 	"tests/data_source_datadog_service_level_objective_test":                 "service-level-objectives",
 	"tests/data_source_datadog_service_level_objectives_test":                "service-level-objectives",
+	"tests/data_source_datadog_synthetics_browser_test_hcl_test":             "synthetics",
 	"tests/data_source_datadog_synthetics_global_variable_test":              "synthetics",
 	"tests/data_source_datadog_synthetics_locations_test":                    "synthetics",
+	"tests/data_source_datadog_synthetics_devices_test":                      "synthetics",
+	"tests/data_source_datadog_synthetics_private_location_status_test":      "synthetics",
 	"tests/data_source_datadog_synthetics_test_test":                         "synthetics",
 	"tests/data_source_datadog_team_memberships_test":                        "team",
 	"tests/data_source_datadog_team_test":                                    "team",
@@ -228,8 +238,11 @@ var testFiles2EndpointTags = map[string]string{
 	"tests/resource_datadog_software_catalog_test":                           "software-catalog",
 	"tests/resource_datadog_spans_metric_test":                               "spans-metric",
 	"tests/resource_datadog_synthetics_concurrency_cap_test":                 "synthetics",
+	"tests/resource_datadog_synthetics_global_variable_rotation_test":        "synthetics",
 	"tests/resource_datadog_synthetics_global_variable_test":                 "synthetics",
 	"tests/resource_datadog_synthetics_private_location_test":                "synthetics",
+	"tests/resource_datadog_synthetics_test_json_test":                       "synthetics",
+	"tests/resource_datadog_synthetics_test_run_test":                        "synthetics",
 	"tests/resource_datadog_synthetics_test_test":                            "synthetics",
 	"tests/resource_datadog_team_link_test":                                  "team",
 	"tests/resource_datadog_team_membership_test":                            "team",
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccDatadogSyntheticsGlobalVariableRotation_Basic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := getUniqueVariableName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogSyntheticsGlobalVariableRotationConfig(uniq, "first-value", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("datadog_synthetics_global_variable_rotation.foo", "value_version", "1"),
+					resource.TestCheckResourceAttrSet("datadog_synthetics_global_variable_rotation.foo", "rotated_at"),
+					resource.TestCheckResourceAttrSet("datadog_synthetics_global_variable_rotation.foo", "next_rotation_at"),
+					resource.TestCheckResourceAttr("datadog_synthetics_global_variable.foo", "value_version", "1"),
+					resource.TestCheckResourceAttr("datadog_synthetics_global_variable.foo", "value_wo", ""),
+					testAccCheckDatadogSyntheticsGlobalVariableValue(providers.frameworkProvider, "first-value"),
+				),
+			},
+			{
+				// The value is only sent when the version changes
+				Config: testAccCheckDatadogSyntheticsGlobalVariableRotationConfig(uniq, "second-value", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("datadog_synthetics_global_variable_rotation.foo", "value_version", "1"),
+					testAccCheckDatadogSyntheticsGlobalVariableValue(providers.frameworkProvider, "first-value"),
+				),
+			},
+			{
+				Config: testAccCheckDatadogSyntheticsGlobalVariableRotationConfig(uniq, "second-value", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("datadog_synthetics_global_variable_rotation.foo", "value_version", "2"),
+					resource.TestCheckResourceAttr("datadog_synthetics_global_variable.foo", "value_version", "2"),
+					testAccCheckDatadogSyntheticsGlobalVariableValue(providers.frameworkProvider, "second-value"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogSyntheticsGlobalVariableRotationConfig(uniq string, value string, trigger string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_global_variable_rotation" "foo" {
+	rotation_days = 30
+	triggers = {
+		key = "%s"
+	}
+}
+
+resource "datadog_synthetics_global_variable" "foo" {
+	name          = "%s"
+	description   = "a global variable rotated by terraform"
+	tags          = ["foo:bar", "baz"]
+	value_wo      = "%s"
+	value_version = datadog_synthetics_global_variable_rotation.foo.value_version
+}`, trigger, uniq, value)
+}
+
+func testAccCheckDatadogSyntheticsGlobalVariableValue(accProvider *fwprovider.FrameworkProvider, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_synthetics_global_variable" {
+				continue
+			}
+			variable, httpResp, err := apiInstances.GetSyntheticsApiV1().GetGlobalVariable(auth, r.Primary.ID)
+			if err != nil {
+				return utils.TranslateClientError(err, httpResp, "error getting synthetics global variable")
+			}
+			value := variable.GetValue()
+			if value.GetValue() != expected {
+				return fmt.Errorf("global variable %s has value %q, expected %q", r.Primary.ID, value.GetValue(), expected)
+			}
+		}
+		return nil
+	}
+}
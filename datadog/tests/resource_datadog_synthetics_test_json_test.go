@@ -0,0 +1,114 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/fwprovider"
+	"github.com/terraform-providers/terraform-provider-datadog/datadog/internal/utils"
+)
+
+func TestAccDatadogSyntheticsTestJSON_Basic(t *testing.T) {
+	t.Parallel()
+	ctx, providers, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		CheckDestroy:             testAccCheckDatadogSyntheticsTestJSONDestroy(providers.frameworkProvider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogSyntheticsTestJSONConfig(uniq),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogSyntheticsTestJSONExists(providers.frameworkProvider),
+					resource.TestCheckResourceAttrSet("datadog_synthetics_test_json.foo", "monitor_id"),
+				),
+			},
+			{
+				// The defaults returned by the API for the fields not set in the definition don't cause a diff
+				Config:   testAccCheckDatadogSyntheticsTestJSONConfig(uniq),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogSyntheticsTestJSONConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test_json" "foo" {
+	test = jsonencode({
+		name    = "%s"
+		type    = "api"
+		subtype = "http"
+		status  = "paused"
+		message = "Notify @datadog.user"
+		tags    = ["foo:bar", "baz"]
+		config = {
+			request = {
+				method = "GET"
+				url    = "https://www.datadoghq.com"
+				basicAuth = {
+					type     = "web"
+					username = "admin"
+					password = "secret"
+				}
+			}
+			assertions = [
+				{
+					type     = "statusCode"
+					operator = "is"
+					target   = 200
+				}
+			]
+		}
+		locations = ["aws:eu-central-1"]
+		options = {
+			tick_every = 900
+		}
+	})
+}`, uniq)
+}
+
+func testAccCheckDatadogSyntheticsTestJSONDestroy(accProvider *fwprovider.FrameworkProvider) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_synthetics_test_json" {
+				continue
+			}
+			_, httpResp, err := apiInstances.GetSyntheticsApiV1().GetTest(auth, r.Primary.ID)
+			if err != nil {
+				if httpResp != nil && httpResp.StatusCode == 404 {
+					continue
+				}
+				return utils.TranslateClientError(err, httpResp, "error retrieving synthetics test")
+			}
+			return fmt.Errorf("synthetics test %s still exists", r.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatadogSyntheticsTestJSONExists(accProvider *fwprovider.FrameworkProvider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiInstances := accProvider.DatadogApiInstances
+		auth := accProvider.Auth
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "datadog_synthetics_test_json" {
+				continue
+			}
+			if _, httpResp, err := apiInstances.GetSyntheticsApiV1().GetTest(auth, r.Primary.ID); err != nil {
+				return utils.TranslateClientError(err, httpResp, "error retrieving synthetics test")
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatadogSyntheticsTestRun_Basic(t *testing.T) {
+	t.Parallel()
+	ctx, _, accProviders := testAccFrameworkMuxProviders(context.Background(), t)
+	uniq := uniqueEntityName(ctx, t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: accProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatadogSyntheticsTestRunConfig(uniq, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("datadog_synthetics_test_run.foo", "batch_id"),
+					resource.TestCheckResourceAttr("datadog_synthetics_test_run.foo", "status", "passed"),
+					resource.TestCheckResourceAttr("datadog_synthetics_test_run.foo", "results.#", "1"),
+					resource.TestCheckResourceAttrPair("datadog_synthetics_test_run.foo", "results.0.public_id", "datadog_synthetics_test.foo", "id"),
+					resource.TestCheckResourceAttr("datadog_synthetics_test_run.foo", "results.0.location", "aws:eu-central-1"),
+					resource.TestCheckResourceAttr("datadog_synthetics_test_run.foo", "results.0.status", "passed"),
+				),
+			},
+			{
+				// Changing the triggers runs the tests again
+				Config: testAccCheckDatadogSyntheticsTestRunConfig(uniq, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("datadog_synthetics_test_run.foo", "triggers.version", "2"),
+					resource.TestCheckResourceAttr("datadog_synthetics_test_run.foo", "status", "passed"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogSyntheticsTestRunConfig(uniq string, version string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test" "foo" {
+	type      = "api"
+	subtype   = "http"
+	name      = "%s"
+	status    = "live"
+	locations = ["aws:eu-central-1"]
+
+	request_definition {
+		method = "GET"
+		url    = "https://www.datadoghq.com"
+	}
+
+	assertion {
+		type     = "statusCode"
+		operator = "is"
+		target   = "200"
+	}
+
+	options_list {
+		tick_every = 900
+	}
+}
+
+resource "datadog_synthetics_test_run" "foo" {
+	triggers = {
+		version = "%s"
+	}
+	timeout = 300
+
+	test {
+		public_id = datadog_synthetics_test.foo.id
+		locations = ["aws:eu-central-1"]
+	}
+}`, uniq, version)
+}
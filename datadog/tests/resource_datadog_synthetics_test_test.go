@@ -561,6 +561,21 @@ func TestAccDatadogSyntheticsTestBrowserUserLocator_NoElement(t *testing.T) {
 	})
 }
 
+func TestAccDatadogSyntheticsMobileTest_WaitStepWithoutParams(t *testing.T) {
+	t.Parallel()
+	ctx, accProviders := testAccProviders(context.Background(), t)
+	accProvider := testAccProvider(t, accProviders)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: accProviders,
+		CheckDestroy:      testSyntheticsTestIsDestroyed(accProvider),
+		Steps: []resource.TestStep{
+			createSyntheticsMobileTestWaitStepWithoutParamsStep(ctx, accProvider, t),
+		},
+	})
+}
+
 func TestAccDatadogSyntheticsTestMultistepApi_Basic(t *testing.T) {
 	t.Parallel()
 	ctx, accProviders := testAccProviders(context.Background(), t)
@@ -926,7 +941,9 @@ func createSyntheticsAPITestStep(ctx context.Context, accProvider func() (*schem
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.foo", "request_basicauth.0.username", "ntlm-username"),
 			resource.TestCheckResourceAttr(
-				"datadog_synthetics_test.foo", "request_basicauth.0.password", "ntlm-password"),
+				"datadog_synthetics_test.foo", "request_basicauth.0.password", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.foo", "request_basicauth.0.password_hash", utils.ConvertToSha256("ntlm-password")),
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.foo", "request_basicauth.0.domain", "ntlm-domain"),
 			resource.TestCheckResourceAttr(
@@ -1350,7 +1367,9 @@ func createSyntheticsAPITestStepNewAssertionsOptions(ctx context.Context, accPro
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.bar", "request_basicauth.0.username", "admin"),
 			resource.TestCheckResourceAttr(
-				"datadog_synthetics_test.bar", "request_basicauth.0.password", "secret"),
+				"datadog_synthetics_test.bar", "request_basicauth.0.password", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.bar", "request_basicauth.0.password_hash", utils.ConvertToSha256("secret")),
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.bar", "request_client_certificate.0.cert.0.content", utils.ConvertToSha256("content-certificate")),
 			resource.TestCheckResourceAttr(
@@ -2586,6 +2605,62 @@ resource "datadog_synthetics_test" "icmp" {
 }`, uniq)
 }
 
+func createSyntheticsMobileTestWaitStepWithoutParamsStep(ctx context.Context, accProvider func() (*schema.Provider, error), t *testing.T) resource.TestStep {
+	testName := uniqueEntityName(ctx, t)
+	return resource.TestStep{
+		Config: createSyntheticsMobileTestWaitStepWithoutParamsConfig(testName),
+		Check: resource.ComposeTestCheckFunc(
+			testSyntheticsTestExists(accProvider),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.mobile", "type", "mobile"),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.mobile", "name", testName),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.mobile", "mobile_step.#", "2"),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.mobile", "mobile_step.0.type", "wait"),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.mobile", "mobile_step.0.params.0.value", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.mobile", "mobile_step.1.type", "wait"),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.mobile", "mobile_step.1.params.0.value", ""),
+		),
+	}
+}
+
+func createSyntheticsMobileTestWaitStepWithoutParamsConfig(uniq string) string {
+	return fmt.Sprintf(`
+resource "datadog_synthetics_test" "mobile" {
+	type = "mobile"
+	name = "%s"
+	message = "Notify @datadog.user"
+	locations = [ "aws:eu-central-1" ]
+	status = "paused"
+
+	mobile_options_list {
+		tick_every = 3600
+		device_ids = ["synthetics:mobile:device:iphone_15_ios_17"]
+
+		mobile_application {
+			application_id = "ab0e0aed-536d-411a-9a99-5428c27d8f8e"
+			reference_type = "latest"
+		}
+	}
+
+	mobile_step {
+		name = "Wait without params"
+		type = "wait"
+	}
+
+	mobile_step {
+		name = "Wait with empty params"
+		type = "wait"
+		params {}
+	}
+}`, uniq)
+}
+
 func createSyntheticsUDPTestStep(ctx context.Context, accProvider func() (*schema.Provider, error), t *testing.T) resource.TestStep {
 	testName := uniqueEntityName(ctx, t)
 	return resource.TestStep{
@@ -3052,7 +3127,9 @@ func createSyntheticsBrowserTestStep(ctx context.Context, accProvider func() (*s
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.bar", "request_basicauth.0.username", "username"),
 			resource.TestCheckResourceAttr(
-				"datadog_synthetics_test.bar", "request_basicauth.0.password", "password"),
+				"datadog_synthetics_test.bar", "request_basicauth.0.password", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.bar", "request_basicauth.0.password_hash", utils.ConvertToSha256("password")),
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.bar", "request_proxy.#", "1"),
 			resource.TestCheckResourceAttr(
@@ -3647,7 +3724,9 @@ func createSyntheticsBrowserTestBrowserVariablesStep(ctx context.Context, accPro
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.bar", "request_basicauth.0.username", "web-username"),
 			resource.TestCheckResourceAttr(
-				"datadog_synthetics_test.bar", "request_basicauth.0.password", "web-password"),
+				"datadog_synthetics_test.bar", "request_basicauth.0.password", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.bar", "request_basicauth.0.password_hash", utils.ConvertToSha256("web-password")),
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.bar", "device_ids.#", "1"),
 			resource.TestCheckResourceAttr(
@@ -4619,7 +4698,9 @@ func createSyntheticsMultistepAPITest(ctx context.Context, accProvider func() (*
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.multi", "api_step.1.request_basicauth.0.client_id", "client-id"),
 			resource.TestCheckResourceAttr(
-				"datadog_synthetics_test.multi", "api_step.1.request_basicauth.0.client_secret", "client-secret"),
+				"datadog_synthetics_test.multi", "api_step.1.request_basicauth.0.client_secret", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.multi", "api_step.1.request_basicauth.0.client_secret_hash", utils.ConvertToSha256("client-secret")),
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.multi", "api_step.1.request_basicauth.0.scope", "scope"),
 			resource.TestCheckResourceAttr(
@@ -4637,7 +4718,9 @@ func createSyntheticsMultistepAPITest(ctx context.Context, accProvider func() (*
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.client_id", "client-id"),
 			resource.TestCheckResourceAttr(
-				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.client_secret", "client-secret"),
+				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.client_secret", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.client_secret_hash", utils.ConvertToSha256("client-secret")),
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.resource", "resource"),
 			resource.TestCheckResourceAttr(
@@ -4649,7 +4732,9 @@ func createSyntheticsMultistepAPITest(ctx context.Context, accProvider func() (*
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.username", "username"),
 			resource.TestCheckResourceAttr(
-				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.password", "password"),
+				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.password", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.multi", "api_step.2.request_basicauth.0.password_hash", utils.ConvertToSha256("password")),
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.multi", "api_step.3.name", "Fourth api step"),
 			resource.TestCheckResourceAttr(
@@ -4659,7 +4744,9 @@ func createSyntheticsMultistepAPITest(ctx context.Context, accProvider func() (*
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.multi", "api_step.3.request_basicauth.0.username", "username"),
 			resource.TestCheckResourceAttr(
-				"datadog_synthetics_test.multi", "api_step.3.request_basicauth.0.password", "password"),
+				"datadog_synthetics_test.multi", "api_step.3.request_basicauth.0.password", ""),
+			resource.TestCheckResourceAttr(
+				"datadog_synthetics_test.multi", "api_step.3.request_basicauth.0.password_hash", utils.ConvertToSha256("password")),
 			resource.TestCheckResourceAttr(
 				"datadog_synthetics_test.multi", "api_step.4.name", "gRPC health check step"),
 			resource.TestCheckResourceAttr(
@@ -0,0 +1,6 @@
+# Downtimes muting the monitor over the coming weekend
+data "datadog_downtime_coverage" "weekend" {
+  monitor_id = 12345
+  from       = "2024-06-01T00:00:00Z"
+  to         = "2024-06-03T00:00:00Z"
+}
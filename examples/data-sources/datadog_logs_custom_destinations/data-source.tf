@@ -0,0 +1,9 @@
+# Custom destinations that can't receive logs because of their configuration
+data "datadog_logs_custom_destinations" "all" {}
+
+output "misconfigured_custom_destinations" {
+  value = {
+    for destination in data.datadog_logs_custom_destinations.all.destinations :
+    destination.name => destination.errors if destination.status == "misconfigured"
+  }
+}
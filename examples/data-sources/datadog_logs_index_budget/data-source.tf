@@ -0,0 +1,14 @@
+# Volume indexed by the main index over the last 14 days, and its projected retention cost
+data "datadog_logs_index_budget" "main" {
+  index_names = ["main"]
+  days        = 14
+
+  price_per_million_events = {
+    "15" = 1.70
+    "30" = 2.50
+  }
+}
+
+output "main_projected_monthly_retention_cost" {
+  value = data.datadog_logs_index_budget.main.indexes[0].projected_monthly_retention_cost
+}
@@ -0,0 +1,15 @@
+# Check which index captures the nginx error logs of production, following the configured order
+data "datadog_logs_index_routing" "nginx_errors" {
+  index_names = datadog_logs_index_order.order.indexes
+
+  facets = {
+    source              = "nginx"
+    status              = "error"
+    env                 = "prod"
+    "@http.status_code" = "503"
+  }
+}
+
+output "nginx_errors_index" {
+  value = data.datadog_logs_index_routing.nginx_errors.index
+}
@@ -0,0 +1,15 @@
+# Run a sample log through the ordered pipelines, with the local definition of one of them
+data "datadog_logs_pipeline_simulation" "nginx" {
+  pipeline_ids = datadog_logs_pipeline_order.order.pipelines
+  pipelines    = [jsonencode(datadog_logs_custom_pipeline.nginx)]
+
+  log = jsonencode({
+    message  = "GET /api/v1/users 503"
+    ddsource = "nginx"
+    ddtags   = "env:prod"
+  })
+}
+
+output "nginx_status" {
+  value = jsondecode(data.datadog_logs_pipeline_simulation.nginx.result).status
+}
@@ -0,0 +1,14 @@
+data "datadog_metric_tag_usage" "requests" {
+  metric_name = "example.terraform.count.metric"
+}
+
+# Keep only the tags queried by dashboards and monitors
+resource "datadog_metric_tag_configuration" "requests" {
+  metric_name = data.datadog_metric_tag_usage.requests.metric_name
+  metric_type = "count"
+  tags        = data.datadog_metric_tag_usage.requests.queried_tag_keys
+}
+
+output "series_saved" {
+  value = data.datadog_metric_tag_usage.requests.estimated_series - data.datadog_metric_tag_usage.requests.queried_tags_estimated_series
+}
@@ -0,0 +1,4 @@
+data "datadog_monitors_search" "alerting_p1" {
+  query = "status:alert muted:false priority:1 team:sre"
+  sort  = "name,asc"
+}
@@ -0,0 +1,13 @@
+# Convert a browser test recorded in the Datadog UI
+data "datadog_synthetics_browser_test_hcl" "recorded" {
+  public_id = "abc-def-123"
+}
+
+# Convert the JSON export of a browser test
+data "datadog_synthetics_browser_test_hcl" "exported" {
+  json = file("${path.module}/browser_test.json")
+}
+
+output "browser_steps" {
+  value = data.datadog_synthetics_browser_test_hcl.recorded.browser_step_hcl
+}
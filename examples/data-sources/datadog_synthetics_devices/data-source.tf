@@ -0,0 +1,9 @@
+# All the Chrome and Firefox devices
+data "datadog_synthetics_devices" "chrome_firefox" {
+  browsers = ["chrome", "firefox"]
+}
+
+resource "datadog_synthetics_test" "test_browser" {
+  type       = "browser"
+  device_ids = data.datadog_synthetics_devices.chrome_firefox.device_ids
+}
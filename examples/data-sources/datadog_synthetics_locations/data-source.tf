@@ -4,3 +4,29 @@ resource "datadog_synthetics_test" "test_api" {
   type      = "api"
   locations = keys(data.datadog_synthetics_locations.test.locations)
 }
+
+# All managed AWS locations in Europe, plus the private locations of the team with running workers
+data "datadog_synthetics_locations" "aws_europe" {
+  provider_filter = ["aws"]
+  region_filter   = ["europe"]
+}
+
+data "datadog_synthetics_locations" "team_private" {
+  type_filter           = "private"
+  private_location_tags = ["team:checkout"]
+  available_only        = true
+}
+
+resource "datadog_synthetics_test" "test_browser" {
+  type = "browser"
+  locations = concat(
+    data.datadog_synthetics_locations.aws_europe.location_ids,
+    data.datadog_synthetics_locations.team_private.location_ids,
+  )
+  device_ids = data.datadog_synthetics_devices.desktop.device_ids
+}
+
+data "datadog_synthetics_devices" "desktop" {
+  browsers     = ["chrome", "firefox"]
+  form_factors = ["laptop_large"]
+}
@@ -0,0 +1,4 @@
+data "datadog_synthetics_private_location_status" "private_location" {
+  private_location_id = "pl:private-location-name-abcdef123456"
+  lookback            = 1800
+}
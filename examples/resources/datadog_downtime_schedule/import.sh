@@ -1,2 +1,9 @@
+terraform import datadog_downtime_schedule.new_list "00e000000-0000-1234-0000-000000000000"
 
-terraform import datadog_downtime_schedule.new_list "00e000000-0000-1234-0000-000000000000"
\ No newline at end of file
+# A `datadog_downtime` can be migrated without canceling it by importing its ID with the `legacy:` prefix,
+# then removing the `datadog_downtime` resource from the state with `terraform state rm`. The import fails
+# unless exactly one active downtime schedule has the same scope, message, monitors and start.
+terraform import datadog_downtime_schedule.migrated "legacy:1234567890"
+
+# Otherwise, find the ID of the downtime schedule backing the `datadog_downtime` in the Datadog UI or with
+# the v2 downtimes API, and import it directly.
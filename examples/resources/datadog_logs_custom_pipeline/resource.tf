@@ -146,3 +146,29 @@ resource "datadog_logs_custom_pipeline" "sample_pipeline" {
     }
   }
 }
+
+# Check at plan time that the samples are parsed by the grok rules
+resource "datadog_logs_custom_pipeline" "validated_pipeline" {
+  filter {
+    query = "source:nginx"
+  }
+  name                  = "validated pipeline"
+  is_enabled            = true
+  validate_grok_parsers = true
+  processor {
+    grok_parser {
+      samples = ["john connected on 10.0.0.1:443"]
+      source  = "message"
+      grok {
+        support_rules = "_client %%{ip:network.client.ip}:%%{port:network.client.port}"
+        match_rules   = "connection %%{word:user} connected on %%{_client}"
+      }
+      name       = "connection parser"
+      is_enabled = true
+    }
+  }
+}
+
+output "grok_parser_results" {
+  value = datadog_logs_custom_pipeline.validated_pipeline.grok_parser_results
+}
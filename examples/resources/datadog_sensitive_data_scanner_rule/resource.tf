@@ -32,6 +32,14 @@ resource "datadog_sensitive_data_scanner_rule" "myrule" {
     keywords        = ["cc", "credit card"]
     character_count = 30
   }
+  test_samples {
+    sample       = "credit card: myregex"
+    should_match = true
+  }
+  test_samples {
+    sample       = "myregex without keyword"
+    should_match = false
+  }
   priority = 1
 }
 
@@ -4,3 +4,11 @@ resource "datadog_synthetics_global_variable" "test_variable" {
   tags        = ["foo:bar", "env:test"]
   value       = "variable-value"
 }
+
+# Keep the value out of the state, and update it by bumping `value_version`
+resource "datadog_synthetics_global_variable" "api_token" {
+  name          = "API_TOKEN"
+  secure        = true
+  value_wo      = var.api_token
+  value_version = 2
+}
@@ -0,0 +1,11 @@
+# Send the API token again from the secret manager every 30 days
+resource "datadog_synthetics_global_variable_rotation" "api_token" {
+  rotation_days = 30
+}
+
+resource "datadog_synthetics_global_variable" "api_token" {
+  name          = "API_TOKEN"
+  secure        = true
+  value_wo      = data.vault_generic_secret.api_token.data["value"]
+  value_version = datadog_synthetics_global_variable_rotation.api_token.value_version
+}
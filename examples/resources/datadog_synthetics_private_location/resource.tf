@@ -3,3 +3,9 @@ resource "datadog_synthetics_private_location" "private_location" {
   description = "Description of the private location"
   tags        = ["foo:bar", "env:test"]
 }
+
+# Store the worker configuration as a Kubernetes Secret
+output "private_location_worker_secret" {
+  value     = datadog_synthetics_private_location.private_location.kubernetes_secret
+  sensitive = true
+}
@@ -282,6 +282,69 @@ EOT
 }
 
 
+# Example Usage (Multistep API test with a shared step)
+# Share the authentication step across tests with `step_template`. Variables extracted by a step,
+# such as `{{ TOKEN }}`, can only be used by the following steps, which `check_variables` verifies during plan.
+locals {
+  login_step = jsonencode({
+    subtype = "http"
+    request = {
+      method = "POST"
+      url    = "https://www.example.org/login"
+      body   = "{\"user\": \"{{ USERNAME }}\"}"
+    }
+    assertions = [{
+      type     = "statusCode"
+      operator = "is"
+      target   = 200
+    }]
+    extractedValues = [{
+      name   = "TOKEN"
+      type   = "http_body"
+      parser = { type = "json_path", value = "$.token" }
+    }]
+  })
+}
+
+resource "datadog_synthetics_test" "test_multi_step_shared_login" {
+  name            = "Multistep API test with a shared login"
+  type            = "api"
+  subtype         = "multi"
+  status          = "live"
+  locations       = ["aws:eu-central-1"]
+  check_variables = true
+
+  config_variable {
+    type    = "text"
+    name    = "USERNAME"
+    example = "synthetics"
+  }
+
+  api_step {
+    name          = "Login"
+    step_template = local.login_step
+  }
+
+  api_step {
+    name = "Get the profile"
+
+    assertion {
+      type     = "statusCode"
+      operator = "is"
+      target   = "200"
+    }
+
+    request_definition {
+      method = "GET"
+      url    = "https://www.example.org/profile"
+    }
+
+    request_headers = {
+      Authorization = "Bearer {{ TOKEN }}"
+    }
+  }
+}
+
 # Example Usage (Synthetics Browser test)
 # Create a new Datadog Synthetics Browser test starting on https://www.example.org
 resource "datadog_synthetics_test" "test_browser" {
@@ -468,3 +531,74 @@ resource "datadog_synthetics_test" "test_grpc_health" {
     tick_every = 900
   }
 }
+
+
+# Example Usage (Mobile application test)
+# Create a new Datadog mobile application test opening the application, typing
+# into the search field and checking the results screen
+resource "datadog_synthetics_test" "test_mobile" {
+  name      = "Mobile application test"
+  type      = "mobile"
+  status    = "paused"
+  message   = "Notify @datadog.user"
+  locations = ["aws:eu-central-1"]
+  tags      = ["foo:bar", "foo", "env:test"]
+
+  config_variable {
+    type    = "text"
+    name    = "SEARCH_TERM"
+    example = "datadog"
+    pattern = "datadog"
+  }
+
+  mobile_options_list {
+    tick_every = 3600
+    device_ids = ["synthetics:mobile:device:iphone_15_ios_17"]
+
+    mobile_application {
+      application_id = "ab0e0aed-536d-411a-9a99-5428c27d8f8e"
+      reference_type = "latest"
+    }
+
+    retry {
+      count    = 1
+      interval = 300
+    }
+  }
+
+  mobile_step {
+    name = "Tap on the search field"
+    type = "tap"
+    params {
+      element {
+        context      = "NATIVE_APP"
+        context_type = "native"
+        view_name    = "UISearchBar"
+        user_locator {
+          fail_test_on_cannot_locate = true
+          values {
+            type  = "accessibility-id"
+            value = "search"
+          }
+        }
+      }
+    }
+  }
+
+  mobile_step {
+    name = "Type the search term"
+    type = "typeText"
+    params {
+      value      = "{{ SEARCH_TERM }}"
+      with_enter = true
+    }
+  }
+
+  mobile_step {
+    name = "Check the results screen"
+    type = "assertScreenContains"
+    params {
+      value = "Results"
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import datadog_synthetics_test_json.test_json abc-def-123
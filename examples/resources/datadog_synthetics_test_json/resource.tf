@@ -0,0 +1,32 @@
+resource "datadog_synthetics_test_json" "test_json" {
+  test = jsonencode({
+    name    = "An API test on example.org"
+    type    = "api"
+    subtype = "http"
+    status  = "live"
+    message = "Notify @datadog.user"
+    tags    = ["foo:bar", "env:test"]
+    config = {
+      request = {
+        method = "GET"
+        url    = "https://www.example.org"
+        basicAuth = {
+          type     = "web"
+          username = "admin"
+          password = var.example_password
+        }
+      }
+      assertions = [
+        {
+          type     = "statusCode"
+          operator = "is"
+          target   = 200
+        }
+      ]
+    }
+    locations = ["aws:eu-central-1"]
+    options = {
+      tick_every = 900
+    }
+  })
+}
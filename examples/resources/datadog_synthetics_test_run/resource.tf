@@ -0,0 +1,19 @@
+# Run the smoke tests after each deployment of the application, and fail the apply when they fail
+resource "datadog_synthetics_test_run" "smoke" {
+  triggers = {
+    app_version = var.app_version
+  }
+  timeout = 900
+
+  test {
+    public_id = datadog_synthetics_test.homepage.id
+    start_url = "https://staging.example.com"
+  }
+
+  test {
+    public_id = datadog_synthetics_test.checkout.id
+    variables = {
+      USERNAME = "smoke-test-user"
+    }
+  }
+}